@@ -95,9 +95,47 @@ func newGenCmd() *ffcli.Command {
 			"or custom mapping like 'apis=APIs'")
 	goTypes := flags.Strings(fset, "go-type", nil,
 		"custom type mapping from Postgres to fully qualified Go type, "+
-			"like 'device_type=github.com/jschaf/pggen.DeviceType'")
+			"like 'device_type=github.com/jschaf/pggen.DeviceType'; "+
+			"the Postgres type may be schema-qualified, like 'billing.status=string'; "+
+			"a '?' suffix overrides only nullable values, like 'int8?=*int'; "+
+			"the Go type may be any type expression, like 'hstore=map[string]*string' "+
+			"or 'int8?=github.com/x/opt.Option[int]'")
+	goTypePresets := flags.Strings(fset, "go-type-preset", nil,
+		"named set of Go types for builtin Postgres types: "+
+			"'stdlib' like time.Duration for interval and netip.Prefix for inet (pgx only), "+
+			"'google-uuid', 'shopspring-decimal', or 'pgtype' like pgtype.Text for text (pgx v4 only); "+
+			"later presets take precedence, and --go-type takes precedence over all presets")
+	goColumnTypes := flags.Strings(fset, "go-column-type", nil,
+		"custom type mapping from a column to fully qualified Go type, "+
+			"like 'users.settings=example.com/user.Settings' for a table column, "+
+			"or 'FindUser.settings=example.com/user.Settings' for a query column or param")
+	schemaNaming := fset.String("schema-naming", "collision",
+		"how to name Go types for Postgres types with the same name in different schemas: "+
+			"'collision' prefixes the schema only for colliding names, like BillingStatus, "+
+			"'always' prefixes the schema for all types outside the public schema, "+
+			"'never' errors on colliding names")
+	nullableCompositeFields := fset.Bool("nullable-composite-fields", false,
+		"use nullable Go types for all composite type fields, even fields backed by a NOT NULL "+
+			"table column; use if a query returns a whole-row NULL, like from an outer join")
+	pgxVersion := fset.Int("pgx-version", 4,
+		"major version of pgx for the generated code, either 4 or 5; "+
+			"pgx v5 code requires calling the generated RegisterTypes on each connection")
+	driver := fset.String("driver", "pgx",
+		"Go database API for the generated code, either 'pgx' or 'database/sql'; "+
+			"database/sql code works with any Postgres driver, like the pgx stdlib driver or lib/pq")
+	nullableStyles := flags.Strings(fset, "nullable-style", nil,
+		"Go type for nullable builtin Postgres types: 'pgtype' like pgtype.Text (pgx v4 only), "+
+			"'pointer' like *string, 'sqlnull' like sql.Null[string], or 'generic' like a generated Null[string]; "+
+			"repeat with a Postgres type to set the style for one type, like 'varchar=pointer'")
 	inlineParamCount := fset.Int("inline-param-count", 2,
 		"number of params (inclusive) to inline when calling querier methods; 0 always generates a struct")
+	protoGoPackages := flags.Strings(fset, "proto-go-package", nil,
+		"Go package of the code generated by protoc-gen-go for a protobuf package, "+
+			"like 'foo.bar=example.com/foobarpb'; used to find the Go struct for a proto-type query pragma")
+	checkGoTypes := fset.Bool("check-go-types", false,
+		"check that each Go type from --go-type, --go-column-type, and go-type pragmas exists "+
+			"and implements the interfaces the generated code needs; loads the Go packages "+
+			"from the output dir, so the output dir must be in a Go module")
 	goSubCmd := &ffcli.Command{
 		Name:       "go",
 		ShortUsage: "pggen gen go --query-glob glob [--schema-glob <glob>]... [flags]",
@@ -112,6 +150,12 @@ func newGenCmd() *ffcli.Command {
 			if len(*queryGlobs) == 0 {
 				return fmt.Errorf("pggen gen go: at least one file in --query-glob must match")
 			}
+			if *pgxVersion != 4 && *pgxVersion != 5 {
+				return fmt.Errorf("pggen gen go: --pgx-version must be 4 or 5; got %d", *pgxVersion)
+			}
+			if *driver != "pgx" && *driver != "database/sql" {
+				return fmt.Errorf("pggen gen go: --driver must be 'pgx' or 'database/sql'; got %q", *driver)
+			}
 			queries, err := expandSortGlobs(*queryGlobs)
 			if err != nil {
 				return err
@@ -120,34 +164,13 @@ func newGenCmd() *ffcli.Command {
 			if err != nil {
 				return err
 			}
-			// Deduce output directory.
-			outDir := *outputDir
-			if outDir == "" {
-				for _, file := range queries {
-					dir := filepath.Dir(file)
-					if outDir != "" && dir != outDir {
-						return fmt.Errorf("cannot deduce output dir because query files use different dirs; " +
-							"specify explicitly with --output-dir")
-					}
-					outDir = dir
-				}
+			outDir, err := deduceOutputDir(*outputDir, queries)
+			if err != nil {
+				return err
 			}
-
-			outDir, _ = filepath.Abs(outDir)
-
-			// Parse two acronym formats: "--acronym api" and "--acronym oids=OIDs"
-			acros := make(map[string]string)
-			for _, acro := range *acronyms {
-				ss := strings.SplitN(acro, "=", 2)
-				word := ss[0]
-				if word != strings.ToLower(word) {
-					return fmt.Errorf("acronym %q should be lower case", word)
-				}
-				replacement := strings.ToUpper(word)
-				if len(ss) > 1 {
-					replacement = ss[1]
-				}
-				acros[word] = replacement
+			acros, err := parseAcronyms(*acronyms)
+			if err != nil {
+				return err
 			}
 
 			typeOverrides := make(map[string]string, len(*goTypes))
@@ -159,17 +182,56 @@ func newGenCmd() *ffcli.Command {
 				typeOverrides[ss[0]] = ss[1]
 			}
 
+			columnTypeOverrides := make(map[string]string, len(*goColumnTypes))
+			for _, colAssoc := range *goColumnTypes {
+				col, goType, ok := strings.Cut(colAssoc, "=")
+				if !ok || strings.Contains(goType, "=") {
+					return fmt.Errorf("--go-column-type must have format <column>=<goType>; got %s", colAssoc)
+				}
+				columnTypeOverrides[col] = goType
+			}
+
+			protoGoPkgs := make(map[string]string, len(*protoGoPackages))
+			for _, pkgAssoc := range *protoGoPackages {
+				protoPkg, goPkg, ok := strings.Cut(pkgAssoc, "=")
+				if !ok || protoPkg == "" || goPkg == "" {
+					return fmt.Errorf("--proto-go-package must have format <protoPackage>=<goPackage>; got %s", pkgAssoc)
+				}
+				protoGoPkgs[protoPkg] = goPkg
+			}
+
+			nullableStyle := ""
+			nullableTypeStyles := make(map[string]string, len(*nullableStyles))
+			for _, style := range *nullableStyles {
+				pgType, typeStyle, ok := strings.Cut(style, "=")
+				if !ok {
+					nullableStyle = style
+					continue
+				}
+				nullableTypeStyles[pgType] = typeStyle
+			}
+
 			// Codegen.
 			err = pggen.Generate(pggen.GenerateOptions{
-				Language:         pggen.LangGo,
-				ConnString:       *postgresConn,
-				SchemaFiles:      schemas,
-				QueryFiles:       queries,
-				OutputDir:        outDir,
-				Acronyms:         acros,
-				TypeOverrides:    typeOverrides,
-				LogLevel:         slog.LevelInfo,
-				InlineParamCount: *inlineParamCount,
+				Language:                pggen.LangGo,
+				ConnString:              *postgresConn,
+				SchemaFiles:             schemas,
+				QueryFiles:              queries,
+				OutputDir:               outDir,
+				Acronyms:                acros,
+				TypeOverrides:           typeOverrides,
+				TypePresets:             *goTypePresets,
+				ColumnTypeOverrides:     columnTypeOverrides,
+				SchemaNaming:            *schemaNaming,
+				NullableCompositeFields: *nullableCompositeFields,
+				PgxVersion:              *pgxVersion,
+				Driver:                  *driver,
+				NullableStyle:           nullableStyle,
+				NullableTypeStyles:      nullableTypeStyles,
+				LogLevel:                slog.LevelInfo,
+				InlineParamCount:        *inlineParamCount,
+				CheckGoTypes:            *checkGoTypes,
+				ProtoGoPackages:         protoGoPkgs,
 			})
 			if err != nil {
 				return err
@@ -185,10 +247,10 @@ func newGenCmd() *ffcli.Command {
 	}
 	cmd := &ffcli.Command{
 		Name:        "gen",
-		ShortUsage:  "pggen gen (go|<lang>) [options...]",
+		ShortUsage:  "pggen gen (go|proto) [options...]",
 		ShortHelp:   "generates code in specific language for Postgres query files",
 		FlagSet:     nil,
-		Subcommands: []*ffcli.Command{goSubCmd},
+		Subcommands: []*ffcli.Command{goSubCmd, newGenProtoCmd()},
 	}
 	cmd.Exec = func(ctx context.Context, args []string) error {
 		fmt.Println(ffcli.DefaultUsageFunc(cmd))
@@ -198,6 +260,119 @@ func newGenCmd() *ffcli.Command {
 	return cmd
 }
 
+func newGenProtoCmd() *ffcli.Command {
+	fset := flag.NewFlagSet("proto", flag.ExitOnError)
+	outputDir := fset.String("output-dir", "",
+		"where to write generated .proto files; defaults to same directory as query files")
+	postgresConn := fset.String("postgres-connection", "",
+		`optional connection string to a postgres database, like: `+
+			`"user=postgres host=localhost dbname=pggen"`)
+	queryGlobs := flags.Strings(fset, "query-glob", nil,
+		"generate .proto files for all SQL files that match glob, like 'queries/**/*.sql'")
+	schemaGlobs := flags.Strings(fset, "schema-glob", nil,
+		"create schema in Postgres from all sql, sql.gz, or shell "+
+			"scripts (*.sh) that match a glob, like 'migrations/*.sql'")
+	acronyms := flags.Strings(fset, "acronym", nil,
+		"lowercase acronym that should convert to all caps in message names like 'api', "+
+			"or custom mapping like 'apis=APIs'")
+	protoPackage := fset.String("proto-package", "",
+		"protobuf package for the generated files, like 'acme.users.v1'; defaults to the output dir name")
+	goPackageOption := fset.String("go-package-option", "",
+		"go_package option for the generated files, like 'example.com/gen/userpb'")
+	return &ffcli.Command{
+		Name:       "proto",
+		ShortUsage: "pggen gen proto --query-glob glob [--schema-glob <glob>]... [flags]",
+		ShortHelp:  "generates .proto message definitions for the rows of Postgres query files",
+		FlagSet:    fset,
+		LongHelp: texts.Dedent(`
+			pggen generates a .proto file for each query file with a message for the
+			output row of each :one and :many query. The first query file also
+			declares an enum for each Postgres enum and a message for each composite
+			type. pggen records field numbers in ` + pggen.ProtoNumbersFile + ` in
+			the output directory so that fields keep their numbers; commit the file
+			with the generated .proto files.
+		`),
+		Exec: func(ctx context.Context, args []string) error {
+			if len(*queryGlobs) == 0 {
+				return fmt.Errorf("pggen gen proto: at least one file in --query-glob must match")
+			}
+			queries, err := expandSortGlobs(*queryGlobs)
+			if err != nil {
+				return err
+			}
+			schemas, err := expandSortGlobs(*schemaGlobs)
+			if err != nil {
+				return err
+			}
+			outDir, err := deduceOutputDir(*outputDir, queries)
+			if err != nil {
+				return err
+			}
+			acros, err := parseAcronyms(*acronyms)
+			if err != nil {
+				return err
+			}
+			err = pggen.Generate(pggen.GenerateOptions{
+				Language:             pggen.LangProto,
+				ConnString:           *postgresConn,
+				SchemaFiles:          schemas,
+				QueryFiles:           queries,
+				OutputDir:            outDir,
+				Acronyms:             acros,
+				ProtoPackage:         *protoPackage,
+				ProtoGoPackageOption: *goPackageOption,
+				LogLevel:             slog.LevelInfo,
+			})
+			if err != nil {
+				return err
+			}
+			fileDesc := "files"
+			if len(queries) == 1 {
+				fileDesc = "file"
+			}
+			fmt.Printf("generated proto for %d query %s\n", len(queries), fileDesc)
+			return nil
+		},
+	}
+}
+
+// deduceOutputDir returns the absolute path of outputDir or, if empty, of the
+// directory containing all query files.
+func deduceOutputDir(outputDir string, queries []string) (string, error) {
+	outDir := outputDir
+	if outDir == "" {
+		for _, file := range queries {
+			dir := filepath.Dir(file)
+			if outDir != "" && dir != outDir {
+				return "", fmt.Errorf("cannot deduce output dir because query files use different dirs; " +
+					"specify explicitly with --output-dir")
+			}
+			outDir = dir
+		}
+	}
+	outDir, _ = filepath.Abs(outDir)
+	return outDir, nil
+}
+
+// parseAcronyms parses two acronym formats: "--acronym api" and
+// "--acronym oids=OIDs".
+func parseAcronyms(acronyms []string) (map[string]string, error) {
+	acros := make(map[string]string)
+	for _, acro := range acronyms {
+		ss := strings.SplitN(acro, "=", 2)
+		word := ss[0]
+		if word != strings.ToLower(word) {
+			return nil, fmt.Errorf("acronym %q should be lower case", word)
+		}
+		replacement := strings.ToUpper(word)
+		if len(ss) > 1 {
+			replacement = ss[1]
+		}
+		acros[word] = replacement
+	}
+	return acros, nil
+}
+
 // expandSortGlobs gets the absolute paths for all files matching globs. Order
 // files lexicographically within each glob but not across all globs. The order
 // of a glob relative to other globs is important for schemas where a schema
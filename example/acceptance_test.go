@@ -42,7 +42,6 @@ func TestExamples(t *testing.T) {
 			args: []string{
 				"--schema-glob", "example/complex_params/schema.sql",
 				"--query-glob", "example/complex_params/query.sql",
-				"--go-type", "int8=int",
 				"--go-type", "int4=int",
 				"--go-type", "text=string",
 			},
@@ -55,6 +54,7 @@ func TestExamples(t *testing.T) {
 				"--go-type", "_bool=[]bool",
 				"--go-type", "bool=bool",
 				"--go-type", "int8=int",
+				"--go-type", "int8?=*int",
 				"--go-type", "int4=int",
 				"--go-type", "text=string",
 				"--go-type", "citext=github.com/jackc/pgtype.Text",
@@ -88,6 +88,7 @@ func TestExamples(t *testing.T) {
 				"--acronym", "oid",
 				"--acronym", "oids=OIDs",
 				"--go-type", "text=string",
+				"--go-type", "text?=string", // catalog names from joins are never null
 				"--go-type", "_int8=[]int",
 				"--go-type", "_text=[]string",
 				"--go-type", "_float4=[]float32",
@@ -100,6 +101,58 @@ func TestExamples(t *testing.T) {
 				"--query-glob", "example/device/query.sql",
 			},
 		},
+		{
+			name: "example/pgx4",
+			args: []string{
+				"--schema-glob", "example/pgx4/schema.sql",
+				"--query-glob", "example/pgx4/query.sql",
+			},
+		},
+		{
+			name: "example/pgx5",
+			args: []string{
+				"--schema-glob", "example/pgx5/schema.sql",
+				"--query-glob", "example/pgx5/query.sql",
+				"--pgx-version", "5",
+			},
+		},
+		{
+			name: "example/ranges",
+			args: []string{
+				"--schema-glob", "example/ranges/schema.sql",
+				"--query-glob", "example/ranges/query.sql",
+			},
+		},
+		{
+			name: "example/multi_array",
+			args: []string{
+				"--schema-glob", "example/multi_array/schema.sql",
+				"--query-glob", "example/multi_array/query.sql",
+				"--go-type", "int4=int32",
+			},
+		},
+		{
+			name: "example/schemas",
+			args: []string{
+				"--schema-glob", "example/schemas/schema.sql",
+				"--query-glob", "example/schemas/query.sql",
+			},
+		},
+		{
+			name: "example/database_sql",
+			args: []string{
+				"--schema-glob", "example/database_sql/schema.sql",
+				"--query-glob", "example/database_sql/query.sql",
+				"--driver", "database/sql",
+			},
+		},
+		{
+			name: "example/group_by",
+			args: []string{
+				"--schema-glob", "example/group_by/schema.sql",
+				"--query-glob", "example/group_by/query.sql",
+			},
+		},
 		{
 			name: "example/erp star glob",
 			args: []string{
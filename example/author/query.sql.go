@@ -14,35 +14,79 @@ import (
 type Querier interface {
 	// FindAuthorById finds one (or zero) authors by ID.
 	FindAuthorByID(ctx context.Context, authorID int32) (FindAuthorByIDRow, error)
+	// QueueFindAuthorByID queues the FindAuthorByID query into batch to send with SendBatch.
+	QueueFindAuthorByID(batch *pgx.Batch, authorID int32)
+	// FindAuthorByIDScan scans the results of a queued FindAuthorByID query.
+	FindAuthorByIDScan(results pgx.BatchResults) (FindAuthorByIDRow, error)
 
 	// FindAuthors finds authors by first name.
 	FindAuthors(ctx context.Context, firstName string) ([]FindAuthorsRow, error)
+	// QueueFindAuthors queues the FindAuthors query into batch to send with SendBatch.
+	QueueFindAuthors(batch *pgx.Batch, firstName string)
+	// FindAuthorsScan scans the results of a queued FindAuthors query.
+	FindAuthorsScan(results pgx.BatchResults) ([]FindAuthorsRow, error)
 
 	// FindAuthorNames finds one (or zero) authors by ID.
 	FindAuthorNames(ctx context.Context, authorID int32) ([]FindAuthorNamesRow, error)
+	// QueueFindAuthorNames queues the FindAuthorNames query into batch to send with SendBatch.
+	QueueFindAuthorNames(batch *pgx.Batch, authorID int32)
+	// FindAuthorNamesScan scans the results of a queued FindAuthorNames query.
+	FindAuthorNamesScan(results pgx.BatchResults) ([]FindAuthorNamesRow, error)
 
 	// FindFirstNames finds one (or zero) authors by ID.
 	FindFirstNames(ctx context.Context, authorID int32) ([]*string, error)
+	// QueueFindFirstNames queues the FindFirstNames query into batch to send with SendBatch.
+	QueueFindFirstNames(batch *pgx.Batch, authorID int32)
+	// FindFirstNamesScan scans the results of a queued FindFirstNames query.
+	FindFirstNamesScan(results pgx.BatchResults) ([]*string, error)
 
 	// DeleteAuthors deletes authors with a first name of "joe".
 	DeleteAuthors(ctx context.Context) (pgconn.CommandTag, error)
+	// QueueDeleteAuthors queues the DeleteAuthors query into batch to send with SendBatch.
+	QueueDeleteAuthors(batch *pgx.Batch)
+	// DeleteAuthorsScan scans the results of a queued DeleteAuthors query.
+	DeleteAuthorsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	// DeleteAuthorsByFirstName deletes authors by first name.
 	DeleteAuthorsByFirstName(ctx context.Context, firstName string) (pgconn.CommandTag, error)
+	// QueueDeleteAuthorsByFirstName queues the DeleteAuthorsByFirstName query into batch to send with SendBatch.
+	QueueDeleteAuthorsByFirstName(batch *pgx.Batch, firstName string)
+	// DeleteAuthorsByFirstNameScan scans the results of a queued DeleteAuthorsByFirstName query.
+	DeleteAuthorsByFirstNameScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	// DeleteAuthorsByFullName deletes authors by the full name.
 	DeleteAuthorsByFullName(ctx context.Context, params DeleteAuthorsByFullNameParams) (pgconn.CommandTag, error)
+	// QueueDeleteAuthorsByFullName queues the DeleteAuthorsByFullName query into batch to send with SendBatch.
+	QueueDeleteAuthorsByFullName(batch *pgx.Batch, params DeleteAuthorsByFullNameParams)
+	// DeleteAuthorsByFullNameScan scans the results of a queued DeleteAuthorsByFullName query.
+	DeleteAuthorsByFullNameScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	// InsertAuthor inserts an author by name and returns the ID.
 	InsertAuthor(ctx context.Context, firstName string, lastName string) (int32, error)
+	// QueueInsertAuthor queues the InsertAuthor query into batch to send with SendBatch.
+	QueueInsertAuthor(batch *pgx.Batch, firstName string, lastName string)
+	// InsertAuthorScan scans the results of a queued InsertAuthor query.
+	InsertAuthorScan(results pgx.BatchResults) (int32, error)
 
 	// InsertAuthorSuffix inserts an author by name and suffix and returns the
 	// entire row.
 	InsertAuthorSuffix(ctx context.Context, params InsertAuthorSuffixParams) (InsertAuthorSuffixRow, error)
+	// QueueInsertAuthorSuffix queues the InsertAuthorSuffix query into batch to send with SendBatch.
+	QueueInsertAuthorSuffix(batch *pgx.Batch, params InsertAuthorSuffixParams)
+	// InsertAuthorSuffixScan scans the results of a queued InsertAuthorSuffix query.
+	InsertAuthorSuffixScan(results pgx.BatchResults) (InsertAuthorSuffixRow, error)
 
 	StringAggFirstName(ctx context.Context, authorID int32) (*string, error)
+	// QueueStringAggFirstName queues the StringAggFirstName query into batch to send with SendBatch.
+	QueueStringAggFirstName(batch *pgx.Batch, authorID int32)
+	// StringAggFirstNameScan scans the results of a queued StringAggFirstName query.
+	StringAggFirstNameScan(results pgx.BatchResults) (*string, error)
 
 	ArrayAggFirstName(ctx context.Context, authorID int32) ([]string, error)
+	// QueueArrayAggFirstName queues the ArrayAggFirstName query into batch to send with SendBatch.
+	QueueArrayAggFirstName(batch *pgx.Batch, authorID int32)
+	// ArrayAggFirstNameScan scans the results of a queued ArrayAggFirstName query.
+	ArrayAggFirstNameScan(results pgx.BatchResults) ([]string, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -57,6 +101,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -113,6 +158,21 @@ func (q *DBQuerier) FindAuthorByID(ctx context.Context, authorID int32) (FindAut
 	return item, nil
 }
 
+// QueueFindAuthorByID implements Querier.QueueFindAuthorByID.
+func (q *DBQuerier) QueueFindAuthorByID(batch *pgx.Batch, authorID int32) {
+	batch.Queue(findAuthorByIDSQL, authorID)
+}
+
+// FindAuthorByIDScan implements Querier.FindAuthorByIDScan.
+func (q *DBQuerier) FindAuthorByIDScan(results pgx.BatchResults) (FindAuthorByIDRow, error) {
+	row := results.QueryRow()
+	var item FindAuthorByIDRow
+	if err := row.Scan(&item.AuthorID, &item.FirstName, &item.LastName, &item.Suffix); err != nil {
+		return item, fmt.Errorf("scan FindAuthorByID batch row: %w", err)
+	}
+	return item, nil
+}
+
 const findAuthorsSQL = `SELECT * FROM author WHERE first_name = $1;`
 
 type FindAuthorsRow struct {
@@ -144,6 +204,32 @@ func (q *DBQuerier) FindAuthors(ctx context.Context, firstName string) ([]FindAu
 	return items, err
 }
 
+// QueueFindAuthors implements Querier.QueueFindAuthors.
+func (q *DBQuerier) QueueFindAuthors(batch *pgx.Batch, firstName string) {
+	batch.Queue(findAuthorsSQL, firstName)
+}
+
+// FindAuthorsScan implements Querier.FindAuthorsScan.
+func (q *DBQuerier) FindAuthorsScan(results pgx.BatchResults) ([]FindAuthorsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindAuthors batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindAuthorsRow{}
+	for rows.Next() {
+		var item FindAuthorsRow
+		if err := rows.Scan(&item.AuthorID, &item.FirstName, &item.LastName, &item.Suffix); err != nil {
+			return nil, fmt.Errorf("scan FindAuthors batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindAuthors batch rows: %w", err)
+	}
+	return items, err
+}
+
 const findAuthorNamesSQL = `SELECT first_name, last_name FROM author ORDER BY author_id = $1;`
 
 type FindAuthorNamesRow struct {
@@ -173,6 +259,32 @@ func (q *DBQuerier) FindAuthorNames(ctx context.Context, authorID int32) ([]Find
 	return items, err
 }
 
+// QueueFindAuthorNames implements Querier.QueueFindAuthorNames.
+func (q *DBQuerier) QueueFindAuthorNames(batch *pgx.Batch, authorID int32) {
+	batch.Queue(findAuthorNamesSQL, authorID)
+}
+
+// FindAuthorNamesScan implements Querier.FindAuthorNamesScan.
+func (q *DBQuerier) FindAuthorNamesScan(results pgx.BatchResults) ([]FindAuthorNamesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindAuthorNames batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindAuthorNamesRow{}
+	for rows.Next() {
+		var item FindAuthorNamesRow
+		if err := rows.Scan(&item.FirstName, &item.LastName); err != nil {
+			return nil, fmt.Errorf("scan FindAuthorNames batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindAuthorNames batch rows: %w", err)
+	}
+	return items, err
+}
+
 const findFirstNamesSQL = `SELECT first_name FROM author ORDER BY author_id = $1;`
 
 // FindFirstNames implements Querier.FindFirstNames.
@@ -197,6 +309,32 @@ func (q *DBQuerier) FindFirstNames(ctx context.Context, authorID int32) ([]*stri
 	return items, err
 }
 
+// QueueFindFirstNames implements Querier.QueueFindFirstNames.
+func (q *DBQuerier) QueueFindFirstNames(batch *pgx.Batch, authorID int32) {
+	batch.Queue(findFirstNamesSQL, authorID)
+}
+
+// FindFirstNamesScan implements Querier.FindFirstNamesScan.
+func (q *DBQuerier) FindFirstNamesScan(results pgx.BatchResults) ([]*string, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindFirstNames batch: %w", err)
+	}
+	defer rows.Close()
+	items := []*string{}
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindFirstNames batch row: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindFirstNames batch rows: %w", err)
+	}
+	return items, err
+}
+
 const deleteAuthorsSQL = `DELETE FROM author WHERE first_name = 'joe';`
 
 // DeleteAuthors implements Querier.DeleteAuthors.
@@ -209,6 +347,20 @@ func (q *DBQuerier) DeleteAuthors(ctx context.Context) (pgconn.CommandTag, error
 	return cmdTag, err
 }
 
+// QueueDeleteAuthors implements Querier.QueueDeleteAuthors.
+func (q *DBQuerier) QueueDeleteAuthors(batch *pgx.Batch) {
+	batch.Queue(deleteAuthorsSQL)
+}
+
+// DeleteAuthorsScan implements Querier.DeleteAuthorsScan.
+func (q *DBQuerier) DeleteAuthorsScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query DeleteAuthors: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteAuthorsByFirstNameSQL = `DELETE FROM author WHERE first_name = $1;`
 
 // DeleteAuthorsByFirstName implements Querier.DeleteAuthorsByFirstName.
@@ -221,6 +373,20 @@ func (q *DBQuerier) DeleteAuthorsByFirstName(ctx context.Context, firstName stri
 	return cmdTag, err
 }
 
+// QueueDeleteAuthorsByFirstName implements Querier.QueueDeleteAuthorsByFirstName.
+func (q *DBQuerier) QueueDeleteAuthorsByFirstName(batch *pgx.Batch, firstName string) {
+	batch.Queue(deleteAuthorsByFirstNameSQL, firstName)
+}
+
+// DeleteAuthorsByFirstNameScan implements Querier.DeleteAuthorsByFirstNameScan.
+func (q *DBQuerier) DeleteAuthorsByFirstNameScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query DeleteAuthorsByFirstName: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteAuthorsByFullNameSQL = `DELETE
 FROM author
 WHERE first_name = $1
@@ -243,6 +409,20 @@ func (q *DBQuerier) DeleteAuthorsByFullName(ctx context.Context, params DeleteAu
 	return cmdTag, err
 }
 
+// QueueDeleteAuthorsByFullName implements Querier.QueueDeleteAuthorsByFullName.
+func (q *DBQuerier) QueueDeleteAuthorsByFullName(batch *pgx.Batch, params DeleteAuthorsByFullNameParams) {
+	batch.Queue(deleteAuthorsByFullNameSQL, params.FirstName, params.LastName, params.Suffix)
+}
+
+// DeleteAuthorsByFullNameScan implements Querier.DeleteAuthorsByFullNameScan.
+func (q *DBQuerier) DeleteAuthorsByFullNameScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query DeleteAuthorsByFullName: %w", err)
+	}
+	return cmdTag, err
+}
+
 const insertAuthorSQL = `INSERT INTO author (first_name, last_name)
 VALUES ($1, $2)
 RETURNING author_id;`
@@ -258,6 +438,21 @@ func (q *DBQuerier) InsertAuthor(ctx context.Context, firstName string, lastName
 	return item, nil
 }
 
+// QueueInsertAuthor implements Querier.QueueInsertAuthor.
+func (q *DBQuerier) QueueInsertAuthor(batch *pgx.Batch, firstName string, lastName string) {
+	batch.Queue(insertAuthorSQL, firstName, lastName)
+}
+
+// InsertAuthorScan implements Querier.InsertAuthorScan.
+func (q *DBQuerier) InsertAuthorScan(results pgx.BatchResults) (int32, error) {
+	row := results.QueryRow()
+	var item int32
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan InsertAuthor batch row: %w", err)
+	}
+	return item, nil
+}
+
 const insertAuthorSuffixSQL = `INSERT INTO author (first_name, last_name, suffix)
 VALUES ($1, $2, $3)
 RETURNING author_id, first_name, last_name, suffix;`
@@ -286,6 +481,21 @@ func (q *DBQuerier) InsertAuthorSuffix(ctx context.Context, params InsertAuthorS
 	return item, nil
 }
 
+// QueueInsertAuthorSuffix implements Querier.QueueInsertAuthorSuffix.
+func (q *DBQuerier) QueueInsertAuthorSuffix(batch *pgx.Batch, params InsertAuthorSuffixParams) {
+	batch.Queue(insertAuthorSuffixSQL, params.FirstName, params.LastName, params.Suffix)
+}
+
+// InsertAuthorSuffixScan implements Querier.InsertAuthorSuffixScan.
+func (q *DBQuerier) InsertAuthorSuffixScan(results pgx.BatchResults) (InsertAuthorSuffixRow, error) {
+	row := results.QueryRow()
+	var item InsertAuthorSuffixRow
+	if err := row.Scan(&item.AuthorID, &item.FirstName, &item.LastName, &item.Suffix); err != nil {
+		return item, fmt.Errorf("scan InsertAuthorSuffix batch row: %w", err)
+	}
+	return item, nil
+}
+
 const stringAggFirstNameSQL = `SELECT string_agg(first_name, ',') AS names FROM author WHERE author_id = $1;`
 
 // StringAggFirstName implements Querier.StringAggFirstName.
@@ -299,6 +509,21 @@ func (q *DBQuerier) StringAggFirstName(ctx context.Context, authorID int32) (*st
 	return item, nil
 }
 
+// QueueStringAggFirstName implements Querier.QueueStringAggFirstName.
+func (q *DBQuerier) QueueStringAggFirstName(batch *pgx.Batch, authorID int32) {
+	batch.Queue(stringAggFirstNameSQL, authorID)
+}
+
+// StringAggFirstNameScan implements Querier.StringAggFirstNameScan.
+func (q *DBQuerier) StringAggFirstNameScan(results pgx.BatchResults) (*string, error) {
+	row := results.QueryRow()
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan StringAggFirstName batch row: %w", err)
+	}
+	return item, nil
+}
+
 const arrayAggFirstNameSQL = `SELECT array_agg(first_name) AS names FROM author WHERE author_id = $1;`
 
 // ArrayAggFirstName implements Querier.ArrayAggFirstName.
@@ -312,6 +537,21 @@ func (q *DBQuerier) ArrayAggFirstName(ctx context.Context, authorID int32) ([]st
 	return item, nil
 }
 
+// QueueArrayAggFirstName implements Querier.QueueArrayAggFirstName.
+func (q *DBQuerier) QueueArrayAggFirstName(batch *pgx.Batch, authorID int32) {
+	batch.Queue(arrayAggFirstNameSQL, authorID)
+}
+
+// ArrayAggFirstNameScan implements Querier.ArrayAggFirstNameScan.
+func (q *DBQuerier) ArrayAggFirstNameScan(results pgx.BatchResults) ([]string, error) {
+	row := results.QueryRow()
+	item := []string{}
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan ArrayAggFirstName batch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
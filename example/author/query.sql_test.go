@@ -224,6 +224,33 @@ func TestNewQuerier_ArrayAggFirstName(t *testing.T) {
 	})
 }
 
+func TestNewQuerier_Batch(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	washingtonID := insertAuthor(t, q, "george", "washington")
+
+	batch := &pgx.Batch{}
+	q.QueueFindAuthorByID(batch, adamsID)
+	q.QueueFindAuthors(batch, "george")
+	q.QueueDeleteAuthorsByFirstName(batch, "george")
+	results := conn.SendBatch(t.Context(), batch)
+	defer results.Close()
+
+	author, err := q.FindAuthorByIDScan(results)
+	require.NoError(t, err)
+	assert.Equal(t, FindAuthorByIDRow{AuthorID: adamsID, FirstName: "john", LastName: "adams"}, author)
+
+	authors, err := q.FindAuthorsScan(results)
+	require.NoError(t, err)
+	assert.Equal(t, []FindAuthorsRow{{AuthorID: washingtonID, FirstName: "george", LastName: "washington"}}, authors)
+
+	tag, err := q.DeleteAuthorsByFirstNameScan(results)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tag.RowsAffected())
+}
+
 func insertAuthor(t *testing.T, q *DBQuerier, first, last string) int32 {
 	t.Helper()
 	authorID, err := q.InsertAuthor(t.Context(), first, last)
@@ -13,14 +13,34 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	ParamArrayInt(ctx context.Context, ints []int) ([]int, error)
+	// QueueParamArrayInt queues the ParamArrayInt query into batch to send with SendBatch.
+	QueueParamArrayInt(batch *pgx.Batch, ints []int)
+	// ParamArrayIntScan scans the results of a queued ParamArrayInt query.
+	ParamArrayIntScan(results pgx.BatchResults) ([]int, error)
 
 	ParamNested1(ctx context.Context, dimensions Dimensions) (Dimensions, error)
+	// QueueParamNested1 queues the ParamNested1 query into batch to send with SendBatch.
+	QueueParamNested1(batch *pgx.Batch, dimensions Dimensions)
+	// ParamNested1Scan scans the results of a queued ParamNested1 query.
+	ParamNested1Scan(results pgx.BatchResults) (Dimensions, error)
 
 	ParamNested2(ctx context.Context, image ProductImageType) (ProductImageType, error)
+	// QueueParamNested2 queues the ParamNested2 query into batch to send with SendBatch.
+	QueueParamNested2(batch *pgx.Batch, image ProductImageType)
+	// ParamNested2Scan scans the results of a queued ParamNested2 query.
+	ParamNested2Scan(results pgx.BatchResults) (ProductImageType, error)
 
 	ParamNested2Array(ctx context.Context, images []ProductImageType) ([]ProductImageType, error)
+	// QueueParamNested2Array queues the ParamNested2Array query into batch to send with SendBatch.
+	QueueParamNested2Array(batch *pgx.Batch, images []ProductImageType)
+	// ParamNested2ArrayScan scans the results of a queued ParamNested2Array query.
+	ParamNested2ArrayScan(results pgx.BatchResults) ([]ProductImageType, error)
 
 	ParamNested3(ctx context.Context, imageSet ProductImageSetType) (ProductImageSetType, error)
+	// QueueParamNested3 queues the ParamNested3 query into batch to send with SendBatch.
+	QueueParamNested3(batch *pgx.Batch, imageSet ProductImageSetType)
+	// ParamNested3Scan scans the results of a queued ParamNested3 query.
+	ParamNested3Scan(results pgx.BatchResults) (ProductImageSetType, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -35,6 +55,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -257,6 +278,21 @@ func (q *DBQuerier) ParamArrayInt(ctx context.Context, ints []int) ([]int, error
 	return item, nil
 }
 
+// QueueParamArrayInt implements Querier.QueueParamArrayInt.
+func (q *DBQuerier) QueueParamArrayInt(batch *pgx.Batch, ints []int) {
+	batch.Queue(paramArrayIntSQL, ints)
+}
+
+// ParamArrayIntScan implements Querier.ParamArrayIntScan.
+func (q *DBQuerier) ParamArrayIntScan(results pgx.BatchResults) ([]int, error) {
+	row := results.QueryRow()
+	item := []int{}
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan ParamArrayInt batch row: %w", err)
+	}
+	return item, nil
+}
+
 const paramNested1SQL = `SELECT $1::dimensions;`
 
 // ParamNested1 implements Querier.ParamNested1.
@@ -274,6 +310,25 @@ func (q *DBQuerier) ParamNested1(ctx context.Context, dimensions Dimensions) (Di
 	return item, nil
 }
 
+// QueueParamNested1 implements Querier.QueueParamNested1.
+func (q *DBQuerier) QueueParamNested1(batch *pgx.Batch, dimensions Dimensions) {
+	batch.Queue(paramNested1SQL, q.types.newDimensionsInit(dimensions))
+}
+
+// ParamNested1Scan implements Querier.ParamNested1Scan.
+func (q *DBQuerier) ParamNested1Scan(results pgx.BatchResults) (Dimensions, error) {
+	row := results.QueryRow()
+	var item Dimensions
+	dimensionsRow := q.types.newDimensions()
+	if err := row.Scan(dimensionsRow); err != nil {
+		return item, fmt.Errorf("scan ParamNested1 batch row: %w", err)
+	}
+	if err := dimensionsRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ParamNested1 row: %w", err)
+	}
+	return item, nil
+}
+
 const paramNested2SQL = `SELECT $1::product_image_type;`
 
 // ParamNested2 implements Querier.ParamNested2.
@@ -291,6 +346,25 @@ func (q *DBQuerier) ParamNested2(ctx context.Context, image ProductImageType) (P
 	return item, nil
 }
 
+// QueueParamNested2 implements Querier.QueueParamNested2.
+func (q *DBQuerier) QueueParamNested2(batch *pgx.Batch, image ProductImageType) {
+	batch.Queue(paramNested2SQL, q.types.newProductImageTypeInit(image))
+}
+
+// ParamNested2Scan implements Querier.ParamNested2Scan.
+func (q *DBQuerier) ParamNested2Scan(results pgx.BatchResults) (ProductImageType, error) {
+	row := results.QueryRow()
+	var item ProductImageType
+	productImageTypeRow := q.types.newProductImageType()
+	if err := row.Scan(productImageTypeRow); err != nil {
+		return item, fmt.Errorf("scan ParamNested2 batch row: %w", err)
+	}
+	if err := productImageTypeRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ParamNested2 row: %w", err)
+	}
+	return item, nil
+}
+
 const paramNested2ArraySQL = `SELECT $1::product_image_type[];`
 
 // ParamNested2Array implements Querier.ParamNested2Array.
@@ -308,6 +382,25 @@ func (q *DBQuerier) ParamNested2Array(ctx context.Context, images []ProductImage
 	return item, nil
 }
 
+// QueueParamNested2Array implements Querier.QueueParamNested2Array.
+func (q *DBQuerier) QueueParamNested2Array(batch *pgx.Batch, images []ProductImageType) {
+	batch.Queue(paramNested2ArraySQL, q.types.newProductImageTypeArrayInit(images))
+}
+
+// ParamNested2ArrayScan implements Querier.ParamNested2ArrayScan.
+func (q *DBQuerier) ParamNested2ArrayScan(results pgx.BatchResults) ([]ProductImageType, error) {
+	row := results.QueryRow()
+	item := []ProductImageType{}
+	productImageTypeArray := q.types.newProductImageTypeArray()
+	if err := row.Scan(productImageTypeArray); err != nil {
+		return item, fmt.Errorf("scan ParamNested2Array batch row: %w", err)
+	}
+	if err := productImageTypeArray.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ParamNested2Array row: %w", err)
+	}
+	return item, nil
+}
+
 const paramNested3SQL = `SELECT $1::product_image_set_type;`
 
 // ParamNested3 implements Querier.ParamNested3.
@@ -325,6 +418,25 @@ func (q *DBQuerier) ParamNested3(ctx context.Context, imageSet ProductImageSetTy
 	return item, nil
 }
 
+// QueueParamNested3 implements Querier.QueueParamNested3.
+func (q *DBQuerier) QueueParamNested3(batch *pgx.Batch, imageSet ProductImageSetType) {
+	batch.Queue(paramNested3SQL, q.types.newProductImageSetTypeInit(imageSet))
+}
+
+// ParamNested3Scan implements Querier.ParamNested3Scan.
+func (q *DBQuerier) ParamNested3Scan(results pgx.BatchResults) (ProductImageSetType, error) {
+	row := results.QueryRow()
+	var item ProductImageSetType
+	productImageSetTypeRow := q.types.newProductImageSetType()
+	if err := row.Scan(productImageSetTypeRow); err != nil {
+		return item, fmt.Errorf("scan ParamNested3 batch row: %w", err)
+	}
+	if err := productImageSetTypeRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ParamNested3 row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -27,6 +27,7 @@ func TestGenerate_Go_Example_Composite(t *testing.T) {
 				"_bool":  "[]bool",
 				"bool":   "bool",
 				"int8":   "int",
+				"int8?":  "*int", // SearchScreenshots selects a primary key through a join
 				"int4":   "int",
 				"text":   "string",
 				"citext": "github.com/jackc/pgtype.Text",
@@ -13,14 +13,34 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	SearchScreenshots(ctx context.Context, params SearchScreenshotsParams) ([]SearchScreenshotsRow, error)
+	// QueueSearchScreenshots queues the SearchScreenshots query into batch to send with SendBatch.
+	QueueSearchScreenshots(batch *pgx.Batch, params SearchScreenshotsParams)
+	// SearchScreenshotsScan scans the results of a queued SearchScreenshots query.
+	SearchScreenshotsScan(results pgx.BatchResults) ([]SearchScreenshotsRow, error)
 
 	SearchScreenshotsOneCol(ctx context.Context, params SearchScreenshotsOneColParams) ([][]Blocks, error)
+	// QueueSearchScreenshotsOneCol queues the SearchScreenshotsOneCol query into batch to send with SendBatch.
+	QueueSearchScreenshotsOneCol(batch *pgx.Batch, params SearchScreenshotsOneColParams)
+	// SearchScreenshotsOneColScan scans the results of a queued SearchScreenshotsOneCol query.
+	SearchScreenshotsOneColScan(results pgx.BatchResults) ([][]Blocks, error)
 
 	InsertScreenshotBlocks(ctx context.Context, screenshotID int, body string) (InsertScreenshotBlocksRow, error)
+	// QueueInsertScreenshotBlocks queues the InsertScreenshotBlocks query into batch to send with SendBatch.
+	QueueInsertScreenshotBlocks(batch *pgx.Batch, screenshotID int, body string)
+	// InsertScreenshotBlocksScan scans the results of a queued InsertScreenshotBlocks query.
+	InsertScreenshotBlocksScan(results pgx.BatchResults) (InsertScreenshotBlocksRow, error)
 
 	ArraysInput(ctx context.Context, arrays Arrays) (Arrays, error)
+	// QueueArraysInput queues the ArraysInput query into batch to send with SendBatch.
+	QueueArraysInput(batch *pgx.Batch, arrays Arrays)
+	// ArraysInputScan scans the results of a queued ArraysInput query.
+	ArraysInputScan(results pgx.BatchResults) (Arrays, error)
 
 	UserEmails(ctx context.Context) (UserEmail, error)
+	// QueueUserEmails queues the UserEmails query into batch to send with SendBatch.
+	QueueUserEmails(batch *pgx.Batch)
+	// UserEmailsScan scans the results of a queued UserEmails query.
+	UserEmailsScan(results pgx.BatchResults) (UserEmail, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -35,6 +55,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -226,7 +247,7 @@ type SearchScreenshotsParams struct {
 }
 
 type SearchScreenshotsRow struct {
-	ID     int      `json:"id"`
+	ID     *int     `json:"id"`
 	Blocks []Blocks `json:"blocks"`
 }
 
@@ -256,6 +277,36 @@ func (q *DBQuerier) SearchScreenshots(ctx context.Context, params SearchScreensh
 	return items, err
 }
 
+// QueueSearchScreenshots implements Querier.QueueSearchScreenshots.
+func (q *DBQuerier) QueueSearchScreenshots(batch *pgx.Batch, params SearchScreenshotsParams) {
+	batch.Queue(searchScreenshotsSQL, params.Body, params.Limit, params.Offset)
+}
+
+// SearchScreenshotsScan implements Querier.SearchScreenshotsScan.
+func (q *DBQuerier) SearchScreenshotsScan(results pgx.BatchResults) ([]SearchScreenshotsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query SearchScreenshots batch: %w", err)
+	}
+	defer rows.Close()
+	items := []SearchScreenshotsRow{}
+	blocksArray := q.types.newBlocksArray()
+	for rows.Next() {
+		var item SearchScreenshotsRow
+		if err := rows.Scan(&item.ID, blocksArray); err != nil {
+			return nil, fmt.Errorf("scan SearchScreenshots batch row: %w", err)
+		}
+		if err := blocksArray.AssignTo(&item.Blocks); err != nil {
+			return nil, fmt.Errorf("assign SearchScreenshots row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close SearchScreenshots batch rows: %w", err)
+	}
+	return items, err
+}
+
 const searchScreenshotsOneColSQL = `SELECT
   array_agg(bl) AS blocks
 FROM screenshots ss
@@ -297,6 +348,36 @@ func (q *DBQuerier) SearchScreenshotsOneCol(ctx context.Context, params SearchSc
 	return items, err
 }
 
+// QueueSearchScreenshotsOneCol implements Querier.QueueSearchScreenshotsOneCol.
+func (q *DBQuerier) QueueSearchScreenshotsOneCol(batch *pgx.Batch, params SearchScreenshotsOneColParams) {
+	batch.Queue(searchScreenshotsOneColSQL, params.Body, params.Limit, params.Offset)
+}
+
+// SearchScreenshotsOneColScan implements Querier.SearchScreenshotsOneColScan.
+func (q *DBQuerier) SearchScreenshotsOneColScan(results pgx.BatchResults) ([][]Blocks, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query SearchScreenshotsOneCol batch: %w", err)
+	}
+	defer rows.Close()
+	items := [][]Blocks{}
+	blocksArray := q.types.newBlocksArray()
+	for rows.Next() {
+		var item []Blocks
+		if err := rows.Scan(blocksArray); err != nil {
+			return nil, fmt.Errorf("scan SearchScreenshotsOneCol batch row: %w", err)
+		}
+		if err := blocksArray.AssignTo(&item); err != nil {
+			return nil, fmt.Errorf("assign SearchScreenshotsOneCol row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close SearchScreenshotsOneCol batch rows: %w", err)
+	}
+	return items, err
+}
+
 const insertScreenshotBlocksSQL = `WITH screens AS (
   INSERT INTO screenshots (id) VALUES ($1)
     ON CONFLICT DO NOTHING
@@ -323,6 +404,21 @@ func (q *DBQuerier) InsertScreenshotBlocks(ctx context.Context, screenshotID int
 	return item, nil
 }
 
+// QueueInsertScreenshotBlocks implements Querier.QueueInsertScreenshotBlocks.
+func (q *DBQuerier) QueueInsertScreenshotBlocks(batch *pgx.Batch, screenshotID int, body string) {
+	batch.Queue(insertScreenshotBlocksSQL, screenshotID, body)
+}
+
+// InsertScreenshotBlocksScan implements Querier.InsertScreenshotBlocksScan.
+func (q *DBQuerier) InsertScreenshotBlocksScan(results pgx.BatchResults) (InsertScreenshotBlocksRow, error) {
+	row := results.QueryRow()
+	var item InsertScreenshotBlocksRow
+	if err := row.Scan(&item.ID, &item.ScreenshotID, &item.Body); err != nil {
+		return item, fmt.Errorf("scan InsertScreenshotBlocks batch row: %w", err)
+	}
+	return item, nil
+}
+
 const arraysInputSQL = `SELECT $1::arrays;`
 
 // ArraysInput implements Querier.ArraysInput.
@@ -340,6 +436,25 @@ func (q *DBQuerier) ArraysInput(ctx context.Context, arrays Arrays) (Arrays, err
 	return item, nil
 }
 
+// QueueArraysInput implements Querier.QueueArraysInput.
+func (q *DBQuerier) QueueArraysInput(batch *pgx.Batch, arrays Arrays) {
+	batch.Queue(arraysInputSQL, q.types.newArraysInit(arrays))
+}
+
+// ArraysInputScan implements Querier.ArraysInputScan.
+func (q *DBQuerier) ArraysInputScan(results pgx.BatchResults) (Arrays, error) {
+	row := results.QueryRow()
+	var item Arrays
+	arraysRow := q.types.newArrays()
+	if err := row.Scan(arraysRow); err != nil {
+		return item, fmt.Errorf("scan ArraysInput batch row: %w", err)
+	}
+	if err := arraysRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ArraysInput row: %w", err)
+	}
+	return item, nil
+}
+
 const userEmailsSQL = `SELECT ('foo', 'bar@example.com')::user_email;`
 
 // UserEmails implements Querier.UserEmails.
@@ -357,6 +472,25 @@ func (q *DBQuerier) UserEmails(ctx context.Context) (UserEmail, error) {
 	return item, nil
 }
 
+// QueueUserEmails implements Querier.QueueUserEmails.
+func (q *DBQuerier) QueueUserEmails(batch *pgx.Batch) {
+	batch.Queue(userEmailsSQL)
+}
+
+// UserEmailsScan implements Querier.UserEmailsScan.
+func (q *DBQuerier) UserEmailsScan(results pgx.BatchResults) (UserEmail, error) {
+	row := results.QueryRow()
+	var item UserEmail
+	rowRow := q.types.newUserEmail()
+	if err := row.Scan(rowRow); err != nil {
+		return item, fmt.Errorf("scan UserEmails batch row: %w", err)
+	}
+	if err := rowRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign UserEmails row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -21,7 +21,7 @@ func TestNewQuerier_SearchScreenshots(t *testing.T) {
 	screenshot2 := insertScreenshotBlock(t, q, screenshotID, "body2")
 	want := []SearchScreenshotsRow{
 		{
-			ID: screenshotID,
+			ID: ptrs.Int(screenshotID),
 			Blocks: []Blocks{
 				{
 					ID:           screenshot1.ID,
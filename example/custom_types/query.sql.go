@@ -14,10 +14,22 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	CustomTypes(ctx context.Context) (CustomTypesRow, error)
+	// QueueCustomTypes queues the CustomTypes query into batch to send with SendBatch.
+	QueueCustomTypes(batch *pgx.Batch)
+	// CustomTypesScan scans the results of a queued CustomTypes query.
+	CustomTypesScan(results pgx.BatchResults) (CustomTypesRow, error)
 
 	CustomMyInt(ctx context.Context) (int, error)
+	// QueueCustomMyInt queues the CustomMyInt query into batch to send with SendBatch.
+	QueueCustomMyInt(batch *pgx.Batch)
+	// CustomMyIntScan scans the results of a queued CustomMyInt query.
+	CustomMyIntScan(results pgx.BatchResults) (int, error)
 
 	IntArray(ctx context.Context) ([][]int32, error)
+	// QueueIntArray queues the IntArray query into batch to send with SendBatch.
+	QueueIntArray(batch *pgx.Batch)
+	// IntArrayScan scans the results of a queued IntArray query.
+	IntArrayScan(results pgx.BatchResults) ([][]int32, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -32,6 +44,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -86,6 +99,21 @@ func (q *DBQuerier) CustomTypes(ctx context.Context) (CustomTypesRow, error) {
 	return item, nil
 }
 
+// QueueCustomTypes implements Querier.QueueCustomTypes.
+func (q *DBQuerier) QueueCustomTypes(batch *pgx.Batch) {
+	batch.Queue(customTypesSQL)
+}
+
+// CustomTypesScan implements Querier.CustomTypesScan.
+func (q *DBQuerier) CustomTypesScan(results pgx.BatchResults) (CustomTypesRow, error) {
+	row := results.QueryRow()
+	var item CustomTypesRow
+	if err := row.Scan(&item.Column, &item.Int8); err != nil {
+		return item, fmt.Errorf("scan CustomTypes batch row: %w", err)
+	}
+	return item, nil
+}
+
 const customMyIntSQL = `SELECT '5'::my_int as int5;`
 
 // CustomMyInt implements Querier.CustomMyInt.
@@ -99,6 +127,21 @@ func (q *DBQuerier) CustomMyInt(ctx context.Context) (int, error) {
 	return item, nil
 }
 
+// QueueCustomMyInt implements Querier.QueueCustomMyInt.
+func (q *DBQuerier) QueueCustomMyInt(batch *pgx.Batch) {
+	batch.Queue(customMyIntSQL)
+}
+
+// CustomMyIntScan implements Querier.CustomMyIntScan.
+func (q *DBQuerier) CustomMyIntScan(results pgx.BatchResults) (int, error) {
+	row := results.QueryRow()
+	var item int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CustomMyInt batch row: %w", err)
+	}
+	return item, nil
+}
+
 const intArraySQL = `SELECT ARRAY ['5', '6', '7']::int[] as ints;`
 
 // IntArray implements Querier.IntArray.
@@ -123,6 +166,32 @@ func (q *DBQuerier) IntArray(ctx context.Context) ([][]int32, error) {
 	return items, err
 }
 
+// QueueIntArray implements Querier.QueueIntArray.
+func (q *DBQuerier) QueueIntArray(batch *pgx.Batch) {
+	batch.Queue(intArraySQL)
+}
+
+// IntArrayScan implements Querier.IntArrayScan.
+func (q *DBQuerier) IntArrayScan(results pgx.BatchResults) ([][]int32, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query IntArray batch: %w", err)
+	}
+	defer rows.Close()
+	items := [][]int32{}
+	for rows.Next() {
+		var item []int32
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan IntArray batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close IntArray batch rows: %w", err)
+	}
+	return items, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
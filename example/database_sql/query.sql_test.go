@@ -0,0 +1,145 @@
+package database_sql
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDB opens a database/sql handle to a new schema using the pgx stdlib
+// driver.
+func newTestDB(t *testing.T) (*sql.DB, pgtest.CleanupFunc) {
+	t.Helper()
+	schemaConn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	db, err := sql.Open("pgx", schemaConn.Config().ConnString())
+	if err != nil {
+		cleanup()
+		t.Fatalf("open database/sql db: %s", err)
+	}
+	return db, func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close database/sql db: %s", err)
+		}
+		cleanup()
+	}
+}
+
+func TestNewQuerier_FindAuthorByID(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	q := NewQuerier(db)
+	adamsID := insertAuthor(t, q, "john", "adams")
+
+	t.Run("FindAuthorByID", func(t *testing.T) {
+		author, err := q.FindAuthorByID(t.Context(), adamsID)
+		require.NoError(t, err)
+		assert.Equal(t, &FindAuthorByIDRow{
+			AuthorID:  adamsID,
+			FirstName: "john",
+			LastName:  "adams",
+			Suffix:    nil,
+			Status:    AuthorStatusActive,
+		}, author)
+	})
+
+	t.Run("FindAuthorByID - none-exists", func(t *testing.T) {
+		author, err := q.FindAuthorByID(t.Context(), 888)
+		require.NoError(t, err)
+		assert.Nil(t, author)
+	})
+}
+
+func TestNewQuerier_FindAuthorsByStatus(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	q := NewQuerier(db)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	washingtonID := insertAuthor(t, q, "george", "washington")
+	_, err := q.RetireAuthor(t.Context(), washingtonID)
+	require.NoError(t, err)
+
+	t.Run("FindAuthorsByStatus", func(t *testing.T) {
+		authors, err := q.FindAuthorsByStatus(t.Context(), []AuthorStatus{AuthorStatusRetired})
+		require.NoError(t, err)
+		assert.Equal(t, []FindAuthorsByStatusRow{
+			{AuthorID: washingtonID, FirstName: "george", Status: AuthorStatusRetired},
+		}, authors)
+	})
+
+	t.Run("FindAuthorsByStatus - all", func(t *testing.T) {
+		authors, err := q.FindAuthorsByStatus(t.Context(), []AuthorStatus{AuthorStatusActive, AuthorStatusRetired})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []FindAuthorsByStatusRow{
+			{AuthorID: adamsID, FirstName: "john", Status: AuthorStatusActive},
+			{AuthorID: washingtonID, FirstName: "george", Status: AuthorStatusRetired},
+		}, authors)
+	})
+
+	t.Run("FindAuthorsByStatus - tx", func(t *testing.T) {
+		tx, err := db.BeginTx(t.Context(), nil)
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback() }()
+		authors, err := NewQuerier(tx).FindAuthorsByStatus(t.Context(), []AuthorStatus{AuthorStatusActive})
+		require.NoError(t, err)
+		assert.Equal(t, []FindAuthorsByStatusRow{
+			{AuthorID: adamsID, FirstName: "john", Status: AuthorStatusActive},
+		}, authors)
+	})
+}
+
+func TestNewQuerier_StreamAuthors(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	q := NewQuerier(db)
+	for _, name := range []string{"john", "george", "thomas"} {
+		insertAuthor(t, q, name, "smith")
+	}
+
+	var names []string
+	for author, err := range q.StreamAuthors(t.Context(), 0) {
+		require.NoError(t, err)
+		names = append(names, author.FirstName)
+	}
+	assert.ElementsMatch(t, []string{"john", "george", "thomas"}, names)
+}
+
+func TestNewQuerier_RetireAuthor(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	q := NewQuerier(db)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	insertAuthor(t, q, "george", "washington")
+
+	t.Run("RetireAuthor", func(t *testing.T) {
+		res, err := q.RetireAuthor(t.Context(), adamsID)
+		require.NoError(t, err)
+		n, err := res.RowsAffected()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+
+	t.Run("RetireAuthor - none-exists", func(t *testing.T) {
+		_, err := q.RetireAuthor(t.Context(), 888)
+		if !errors.Is(err, ErrUnexpectedRowsAffected) {
+			t.Fatalf("expected error to wrap ErrUnexpectedRowsAffected; got %v", err)
+		}
+	})
+
+	t.Run("DeleteRetiredAuthors", func(t *testing.T) {
+		n, err := q.DeleteRetiredAuthors(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+}
+
+func insertAuthor(t *testing.T, q *DBQuerier, first, last string) int32 {
+	t.Helper()
+	authorID, err := q.InsertAuthor(t.Context(), first, last)
+	require.NoError(t, err, "insert author")
+	return authorID
+}
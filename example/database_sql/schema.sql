@@ -0,0 +1,9 @@
+CREATE TYPE author_status AS ENUM ('active', 'retired');
+
+CREATE TABLE author (
+  author_id  serial PRIMARY KEY,
+  first_name text          NOT NULL,
+  last_name  text          NOT NULL,
+  suffix     text          NULL,
+  status     author_status NOT NULL DEFAULT 'active'
+);
@@ -13,18 +13,46 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	FindDevicesByUser(ctx context.Context, id int) ([]FindDevicesByUserRow, error)
+	// QueueFindDevicesByUser queues the FindDevicesByUser query into batch to send with SendBatch.
+	QueueFindDevicesByUser(batch *pgx.Batch, id int)
+	// FindDevicesByUserScan scans the results of a queued FindDevicesByUser query.
+	FindDevicesByUserScan(results pgx.BatchResults) ([]FindDevicesByUserRow, error)
 
 	CompositeUser(ctx context.Context) ([]CompositeUserRow, error)
+	// QueueCompositeUser queues the CompositeUser query into batch to send with SendBatch.
+	QueueCompositeUser(batch *pgx.Batch)
+	// CompositeUserScan scans the results of a queued CompositeUser query.
+	CompositeUserScan(results pgx.BatchResults) ([]CompositeUserRow, error)
 
 	CompositeUserOne(ctx context.Context) (User, error)
+	// QueueCompositeUserOne queues the CompositeUserOne query into batch to send with SendBatch.
+	QueueCompositeUserOne(batch *pgx.Batch)
+	// CompositeUserOneScan scans the results of a queued CompositeUserOne query.
+	CompositeUserOneScan(results pgx.BatchResults) (User, error)
 
 	CompositeUserOneTwoCols(ctx context.Context) (CompositeUserOneTwoColsRow, error)
+	// QueueCompositeUserOneTwoCols queues the CompositeUserOneTwoCols query into batch to send with SendBatch.
+	QueueCompositeUserOneTwoCols(batch *pgx.Batch)
+	// CompositeUserOneTwoColsScan scans the results of a queued CompositeUserOneTwoCols query.
+	CompositeUserOneTwoColsScan(results pgx.BatchResults) (CompositeUserOneTwoColsRow, error)
 
 	CompositeUserMany(ctx context.Context) ([]User, error)
+	// QueueCompositeUserMany queues the CompositeUserMany query into batch to send with SendBatch.
+	QueueCompositeUserMany(batch *pgx.Batch)
+	// CompositeUserManyScan scans the results of a queued CompositeUserMany query.
+	CompositeUserManyScan(results pgx.BatchResults) ([]User, error)
 
 	InsertUser(ctx context.Context, userID int, name string) (pgconn.CommandTag, error)
+	// QueueInsertUser queues the InsertUser query into batch to send with SendBatch.
+	QueueInsertUser(batch *pgx.Batch, userID int, name string)
+	// InsertUserScan scans the results of a queued InsertUser query.
+	InsertUserScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	InsertDevice(ctx context.Context, mac pgtype.Macaddr, owner int) (pgconn.CommandTag, error)
+	// QueueInsertDevice queues the InsertDevice query into batch to send with SendBatch.
+	QueueInsertDevice(batch *pgx.Batch, mac pgtype.Macaddr, owner int)
+	// InsertDeviceScan scans the results of a queued InsertDevice query.
+	InsertDeviceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -39,6 +67,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -48,8 +77,8 @@ func NewQuerier(conn genericConn) *DBQuerier {
 
 // User represents the Postgres composite type "user".
 type User struct {
-	ID   *int    `json:"id"`
-	Name *string `json:"name"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 // DeviceType represents the Postgres enum "device_type".
@@ -191,6 +220,32 @@ func (q *DBQuerier) FindDevicesByUser(ctx context.Context, id int) ([]FindDevice
 	return items, err
 }
 
+// QueueFindDevicesByUser implements Querier.QueueFindDevicesByUser.
+func (q *DBQuerier) QueueFindDevicesByUser(batch *pgx.Batch, id int) {
+	batch.Queue(findDevicesByUserSQL, id)
+}
+
+// FindDevicesByUserScan implements Querier.FindDevicesByUserScan.
+func (q *DBQuerier) FindDevicesByUserScan(results pgx.BatchResults) ([]FindDevicesByUserRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindDevicesByUser batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindDevicesByUserRow{}
+	for rows.Next() {
+		var item FindDevicesByUserRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.MacAddrs); err != nil {
+			return nil, fmt.Errorf("scan FindDevicesByUser batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindDevicesByUser batch rows: %w", err)
+	}
+	return items, err
+}
+
 const compositeUserSQL = `SELECT
   d.mac,
   d.type,
@@ -230,6 +285,36 @@ func (q *DBQuerier) CompositeUser(ctx context.Context) ([]CompositeUserRow, erro
 	return items, err
 }
 
+// QueueCompositeUser implements Querier.QueueCompositeUser.
+func (q *DBQuerier) QueueCompositeUser(batch *pgx.Batch) {
+	batch.Queue(compositeUserSQL)
+}
+
+// CompositeUserScan implements Querier.CompositeUserScan.
+func (q *DBQuerier) CompositeUserScan(results pgx.BatchResults) ([]CompositeUserRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query CompositeUser batch: %w", err)
+	}
+	defer rows.Close()
+	items := []CompositeUserRow{}
+	userRow := q.types.newUser()
+	for rows.Next() {
+		var item CompositeUserRow
+		if err := rows.Scan(&item.Mac, &item.Type, userRow); err != nil {
+			return nil, fmt.Errorf("scan CompositeUser batch row: %w", err)
+		}
+		if err := userRow.AssignTo(&item.User); err != nil {
+			return nil, fmt.Errorf("assign CompositeUser row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close CompositeUser batch rows: %w", err)
+	}
+	return items, err
+}
+
 const compositeUserOneSQL = `SELECT ROW (15, 'qux')::"user" AS "user";`
 
 // CompositeUserOne implements Querier.CompositeUserOne.
@@ -247,6 +332,25 @@ func (q *DBQuerier) CompositeUserOne(ctx context.Context) (User, error) {
 	return item, nil
 }
 
+// QueueCompositeUserOne implements Querier.QueueCompositeUserOne.
+func (q *DBQuerier) QueueCompositeUserOne(batch *pgx.Batch) {
+	batch.Queue(compositeUserOneSQL)
+}
+
+// CompositeUserOneScan implements Querier.CompositeUserOneScan.
+func (q *DBQuerier) CompositeUserOneScan(results pgx.BatchResults) (User, error) {
+	row := results.QueryRow()
+	var item User
+	userRow := q.types.newUser()
+	if err := row.Scan(userRow); err != nil {
+		return item, fmt.Errorf("scan CompositeUserOne batch row: %w", err)
+	}
+	if err := userRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign CompositeUserOne row: %w", err)
+	}
+	return item, nil
+}
+
 const compositeUserOneTwoColsSQL = `SELECT 1 AS num, ROW (15, 'qux')::"user" AS "user";`
 
 type CompositeUserOneTwoColsRow struct {
@@ -269,6 +373,25 @@ func (q *DBQuerier) CompositeUserOneTwoCols(ctx context.Context) (CompositeUserO
 	return item, nil
 }
 
+// QueueCompositeUserOneTwoCols implements Querier.QueueCompositeUserOneTwoCols.
+func (q *DBQuerier) QueueCompositeUserOneTwoCols(batch *pgx.Batch) {
+	batch.Queue(compositeUserOneTwoColsSQL)
+}
+
+// CompositeUserOneTwoColsScan implements Querier.CompositeUserOneTwoColsScan.
+func (q *DBQuerier) CompositeUserOneTwoColsScan(results pgx.BatchResults) (CompositeUserOneTwoColsRow, error) {
+	row := results.QueryRow()
+	var item CompositeUserOneTwoColsRow
+	userRow := q.types.newUser()
+	if err := row.Scan(&item.Num, userRow); err != nil {
+		return item, fmt.Errorf("scan CompositeUserOneTwoCols batch row: %w", err)
+	}
+	if err := userRow.AssignTo(&item.User); err != nil {
+		return item, fmt.Errorf("assign CompositeUserOneTwoCols row: %w", err)
+	}
+	return item, nil
+}
+
 const compositeUserManySQL = `SELECT ROW (15, 'qux')::"user" AS "user";`
 
 // CompositeUserMany implements Querier.CompositeUserMany.
@@ -297,6 +420,36 @@ func (q *DBQuerier) CompositeUserMany(ctx context.Context) ([]User, error) {
 	return items, err
 }
 
+// QueueCompositeUserMany implements Querier.QueueCompositeUserMany.
+func (q *DBQuerier) QueueCompositeUserMany(batch *pgx.Batch) {
+	batch.Queue(compositeUserManySQL)
+}
+
+// CompositeUserManyScan implements Querier.CompositeUserManyScan.
+func (q *DBQuerier) CompositeUserManyScan(results pgx.BatchResults) ([]User, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query CompositeUserMany batch: %w", err)
+	}
+	defer rows.Close()
+	items := []User{}
+	userRow := q.types.newUser()
+	for rows.Next() {
+		var item User
+		if err := rows.Scan(userRow); err != nil {
+			return nil, fmt.Errorf("scan CompositeUserMany batch row: %w", err)
+		}
+		if err := userRow.AssignTo(&item); err != nil {
+			return nil, fmt.Errorf("assign CompositeUserMany row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close CompositeUserMany batch rows: %w", err)
+	}
+	return items, err
+}
+
 const insertUserSQL = `INSERT INTO "user" (id, name)
 VALUES ($1, $2);`
 
@@ -310,6 +463,20 @@ func (q *DBQuerier) InsertUser(ctx context.Context, userID int, name string) (pg
 	return cmdTag, err
 }
 
+// QueueInsertUser implements Querier.QueueInsertUser.
+func (q *DBQuerier) QueueInsertUser(batch *pgx.Batch, userID int, name string) {
+	batch.Queue(insertUserSQL, userID, name)
+}
+
+// InsertUserScan implements Querier.InsertUserScan.
+func (q *DBQuerier) InsertUserScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query InsertUser: %w", err)
+	}
+	return cmdTag, err
+}
+
 const insertDeviceSQL = `INSERT INTO device (mac, owner)
 VALUES ($1, $2);`
 
@@ -323,6 +490,20 @@ func (q *DBQuerier) InsertDevice(ctx context.Context, mac pgtype.Macaddr, owner
 	return cmdTag, err
 }
 
+// QueueInsertDevice implements Querier.QueueInsertDevice.
+func (q *DBQuerier) QueueInsertDevice(batch *pgx.Batch, mac pgtype.Macaddr, owner int) {
+	batch.Queue(insertDeviceSQL, mac, owner)
+}
+
+// InsertDeviceScan implements Querier.InsertDeviceScan.
+func (q *DBQuerier) InsertDeviceScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query InsertDevice: %w", err)
+	}
+	return cmdTag, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -68,12 +68,12 @@ func TestQuerier_CompositeUser(t *testing.T) {
 			{
 				Mac:  pgtype.Macaddr{Addr: mac1, Status: pgtype.Present},
 				Type: DeviceTypeUndefined,
-				User: User{ID: &userID, Name: &name},
+				User: User{ID: userID, Name: name},
 			},
 			{
 				Mac:  pgtype.Macaddr{Addr: mac2, Status: pgtype.Present},
 				Type: DeviceTypeUndefined,
-				User: User{ID: &userID, Name: &name},
+				User: User{ID: userID, Name: name},
 			},
 		}
 		assert.Equal(t, want, users)
@@ -87,7 +87,7 @@ func TestQuerier_CompositeUserOne(t *testing.T) {
 	ctx := t.Context()
 	id := 15
 	name := "qux"
-	wantUser := User{ID: &id, Name: &name}
+	wantUser := User{ID: id, Name: name}
 
 	t.Run("CompositeUserOne", func(t *testing.T) {
 		got, err := q.CompositeUserOne(ctx)
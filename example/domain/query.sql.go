@@ -13,6 +13,10 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	DomainOne(ctx context.Context) (string, error)
+	// QueueDomainOne queues the DomainOne query into batch to send with SendBatch.
+	QueueDomainOne(batch *pgx.Batch)
+	// DomainOneScan scans the results of a queued DomainOne query.
+	DomainOneScan(results pgx.BatchResults) (string, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -27,6 +31,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -76,6 +81,21 @@ func (q *DBQuerier) DomainOne(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueDomainOne implements Querier.QueueDomainOne.
+func (q *DBQuerier) QueueDomainOne(batch *pgx.Batch) {
+	batch.Queue(domainOneSQL)
+}
+
+// DomainOneScan implements Querier.DomainOneScan.
+func (q *DBQuerier) DomainOneScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan DomainOne batch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
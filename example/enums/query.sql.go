@@ -13,20 +13,44 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	FindAllDevices(ctx context.Context) ([]FindAllDevicesRow, error)
+	// QueueFindAllDevices queues the FindAllDevices query into batch to send with SendBatch.
+	QueueFindAllDevices(batch *pgx.Batch)
+	// FindAllDevicesScan scans the results of a queued FindAllDevices query.
+	FindAllDevicesScan(results pgx.BatchResults) ([]FindAllDevicesRow, error)
 
 	InsertDevice(ctx context.Context, mac pgtype.Macaddr, typePg DeviceType) (pgconn.CommandTag, error)
+	// QueueInsertDevice queues the InsertDevice query into batch to send with SendBatch.
+	QueueInsertDevice(batch *pgx.Batch, mac pgtype.Macaddr, typePg DeviceType)
+	// InsertDeviceScan scans the results of a queued InsertDevice query.
+	InsertDeviceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	// Select an array of all device_type enum values.
 	FindOneDeviceArray(ctx context.Context) ([]DeviceType, error)
+	// QueueFindOneDeviceArray queues the FindOneDeviceArray query into batch to send with SendBatch.
+	QueueFindOneDeviceArray(batch *pgx.Batch)
+	// FindOneDeviceArrayScan scans the results of a queued FindOneDeviceArray query.
+	FindOneDeviceArrayScan(results pgx.BatchResults) ([]DeviceType, error)
 
 	// Select many rows of device_type enum values.
 	FindManyDeviceArray(ctx context.Context) ([][]DeviceType, error)
+	// QueueFindManyDeviceArray queues the FindManyDeviceArray query into batch to send with SendBatch.
+	QueueFindManyDeviceArray(batch *pgx.Batch)
+	// FindManyDeviceArrayScan scans the results of a queued FindManyDeviceArray query.
+	FindManyDeviceArrayScan(results pgx.BatchResults) ([][]DeviceType, error)
 
 	// Select many rows of device_type enum values with multiple output columns.
 	FindManyDeviceArrayWithNum(ctx context.Context) ([]FindManyDeviceArrayWithNumRow, error)
+	// QueueFindManyDeviceArrayWithNum queues the FindManyDeviceArrayWithNum query into batch to send with SendBatch.
+	QueueFindManyDeviceArrayWithNum(batch *pgx.Batch)
+	// FindManyDeviceArrayWithNumScan scans the results of a queued FindManyDeviceArrayWithNum query.
+	FindManyDeviceArrayWithNumScan(results pgx.BatchResults) ([]FindManyDeviceArrayWithNumRow, error)
 
 	// Regression test for https://github.com/jschaf/pggen/issues/23.
 	EnumInsideComposite(ctx context.Context) (Device, error)
+	// QueueEnumInsideComposite queues the EnumInsideComposite query into batch to send with SendBatch.
+	QueueEnumInsideComposite(batch *pgx.Batch)
+	// EnumInsideCompositeScan scans the results of a queued EnumInsideComposite query.
+	EnumInsideCompositeScan(results pgx.BatchResults) (Device, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -41,6 +65,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -210,6 +235,32 @@ func (q *DBQuerier) FindAllDevices(ctx context.Context) ([]FindAllDevicesRow, er
 	return items, err
 }
 
+// QueueFindAllDevices implements Querier.QueueFindAllDevices.
+func (q *DBQuerier) QueueFindAllDevices(batch *pgx.Batch) {
+	batch.Queue(findAllDevicesSQL)
+}
+
+// FindAllDevicesScan implements Querier.FindAllDevicesScan.
+func (q *DBQuerier) FindAllDevicesScan(results pgx.BatchResults) ([]FindAllDevicesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindAllDevices batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindAllDevicesRow{}
+	for rows.Next() {
+		var item FindAllDevicesRow
+		if err := rows.Scan(&item.Mac, &item.Type); err != nil {
+			return nil, fmt.Errorf("scan FindAllDevices batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindAllDevices batch rows: %w", err)
+	}
+	return items, err
+}
+
 const insertDeviceSQL = `INSERT INTO device (mac, type)
 VALUES ($1, $2);`
 
@@ -223,6 +274,20 @@ func (q *DBQuerier) InsertDevice(ctx context.Context, mac pgtype.Macaddr, typePg
 	return cmdTag, err
 }
 
+// QueueInsertDevice implements Querier.QueueInsertDevice.
+func (q *DBQuerier) QueueInsertDevice(batch *pgx.Batch, mac pgtype.Macaddr, typePg DeviceType) {
+	batch.Queue(insertDeviceSQL, mac, typePg)
+}
+
+// InsertDeviceScan implements Querier.InsertDeviceScan.
+func (q *DBQuerier) InsertDeviceScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query InsertDevice: %w", err)
+	}
+	return cmdTag, err
+}
+
 const findOneDeviceArraySQL = `SELECT enum_range(NULL::device_type) AS device_types;`
 
 // FindOneDeviceArray implements Querier.FindOneDeviceArray.
@@ -240,6 +305,25 @@ func (q *DBQuerier) FindOneDeviceArray(ctx context.Context) ([]DeviceType, error
 	return item, nil
 }
 
+// QueueFindOneDeviceArray implements Querier.QueueFindOneDeviceArray.
+func (q *DBQuerier) QueueFindOneDeviceArray(batch *pgx.Batch) {
+	batch.Queue(findOneDeviceArraySQL)
+}
+
+// FindOneDeviceArrayScan implements Querier.FindOneDeviceArrayScan.
+func (q *DBQuerier) FindOneDeviceArrayScan(results pgx.BatchResults) ([]DeviceType, error) {
+	row := results.QueryRow()
+	item := []DeviceType{}
+	deviceTypesArray := q.types.newDeviceTypeArray()
+	if err := row.Scan(deviceTypesArray); err != nil {
+		return item, fmt.Errorf("scan FindOneDeviceArray batch row: %w", err)
+	}
+	if err := deviceTypesArray.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign FindOneDeviceArray row: %w", err)
+	}
+	return item, nil
+}
+
 const findManyDeviceArraySQL = `SELECT enum_range('ipad'::device_type, 'iot'::device_type) AS device_types
 UNION ALL
 SELECT enum_range(NULL::device_type) AS device_types;`
@@ -270,6 +354,36 @@ func (q *DBQuerier) FindManyDeviceArray(ctx context.Context) ([][]DeviceType, er
 	return items, err
 }
 
+// QueueFindManyDeviceArray implements Querier.QueueFindManyDeviceArray.
+func (q *DBQuerier) QueueFindManyDeviceArray(batch *pgx.Batch) {
+	batch.Queue(findManyDeviceArraySQL)
+}
+
+// FindManyDeviceArrayScan implements Querier.FindManyDeviceArrayScan.
+func (q *DBQuerier) FindManyDeviceArrayScan(results pgx.BatchResults) ([][]DeviceType, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindManyDeviceArray batch: %w", err)
+	}
+	defer rows.Close()
+	items := [][]DeviceType{}
+	deviceTypesArray := q.types.newDeviceTypeArray()
+	for rows.Next() {
+		var item []DeviceType
+		if err := rows.Scan(deviceTypesArray); err != nil {
+			return nil, fmt.Errorf("scan FindManyDeviceArray batch row: %w", err)
+		}
+		if err := deviceTypesArray.AssignTo(&item); err != nil {
+			return nil, fmt.Errorf("assign FindManyDeviceArray row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindManyDeviceArray batch rows: %w", err)
+	}
+	return items, err
+}
+
 const findManyDeviceArrayWithNumSQL = `SELECT 1 AS num, enum_range('ipad'::device_type, 'iot'::device_type) AS device_types
 UNION ALL
 SELECT 2 as num, enum_range(NULL::device_type) AS device_types;`
@@ -305,6 +419,36 @@ func (q *DBQuerier) FindManyDeviceArrayWithNum(ctx context.Context) ([]FindManyD
 	return items, err
 }
 
+// QueueFindManyDeviceArrayWithNum implements Querier.QueueFindManyDeviceArrayWithNum.
+func (q *DBQuerier) QueueFindManyDeviceArrayWithNum(batch *pgx.Batch) {
+	batch.Queue(findManyDeviceArrayWithNumSQL)
+}
+
+// FindManyDeviceArrayWithNumScan implements Querier.FindManyDeviceArrayWithNumScan.
+func (q *DBQuerier) FindManyDeviceArrayWithNumScan(results pgx.BatchResults) ([]FindManyDeviceArrayWithNumRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindManyDeviceArrayWithNum batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindManyDeviceArrayWithNumRow{}
+	deviceTypesArray := q.types.newDeviceTypeArray()
+	for rows.Next() {
+		var item FindManyDeviceArrayWithNumRow
+		if err := rows.Scan(&item.Num, deviceTypesArray); err != nil {
+			return nil, fmt.Errorf("scan FindManyDeviceArrayWithNum batch row: %w", err)
+		}
+		if err := deviceTypesArray.AssignTo(&item.DeviceTypes); err != nil {
+			return nil, fmt.Errorf("assign FindManyDeviceArrayWithNum row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindManyDeviceArrayWithNum batch rows: %w", err)
+	}
+	return items, err
+}
+
 const enumInsideCompositeSQL = `SELECT ROW('08:00:2b:01:02:03'::macaddr, 'phone'::device_type) ::device;`
 
 // EnumInsideComposite implements Querier.EnumInsideComposite.
@@ -322,6 +466,25 @@ func (q *DBQuerier) EnumInsideComposite(ctx context.Context) (Device, error) {
 	return item, nil
 }
 
+// QueueEnumInsideComposite implements Querier.QueueEnumInsideComposite.
+func (q *DBQuerier) QueueEnumInsideComposite(batch *pgx.Batch) {
+	batch.Queue(enumInsideCompositeSQL)
+}
+
+// EnumInsideCompositeScan implements Querier.EnumInsideCompositeScan.
+func (q *DBQuerier) EnumInsideCompositeScan(results pgx.BatchResults) (Device, error) {
+	row := results.QueryRow()
+	var item Device
+	rowRow := q.types.newDevice()
+	if err := row.Scan(rowRow); err != nil {
+		return item, fmt.Errorf("scan EnumInsideComposite batch row: %w", err)
+	}
+	if err := rowRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign EnumInsideComposite row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -13,18 +13,46 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	CreateTenant(ctx context.Context, key string, name string) (CreateTenantRow, error)
+	// QueueCreateTenant queues the CreateTenant query into batch to send with SendBatch.
+	QueueCreateTenant(batch *pgx.Batch, key string, name string)
+	// CreateTenantScan scans the results of a queued CreateTenant query.
+	CreateTenantScan(results pgx.BatchResults) (CreateTenantRow, error)
 
 	FindOrdersByCustomer(ctx context.Context, customerID int32) ([]FindOrdersByCustomerRow, error)
+	// QueueFindOrdersByCustomer queues the FindOrdersByCustomer query into batch to send with SendBatch.
+	QueueFindOrdersByCustomer(batch *pgx.Batch, customerID int32)
+	// FindOrdersByCustomerScan scans the results of a queued FindOrdersByCustomer query.
+	FindOrdersByCustomerScan(results pgx.BatchResults) ([]FindOrdersByCustomerRow, error)
 
 	FindProductsInOrder(ctx context.Context, orderID int32) ([]FindProductsInOrderRow, error)
+	// QueueFindProductsInOrder queues the FindProductsInOrder query into batch to send with SendBatch.
+	QueueFindProductsInOrder(batch *pgx.Batch, orderID int32)
+	// FindProductsInOrderScan scans the results of a queued FindProductsInOrder query.
+	FindProductsInOrderScan(results pgx.BatchResults) ([]FindProductsInOrderRow, error)
 
 	InsertCustomer(ctx context.Context, params InsertCustomerParams) (InsertCustomerRow, error)
+	// QueueInsertCustomer queues the InsertCustomer query into batch to send with SendBatch.
+	QueueInsertCustomer(batch *pgx.Batch, params InsertCustomerParams)
+	// InsertCustomerScan scans the results of a queued InsertCustomer query.
+	InsertCustomerScan(results pgx.BatchResults) (InsertCustomerRow, error)
 
 	InsertOrder(ctx context.Context, params InsertOrderParams) (InsertOrderRow, error)
+	// QueueInsertOrder queues the InsertOrder query into batch to send with SendBatch.
+	QueueInsertOrder(batch *pgx.Batch, params InsertOrderParams)
+	// InsertOrderScan scans the results of a queued InsertOrder query.
+	InsertOrderScan(results pgx.BatchResults) (InsertOrderRow, error)
 
 	FindOrdersByPrice(ctx context.Context, minTotal pgtype.Numeric) ([]FindOrdersByPriceRow, error)
+	// QueueFindOrdersByPrice queues the FindOrdersByPrice query into batch to send with SendBatch.
+	QueueFindOrdersByPrice(batch *pgx.Batch, minTotal pgtype.Numeric)
+	// FindOrdersByPriceScan scans the results of a queued FindOrdersByPrice query.
+	FindOrdersByPriceScan(results pgx.BatchResults) ([]FindOrdersByPriceRow, error)
 
 	FindOrdersMRR(ctx context.Context) ([]FindOrdersMRRRow, error)
+	// QueueFindOrdersMRR queues the FindOrdersMRR query into batch to send with SendBatch.
+	QueueFindOrdersMRR(batch *pgx.Batch)
+	// FindOrdersMRRScan scans the results of a queued FindOrdersMRR query.
+	FindOrdersMRRScan(results pgx.BatchResults) ([]FindOrdersMRRRow, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -39,6 +67,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -96,6 +125,21 @@ func (q *DBQuerier) CreateTenant(ctx context.Context, key string, name string) (
 	return item, nil
 }
 
+// QueueCreateTenant implements Querier.QueueCreateTenant.
+func (q *DBQuerier) QueueCreateTenant(batch *pgx.Batch, key string, name string) {
+	batch.Queue(createTenantSQL, key, name)
+}
+
+// CreateTenantScan implements Querier.CreateTenantScan.
+func (q *DBQuerier) CreateTenantScan(results pgx.BatchResults) (CreateTenantRow, error) {
+	row := results.QueryRow()
+	var item CreateTenantRow
+	if err := row.Scan(&item.TenantID, &item.Rname, &item.Name); err != nil {
+		return item, fmt.Errorf("scan CreateTenant batch row: %w", err)
+	}
+	return item, nil
+}
+
 const findOrdersByCustomerSQL = `SELECT *
 FROM orders
 WHERE customer_id = $1;`
@@ -129,6 +173,32 @@ func (q *DBQuerier) FindOrdersByCustomer(ctx context.Context, customerID int32)
 	return items, err
 }
 
+// QueueFindOrdersByCustomer implements Querier.QueueFindOrdersByCustomer.
+func (q *DBQuerier) QueueFindOrdersByCustomer(batch *pgx.Batch, customerID int32) {
+	batch.Queue(findOrdersByCustomerSQL, customerID)
+}
+
+// FindOrdersByCustomerScan implements Querier.FindOrdersByCustomerScan.
+func (q *DBQuerier) FindOrdersByCustomerScan(results pgx.BatchResults) ([]FindOrdersByCustomerRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindOrdersByCustomer batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindOrdersByCustomerRow{}
+	for rows.Next() {
+		var item FindOrdersByCustomerRow
+		if err := rows.Scan(&item.OrderID, &item.OrderDate, &item.OrderTotal, &item.CustomerID); err != nil {
+			return nil, fmt.Errorf("scan FindOrdersByCustomer batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindOrdersByCustomer batch rows: %w", err)
+	}
+	return items, err
+}
+
 const findProductsInOrderSQL = `SELECT o.order_id, p.product_id, p.name
 FROM orders o
   INNER JOIN order_product op USING (order_id)
@@ -163,6 +233,32 @@ func (q *DBQuerier) FindProductsInOrder(ctx context.Context, orderID int32) ([]F
 	return items, err
 }
 
+// QueueFindProductsInOrder implements Querier.QueueFindProductsInOrder.
+func (q *DBQuerier) QueueFindProductsInOrder(batch *pgx.Batch, orderID int32) {
+	batch.Queue(findProductsInOrderSQL, orderID)
+}
+
+// FindProductsInOrderScan implements Querier.FindProductsInOrderScan.
+func (q *DBQuerier) FindProductsInOrderScan(results pgx.BatchResults) ([]FindProductsInOrderRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindProductsInOrder batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindProductsInOrderRow{}
+	for rows.Next() {
+		var item FindProductsInOrderRow
+		if err := rows.Scan(&item.OrderID, &item.ProductID, &item.Name); err != nil {
+			return nil, fmt.Errorf("scan FindProductsInOrder batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindProductsInOrder batch rows: %w", err)
+	}
+	return items, err
+}
+
 const insertCustomerSQL = `INSERT INTO customer (first_name, last_name, email)
 VALUES ($1, $2, $3)
 RETURNING *;`
@@ -191,6 +287,21 @@ func (q *DBQuerier) InsertCustomer(ctx context.Context, params InsertCustomerPar
 	return item, nil
 }
 
+// QueueInsertCustomer implements Querier.QueueInsertCustomer.
+func (q *DBQuerier) QueueInsertCustomer(batch *pgx.Batch, params InsertCustomerParams) {
+	batch.Queue(insertCustomerSQL, params.FirstName, params.LastName, params.Email)
+}
+
+// InsertCustomerScan implements Querier.InsertCustomerScan.
+func (q *DBQuerier) InsertCustomerScan(results pgx.BatchResults) (InsertCustomerRow, error) {
+	row := results.QueryRow()
+	var item InsertCustomerRow
+	if err := row.Scan(&item.CustomerID, &item.FirstName, &item.LastName, &item.Email); err != nil {
+		return item, fmt.Errorf("scan InsertCustomer batch row: %w", err)
+	}
+	return item, nil
+}
+
 const insertOrderSQL = `INSERT INTO orders (order_date, order_total, customer_id)
 VALUES ($1, $2, $3)
 RETURNING *;`
@@ -219,6 +330,21 @@ func (q *DBQuerier) InsertOrder(ctx context.Context, params InsertOrderParams) (
 	return item, nil
 }
 
+// QueueInsertOrder implements Querier.QueueInsertOrder.
+func (q *DBQuerier) QueueInsertOrder(batch *pgx.Batch, params InsertOrderParams) {
+	batch.Queue(insertOrderSQL, params.OrderDate, params.OrderTotal, params.CustID)
+}
+
+// InsertOrderScan implements Querier.InsertOrderScan.
+func (q *DBQuerier) InsertOrderScan(results pgx.BatchResults) (InsertOrderRow, error) {
+	row := results.QueryRow()
+	var item InsertOrderRow
+	if err := row.Scan(&item.OrderID, &item.OrderDate, &item.OrderTotal, &item.CustomerID); err != nil {
+		return item, fmt.Errorf("scan InsertOrder batch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 )
 
 const findOrdersByPriceSQL = `SELECT * FROM orders WHERE order_total > $1;`
@@ -39,6 +40,32 @@ func (q *DBQuerier) FindOrdersByPrice(ctx context.Context, minTotal pgtype.Numer
 	return items, err
 }
 
+// QueueFindOrdersByPrice implements Querier.QueueFindOrdersByPrice.
+func (q *DBQuerier) QueueFindOrdersByPrice(batch *pgx.Batch, minTotal pgtype.Numeric) {
+	batch.Queue(findOrdersByPriceSQL, minTotal)
+}
+
+// FindOrdersByPriceScan implements Querier.FindOrdersByPriceScan.
+func (q *DBQuerier) FindOrdersByPriceScan(results pgx.BatchResults) ([]FindOrdersByPriceRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindOrdersByPrice batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindOrdersByPriceRow{}
+	for rows.Next() {
+		var item FindOrdersByPriceRow
+		if err := rows.Scan(&item.OrderID, &item.OrderDate, &item.OrderTotal, &item.CustomerID); err != nil {
+			return nil, fmt.Errorf("scan FindOrdersByPrice batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindOrdersByPrice batch rows: %w", err)
+	}
+	return items, err
+}
+
 const findOrdersMRRSQL = `SELECT date_trunc('month', order_date) AS month, sum(order_total) AS order_mrr
 FROM orders
 GROUP BY date_trunc('month', order_date);`
@@ -69,3 +96,29 @@ func (q *DBQuerier) FindOrdersMRR(ctx context.Context) ([]FindOrdersMRRRow, erro
 	}
 	return items, err
 }
+
+// QueueFindOrdersMRR implements Querier.QueueFindOrdersMRR.
+func (q *DBQuerier) QueueFindOrdersMRR(batch *pgx.Batch) {
+	batch.Queue(findOrdersMRRSQL)
+}
+
+// FindOrdersMRRScan implements Querier.FindOrdersMRRScan.
+func (q *DBQuerier) FindOrdersMRRScan(results pgx.BatchResults) ([]FindOrdersMRRRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindOrdersMRR batch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindOrdersMRRRow{}
+	for rows.Next() {
+		var item FindOrdersMRRRow
+		if err := rows.Scan(&item.Month, &item.OrderMRR); err != nil {
+			return nil, fmt.Errorf("scan FindOrdersMRR batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindOrdersMRR batch rows: %w", err)
+	}
+	return items, err
+}
@@ -1,2 +1,5 @@
 -- name: OutParams :many
 SELECT * FROM out_params();
+
+-- name: ColorSummary :one record=summary(first_color:text,distinct_colors:int8,total_colors:int8)
+SELECT color_summary(pggen.arg('colors')) AS summary;
@@ -13,6 +13,16 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	OutParams(ctx context.Context) ([]OutParamsRow, error)
+	// QueueOutParams queues the OutParams query into batch to send with SendBatch.
+	QueueOutParams(batch *pgx.Batch)
+	// OutParamsScan scans the results of a queued OutParams query.
+	OutParamsScan(results pgx.BatchResults) ([]OutParamsRow, error)
+
+	ColorSummary(ctx context.Context, colors []string) (ColorSummarySummary, error)
+	// QueueColorSummary queues the ColorSummary query into batch to send with SendBatch.
+	QueueColorSummary(batch *pgx.Batch, colors []string)
+	// ColorSummaryScan scans the results of a queued ColorSummary query.
+	ColorSummaryScan(results pgx.BatchResults) (ColorSummarySummary, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -27,6 +37,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -34,6 +45,13 @@ func NewQuerier(conn genericConn) *DBQuerier {
 	return &DBQuerier{conn: conn, types: newTypeResolver()}
 }
 
+// ColorSummarySummary represents an anonymous Postgres record, like ROW(1, 'foo').
+type ColorSummarySummary struct {
+	FirstColor     *string `json:"first_color"`
+	DistinctColors *int    `json:"distinct_colors"`
+	TotalColors    *int    `json:"total_colors"`
+}
+
 // ListItem represents the Postgres composite type "list_item".
 type ListItem struct {
 	Name  *string `json:"name"`
@@ -126,6 +144,17 @@ func (tr *typeResolver) newArrayValue(name, elemName string, defaultVal func() p
 	return typ
 }
 
+// newColorSummarySummary creates a new pgtype.ValueTranscoder for the Postgres
+// composite type 'ColorSummary_summary'.
+func (tr *typeResolver) newColorSummarySummary() pgtype.ValueTranscoder {
+	return tr.newCompositeValue(
+		"ColorSummary_summary",
+		compositeField{name: "first_color", typeName: "text", defaultVal: &pgtype.Text{}},
+		compositeField{name: "distinct_colors", typeName: "int8", defaultVal: &pgtype.Int8{}},
+		compositeField{name: "total_colors", typeName: "int8", defaultVal: &pgtype.Int8{}},
+	)
+}
+
 // newListItem creates a new pgtype.ValueTranscoder for the Postgres
 // composite type 'list_item'.
 func (tr *typeResolver) newListItem() pgtype.ValueTranscoder {
@@ -189,6 +218,76 @@ func (q *DBQuerier) OutParams(ctx context.Context) ([]OutParamsRow, error) {
 	return items, err
 }
 
+// QueueOutParams implements Querier.QueueOutParams.
+func (q *DBQuerier) QueueOutParams(batch *pgx.Batch) {
+	batch.Queue(outParamsSQL)
+}
+
+// OutParamsScan implements Querier.OutParamsScan.
+func (q *DBQuerier) OutParamsScan(results pgx.BatchResults) ([]OutParamsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query OutParams batch: %w", err)
+	}
+	defer rows.Close()
+	items := []OutParamsRow{}
+	itemsArray := q.types.newListItemArray()
+	statsRow := q.types.newListStats()
+	for rows.Next() {
+		var item OutParamsRow
+		if err := rows.Scan(itemsArray, statsRow); err != nil {
+			return nil, fmt.Errorf("scan OutParams batch row: %w", err)
+		}
+		if err := itemsArray.AssignTo(&item.Items); err != nil {
+			return nil, fmt.Errorf("assign OutParams row: %w", err)
+		}
+		if err := statsRow.AssignTo(&item.Stats); err != nil {
+			return nil, fmt.Errorf("assign OutParams row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close OutParams batch rows: %w", err)
+	}
+	return items, err
+}
+
+const colorSummarySQL = `SELECT color_summary($1) AS summary;`
+
+// ColorSummary implements Querier.ColorSummary.
+func (q *DBQuerier) ColorSummary(ctx context.Context, colors []string) (ColorSummarySummary, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ColorSummary")
+	row := q.conn.QueryRow(ctx, colorSummarySQL, colors)
+	var item ColorSummarySummary
+	summaryRow := q.types.newColorSummarySummary()
+	if err := row.Scan(summaryRow); err != nil {
+		return item, fmt.Errorf("query ColorSummary: %w", err)
+	}
+	if err := summaryRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ColorSummary row: %w", err)
+	}
+	return item, nil
+}
+
+// QueueColorSummary implements Querier.QueueColorSummary.
+func (q *DBQuerier) QueueColorSummary(batch *pgx.Batch, colors []string) {
+	batch.Queue(colorSummarySQL, colors)
+}
+
+// ColorSummaryScan implements Querier.ColorSummaryScan.
+func (q *DBQuerier) ColorSummaryScan(results pgx.BatchResults) (ColorSummarySummary, error) {
+	row := results.QueryRow()
+	var item ColorSummarySummary
+	summaryRow := q.types.newColorSummarySummary()
+	if err := row.Scan(summaryRow); err != nil {
+		return item, fmt.Errorf("scan ColorSummary batch row: %w", err)
+	}
+	if err := summaryRow.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ColorSummary row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -31,3 +31,32 @@ func TestNewQuerier_OutParams(t *testing.T) {
 		difftest.AssertSame(t, want, got)
 	})
 }
+
+func TestNewQuerier_ColorSummary(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+
+	q := NewQuerier(conn)
+
+	t.Run("ColorSummary", func(t *testing.T) {
+		got, err := q.ColorSummary(t.Context(), []string{"red", "blue", "red"})
+		require.NoError(t, err)
+		want := ColorSummarySummary{
+			FirstColor:     ptrs.String("blue"),
+			DistinctColors: ptrs.Int(2),
+			TotalColors:    ptrs.Int(3),
+		}
+		difftest.AssertSame(t, want, got)
+	})
+
+	t.Run("ColorSummary - null field", func(t *testing.T) {
+		got, err := q.ColorSummary(t.Context(), []string{})
+		require.NoError(t, err)
+		want := ColorSummarySummary{
+			FirstColor:     nil,
+			DistinctColors: ptrs.Int(0),
+			TotalColors:    ptrs.Int(0),
+		}
+		difftest.AssertSame(t, want, got)
+	})
+}
@@ -18,3 +18,8 @@ BEGIN
   _stats := ('abc', ARRAY [1, 2])::list_stats;
 END
 $$;
+
+CREATE OR REPLACE FUNCTION color_summary(colors text[]) RETURNS record
+  LANGUAGE sql AS $$
+SELECT min(c), count(DISTINCT c), count(*) FROM unnest(colors) AS c
+$$;
@@ -13,16 +13,40 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	GenSeries1(ctx context.Context) (*int, error)
+	// QueueGenSeries1 queues the GenSeries1 query into batch to send with SendBatch.
+	QueueGenSeries1(batch *pgx.Batch)
+	// GenSeries1Scan scans the results of a queued GenSeries1 query.
+	GenSeries1Scan(results pgx.BatchResults) (*int, error)
 
 	GenSeries(ctx context.Context) ([]*int, error)
+	// QueueGenSeries queues the GenSeries query into batch to send with SendBatch.
+	QueueGenSeries(batch *pgx.Batch)
+	// GenSeriesScan scans the results of a queued GenSeries query.
+	GenSeriesScan(results pgx.BatchResults) ([]*int, error)
 
 	GenSeriesArr1(ctx context.Context) ([]int, error)
+	// QueueGenSeriesArr1 queues the GenSeriesArr1 query into batch to send with SendBatch.
+	QueueGenSeriesArr1(batch *pgx.Batch)
+	// GenSeriesArr1Scan scans the results of a queued GenSeriesArr1 query.
+	GenSeriesArr1Scan(results pgx.BatchResults) ([]int, error)
 
 	GenSeriesArr(ctx context.Context) ([][]int, error)
+	// QueueGenSeriesArr queues the GenSeriesArr query into batch to send with SendBatch.
+	QueueGenSeriesArr(batch *pgx.Batch)
+	// GenSeriesArrScan scans the results of a queued GenSeriesArr query.
+	GenSeriesArrScan(results pgx.BatchResults) ([][]int, error)
 
 	GenSeriesStr1(ctx context.Context) (*string, error)
+	// QueueGenSeriesStr1 queues the GenSeriesStr1 query into batch to send with SendBatch.
+	QueueGenSeriesStr1(batch *pgx.Batch)
+	// GenSeriesStr1Scan scans the results of a queued GenSeriesStr1 query.
+	GenSeriesStr1Scan(results pgx.BatchResults) (*string, error)
 
 	GenSeriesStr(ctx context.Context) ([]*string, error)
+	// QueueGenSeriesStr queues the GenSeriesStr query into batch to send with SendBatch.
+	QueueGenSeriesStr(batch *pgx.Batch)
+	// GenSeriesStrScan scans the results of a queued GenSeriesStr query.
+	GenSeriesStrScan(results pgx.BatchResults) ([]*string, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -37,6 +61,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -88,6 +113,21 @@ func (q *DBQuerier) GenSeries1(ctx context.Context) (*int, error) {
 	return item, nil
 }
 
+// QueueGenSeries1 implements Querier.QueueGenSeries1.
+func (q *DBQuerier) QueueGenSeries1(batch *pgx.Batch) {
+	batch.Queue(genSeries1SQL)
+}
+
+// GenSeries1Scan implements Querier.GenSeries1Scan.
+func (q *DBQuerier) GenSeries1Scan(results pgx.BatchResults) (*int, error) {
+	row := results.QueryRow()
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GenSeries1 batch row: %w", err)
+	}
+	return item, nil
+}
+
 const genSeriesSQL = `SELECT n
 FROM generate_series(0, 2) n;`
 
@@ -113,6 +153,32 @@ func (q *DBQuerier) GenSeries(ctx context.Context) ([]*int, error) {
 	return items, err
 }
 
+// QueueGenSeries implements Querier.QueueGenSeries.
+func (q *DBQuerier) QueueGenSeries(batch *pgx.Batch) {
+	batch.Queue(genSeriesSQL)
+}
+
+// GenSeriesScan implements Querier.GenSeriesScan.
+func (q *DBQuerier) GenSeriesScan(results pgx.BatchResults) ([]*int, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query GenSeries batch: %w", err)
+	}
+	defer rows.Close()
+	items := []*int{}
+	for rows.Next() {
+		var item int
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan GenSeries batch row: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GenSeries batch rows: %w", err)
+	}
+	return items, err
+}
+
 const genSeriesArr1SQL = `SELECT array_agg(n)
 FROM generate_series(0, 2) n;`
 
@@ -127,6 +193,21 @@ func (q *DBQuerier) GenSeriesArr1(ctx context.Context) ([]int, error) {
 	return item, nil
 }
 
+// QueueGenSeriesArr1 implements Querier.QueueGenSeriesArr1.
+func (q *DBQuerier) QueueGenSeriesArr1(batch *pgx.Batch) {
+	batch.Queue(genSeriesArr1SQL)
+}
+
+// GenSeriesArr1Scan implements Querier.GenSeriesArr1Scan.
+func (q *DBQuerier) GenSeriesArr1Scan(results pgx.BatchResults) ([]int, error) {
+	row := results.QueryRow()
+	item := []int{}
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GenSeriesArr1 batch row: %w", err)
+	}
+	return item, nil
+}
+
 const genSeriesArrSQL = `SELECT array_agg(n)
 FROM generate_series(0, 2) n;`
 
@@ -152,6 +233,32 @@ func (q *DBQuerier) GenSeriesArr(ctx context.Context) ([][]int, error) {
 	return items, err
 }
 
+// QueueGenSeriesArr implements Querier.QueueGenSeriesArr.
+func (q *DBQuerier) QueueGenSeriesArr(batch *pgx.Batch) {
+	batch.Queue(genSeriesArrSQL)
+}
+
+// GenSeriesArrScan implements Querier.GenSeriesArrScan.
+func (q *DBQuerier) GenSeriesArrScan(results pgx.BatchResults) ([][]int, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query GenSeriesArr batch: %w", err)
+	}
+	defer rows.Close()
+	items := [][]int{}
+	for rows.Next() {
+		var item []int
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan GenSeriesArr batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GenSeriesArr batch rows: %w", err)
+	}
+	return items, err
+}
+
 const genSeriesStr1SQL = `SELECT n::text
 FROM generate_series(0, 2) n
 LIMIT 1;`
@@ -167,6 +274,21 @@ func (q *DBQuerier) GenSeriesStr1(ctx context.Context) (*string, error) {
 	return item, nil
 }
 
+// QueueGenSeriesStr1 implements Querier.QueueGenSeriesStr1.
+func (q *DBQuerier) QueueGenSeriesStr1(batch *pgx.Batch) {
+	batch.Queue(genSeriesStr1SQL)
+}
+
+// GenSeriesStr1Scan implements Querier.GenSeriesStr1Scan.
+func (q *DBQuerier) GenSeriesStr1Scan(results pgx.BatchResults) (*string, error) {
+	row := results.QueryRow()
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GenSeriesStr1 batch row: %w", err)
+	}
+	return item, nil
+}
+
 const genSeriesStrSQL = `SELECT n::text
 FROM generate_series(0, 2) n;`
 
@@ -192,6 +314,32 @@ func (q *DBQuerier) GenSeriesStr(ctx context.Context) ([]*string, error) {
 	return items, err
 }
 
+// QueueGenSeriesStr implements Querier.QueueGenSeriesStr.
+func (q *DBQuerier) QueueGenSeriesStr(batch *pgx.Batch) {
+	batch.Queue(genSeriesStrSQL)
+}
+
+// GenSeriesStrScan implements Querier.GenSeriesStrScan.
+func (q *DBQuerier) GenSeriesStrScan(results pgx.BatchResults) ([]*string, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query GenSeriesStr batch: %w", err)
+	}
+	defer rows.Close()
+	items := []*string{}
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan GenSeriesStr batch row: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GenSeriesStr batch rows: %w", err)
+	}
+	return items, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
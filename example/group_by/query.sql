@@ -0,0 +1,29 @@
+-- name: InsertAuthor :one
+INSERT INTO author (first_name, last_name)
+VALUES (pggen.arg('first_name'), pggen.arg('last_name'))
+RETURNING author_id;
+
+-- name: InsertBook :one
+INSERT INTO book (author_id, title)
+VALUES (pggen.arg('author_id'), pggen.arg('title'))
+RETURNING book_id;
+
+-- FindAuthorsByID finds authors keyed by author ID.
+-- name: FindAuthorsByID :map key=author_id
+SELECT * FROM author WHERE author_id = ANY(pggen.arg('author_ids')::int[]);
+
+-- FindBookAuthors finds the book title and author name keyed by the nullable
+-- book ID of a join.
+-- name: FindBookAuthors :map key=book_id
+SELECT b.book_id, b.title, a.first_name
+FROM book b
+  JOIN author a USING (author_id)
+WHERE b.book_id = ANY(pggen.arg('book_ids')::int[]);
+
+-- ListAuthorsWithBooks lists each author with their books. Authors without
+-- books have an empty slice of books.
+-- name: ListAuthorsWithBooks :many group-by=author_id
+SELECT a.author_id, a.first_name, b.book_id AS "books.book_id", b.title AS "books.title"
+FROM author a
+  LEFT JOIN book b USING (author_id)
+ORDER BY a.author_id, b.book_id;
@@ -0,0 +1,142 @@
+package group_by
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/jschaf/pggen/internal/ptrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuerier_FindAuthorsByID(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	washingtonID := insertAuthor(t, q, "george", "washington")
+
+	t.Run("FindAuthorsByID", func(t *testing.T) {
+		authors, err := q.FindAuthorsByID(t.Context(), []int32{adamsID, washingtonID, 888})
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]FindAuthorsByIDRow{
+			adamsID:      {AuthorID: adamsID, FirstName: "john", LastName: "adams"},
+			washingtonID: {AuthorID: washingtonID, FirstName: "george", LastName: "washington"},
+		}, authors)
+	})
+
+	t.Run("FindAuthorsByID - none-exists", func(t *testing.T) {
+		authors, err := q.FindAuthorsByID(t.Context(), []int32{888})
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]FindAuthorsByIDRow{}, authors)
+	})
+}
+
+func TestNewQuerier_FindBookAuthors(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	defenceID := insertBook(t, q, adamsID, "defence of the constitutions")
+	thoughtsID := insertBook(t, q, adamsID, "thoughts on government")
+
+	t.Run("FindBookAuthors", func(t *testing.T) {
+		books, err := q.FindBookAuthors(t.Context(), []int32{defenceID, thoughtsID})
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]FindBookAuthorsRow{
+			defenceID: {
+				BookID:    ptrs.Int32(defenceID),
+				Title:     ptrs.String("defence of the constitutions"),
+				FirstName: ptrs.String("john"),
+			},
+			thoughtsID: {
+				BookID:    ptrs.Int32(thoughtsID),
+				Title:     ptrs.String("thoughts on government"),
+				FirstName: ptrs.String("john"),
+			},
+		}, books)
+	})
+
+	t.Run("FindBookAuthors - batch", func(t *testing.T) {
+		batch := &pgx.Batch{}
+		q.QueueFindBookAuthors(batch, []int32{defenceID})
+		results := conn.SendBatch(t.Context(), batch)
+		defer results.Close()
+		books, err := q.FindBookAuthorsScan(results)
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]FindBookAuthorsRow{
+			defenceID: {
+				BookID:    ptrs.Int32(defenceID),
+				Title:     ptrs.String("defence of the constitutions"),
+				FirstName: ptrs.String("john"),
+			},
+		}, books)
+	})
+}
+
+func TestNewQuerier_ListAuthorsWithBooks(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	washingtonID := insertAuthor(t, q, "george", "washington")
+	defenceID := insertBook(t, q, adamsID, "defence of the constitutions")
+	thoughtsID := insertBook(t, q, adamsID, "thoughts on government")
+
+	authors, err := q.ListAuthorsWithBooks(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []ListAuthorsWithBooksRow{
+		{
+			AuthorID:  ptrs.Int32(adamsID),
+			FirstName: ptrs.String("john"),
+			Books: []ListAuthorsWithBooksBooksRow{
+				{BookID: ptrs.Int32(defenceID), Title: ptrs.String("defence of the constitutions")},
+				{BookID: ptrs.Int32(thoughtsID), Title: ptrs.String("thoughts on government")},
+			},
+		},
+		{
+			AuthorID:  ptrs.Int32(washingtonID),
+			FirstName: ptrs.String("george"),
+			Books:     []ListAuthorsWithBooksBooksRow{},
+		},
+	}, authors)
+}
+
+func TestNewQuerier_FindBookAuthors_DuplicateKey(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	defenceID := insertBook(t, q, adamsID, "defence of the constitutions")
+
+	// Drop the primary key to insert a second book with the same ID.
+	_, err := conn.Exec(t.Context(), "ALTER TABLE book DROP CONSTRAINT book_pkey")
+	require.NoError(t, err)
+	_, err = conn.Exec(t.Context(), "INSERT INTO book (book_id, author_id, title) VALUES ($1, $2, 'copy')", defenceID, adamsID)
+	require.NoError(t, err)
+
+	_, err = q.FindBookAuthors(t.Context(), []int32{defenceID})
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("expected duplicate key error to wrap ErrDuplicateKey; got %v", err)
+	}
+}
+
+func insertAuthor(t *testing.T, q *DBQuerier, first, last string) int32 {
+	t.Helper()
+	authorID, err := q.InsertAuthor(t.Context(), first, last)
+	require.NoError(t, err, "insert author")
+	return authorID
+}
+
+func insertBook(t *testing.T, q *DBQuerier, authorID int32, title string) int32 {
+	t.Helper()
+	bookID, err := q.InsertBook(t.Context(), authorID, title)
+	require.NoError(t, err, "insert book")
+	return bookID
+}
@@ -0,0 +1,11 @@
+CREATE TABLE author (
+  author_id  serial PRIMARY KEY,
+  first_name text NOT NULL,
+  last_name  text NOT NULL
+);
+
+CREATE TABLE book (
+  book_id   serial PRIMARY KEY,
+  author_id int  NOT NULL REFERENCES author (author_id),
+  title     text NOT NULL
+);
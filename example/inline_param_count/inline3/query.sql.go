@@ -14,15 +14,31 @@ import (
 type Querier interface {
 	// CountAuthors returns the number of authors (zero params).
 	CountAuthors(ctx context.Context) (*int, error)
+	// QueueCountAuthors queues the CountAuthors query into batch to send with SendBatch.
+	QueueCountAuthors(batch *pgx.Batch)
+	// CountAuthorsScan scans the results of a queued CountAuthors query.
+	CountAuthorsScan(results pgx.BatchResults) (*int, error)
 
 	// FindAuthorById finds one (or zero) authors by ID (one param).
 	FindAuthorByID(ctx context.Context, authorID int32) (FindAuthorByIDRow, error)
+	// QueueFindAuthorByID queues the FindAuthorByID query into batch to send with SendBatch.
+	QueueFindAuthorByID(batch *pgx.Batch, authorID int32)
+	// FindAuthorByIDScan scans the results of a queued FindAuthorByID query.
+	FindAuthorByIDScan(results pgx.BatchResults) (FindAuthorByIDRow, error)
 
 	// InsertAuthor inserts an author by name and returns the ID (two params).
 	InsertAuthor(ctx context.Context, firstName string, lastName string) (int32, error)
+	// QueueInsertAuthor queues the InsertAuthor query into batch to send with SendBatch.
+	QueueInsertAuthor(batch *pgx.Batch, firstName string, lastName string)
+	// InsertAuthorScan scans the results of a queued InsertAuthor query.
+	InsertAuthorScan(results pgx.BatchResults) (int32, error)
 
 	// DeleteAuthorsByFullName deletes authors by the full name (three params).
 	DeleteAuthorsByFullName(ctx context.Context, firstName string, lastName string, suffix string) (pgconn.CommandTag, error)
+	// QueueDeleteAuthorsByFullName queues the DeleteAuthorsByFullName query into batch to send with SendBatch.
+	QueueDeleteAuthorsByFullName(batch *pgx.Batch, firstName string, lastName string, suffix string)
+	// DeleteAuthorsByFullNameScan scans the results of a queued DeleteAuthorsByFullName query.
+	DeleteAuthorsByFullNameScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -37,6 +53,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -86,6 +103,21 @@ func (q *DBQuerier) CountAuthors(ctx context.Context) (*int, error) {
 	return item, nil
 }
 
+// QueueCountAuthors implements Querier.QueueCountAuthors.
+func (q *DBQuerier) QueueCountAuthors(batch *pgx.Batch) {
+	batch.Queue(countAuthorsSQL)
+}
+
+// CountAuthorsScan implements Querier.CountAuthorsScan.
+func (q *DBQuerier) CountAuthorsScan(results pgx.BatchResults) (*int, error) {
+	row := results.QueryRow()
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CountAuthors batch row: %w", err)
+	}
+	return item, nil
+}
+
 const findAuthorByIDSQL = `SELECT * FROM author WHERE author_id = $1;`
 
 type FindAuthorByIDRow struct {
@@ -106,6 +138,21 @@ func (q *DBQuerier) FindAuthorByID(ctx context.Context, authorID int32) (FindAut
 	return item, nil
 }
 
+// QueueFindAuthorByID implements Querier.QueueFindAuthorByID.
+func (q *DBQuerier) QueueFindAuthorByID(batch *pgx.Batch, authorID int32) {
+	batch.Queue(findAuthorByIDSQL, authorID)
+}
+
+// FindAuthorByIDScan implements Querier.FindAuthorByIDScan.
+func (q *DBQuerier) FindAuthorByIDScan(results pgx.BatchResults) (FindAuthorByIDRow, error) {
+	row := results.QueryRow()
+	var item FindAuthorByIDRow
+	if err := row.Scan(&item.AuthorID, &item.FirstName, &item.LastName, &item.Suffix); err != nil {
+		return item, fmt.Errorf("scan FindAuthorByID batch row: %w", err)
+	}
+	return item, nil
+}
+
 const insertAuthorSQL = `INSERT INTO author (first_name, last_name)
 VALUES ($1, $2)
 RETURNING author_id;`
@@ -121,6 +168,21 @@ func (q *DBQuerier) InsertAuthor(ctx context.Context, firstName string, lastName
 	return item, nil
 }
 
+// QueueInsertAuthor implements Querier.QueueInsertAuthor.
+func (q *DBQuerier) QueueInsertAuthor(batch *pgx.Batch, firstName string, lastName string) {
+	batch.Queue(insertAuthorSQL, firstName, lastName)
+}
+
+// InsertAuthorScan implements Querier.InsertAuthorScan.
+func (q *DBQuerier) InsertAuthorScan(results pgx.BatchResults) (int32, error) {
+	row := results.QueryRow()
+	var item int32
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan InsertAuthor batch row: %w", err)
+	}
+	return item, nil
+}
+
 const deleteAuthorsByFullNameSQL = `DELETE
 FROM author
 WHERE first_name = $1
@@ -137,6 +199,20 @@ func (q *DBQuerier) DeleteAuthorsByFullName(ctx context.Context, firstName strin
 	return cmdTag, err
 }
 
+// QueueDeleteAuthorsByFullName implements Querier.QueueDeleteAuthorsByFullName.
+func (q *DBQuerier) QueueDeleteAuthorsByFullName(batch *pgx.Batch, firstName string, lastName string, suffix string) {
+	batch.Queue(deleteAuthorsByFullNameSQL, firstName, lastName, suffix)
+}
+
+// DeleteAuthorsByFullNameScan implements Querier.DeleteAuthorsByFullNameScan.
+func (q *DBQuerier) DeleteAuthorsByFullNameScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query DeleteAuthorsByFullName: %w", err)
+	}
+	return cmdTag, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -13,12 +13,28 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	FindTopScienceChildren(ctx context.Context) ([]pgtype.Text, error)
+	// QueueFindTopScienceChildren queues the FindTopScienceChildren query into batch to send with SendBatch.
+	QueueFindTopScienceChildren(batch *pgx.Batch)
+	// FindTopScienceChildrenScan scans the results of a queued FindTopScienceChildren query.
+	FindTopScienceChildrenScan(results pgx.BatchResults) ([]pgtype.Text, error)
 
 	FindTopScienceChildrenAgg(ctx context.Context) (pgtype.TextArray, error)
+	// QueueFindTopScienceChildrenAgg queues the FindTopScienceChildrenAgg query into batch to send with SendBatch.
+	QueueFindTopScienceChildrenAgg(batch *pgx.Batch)
+	// FindTopScienceChildrenAggScan scans the results of a queued FindTopScienceChildrenAgg query.
+	FindTopScienceChildrenAggScan(results pgx.BatchResults) (pgtype.TextArray, error)
 
 	InsertSampleData(ctx context.Context) (pgconn.CommandTag, error)
+	// QueueInsertSampleData queues the InsertSampleData query into batch to send with SendBatch.
+	QueueInsertSampleData(batch *pgx.Batch)
+	// InsertSampleDataScan scans the results of a queued InsertSampleData query.
+	InsertSampleDataScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	FindLtreeInput(ctx context.Context, inLtree pgtype.Text, inLtreeArray []string) (FindLtreeInputRow, error)
+	// QueueFindLtreeInput queues the FindLtreeInput query into batch to send with SendBatch.
+	QueueFindLtreeInput(batch *pgx.Batch, inLtree pgtype.Text, inLtreeArray []string)
+	// FindLtreeInputScan scans the results of a queued FindLtreeInput query.
+	FindLtreeInputScan(results pgx.BatchResults) (FindLtreeInputRow, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -33,6 +49,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -95,6 +112,32 @@ func (q *DBQuerier) FindTopScienceChildren(ctx context.Context) ([]pgtype.Text,
 	return items, err
 }
 
+// QueueFindTopScienceChildren implements Querier.QueueFindTopScienceChildren.
+func (q *DBQuerier) QueueFindTopScienceChildren(batch *pgx.Batch) {
+	batch.Queue(findTopScienceChildrenSQL)
+}
+
+// FindTopScienceChildrenScan implements Querier.FindTopScienceChildrenScan.
+func (q *DBQuerier) FindTopScienceChildrenScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindTopScienceChildren batch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindTopScienceChildren batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindTopScienceChildren batch rows: %w", err)
+	}
+	return items, err
+}
+
 const findTopScienceChildrenAggSQL = `SELECT array_agg(path)
 FROM test
 WHERE path <@ 'Top.Science';`
@@ -110,6 +153,21 @@ func (q *DBQuerier) FindTopScienceChildrenAgg(ctx context.Context) (pgtype.TextA
 	return item, nil
 }
 
+// QueueFindTopScienceChildrenAgg implements Querier.QueueFindTopScienceChildrenAgg.
+func (q *DBQuerier) QueueFindTopScienceChildrenAgg(batch *pgx.Batch) {
+	batch.Queue(findTopScienceChildrenAggSQL)
+}
+
+// FindTopScienceChildrenAggScan implements Querier.FindTopScienceChildrenAggScan.
+func (q *DBQuerier) FindTopScienceChildrenAggScan(results pgx.BatchResults) (pgtype.TextArray, error) {
+	row := results.QueryRow()
+	var item pgtype.TextArray
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan FindTopScienceChildrenAgg batch row: %w", err)
+	}
+	return item, nil
+}
+
 const insertSampleDataSQL = `INSERT INTO test
 VALUES ('Top'),
        ('Top.Science'),
@@ -135,6 +193,20 @@ func (q *DBQuerier) InsertSampleData(ctx context.Context) (pgconn.CommandTag, er
 	return cmdTag, err
 }
 
+// QueueInsertSampleData implements Querier.QueueInsertSampleData.
+func (q *DBQuerier) QueueInsertSampleData(batch *pgx.Batch) {
+	batch.Queue(insertSampleDataSQL)
+}
+
+// InsertSampleDataScan implements Querier.InsertSampleDataScan.
+func (q *DBQuerier) InsertSampleDataScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query InsertSampleData: %w", err)
+	}
+	return cmdTag, err
+}
+
 const findLtreeInputSQL = `SELECT
   $1::ltree                   AS ltree,
   -- This won't work, but I'm not quite sure why.
@@ -162,6 +234,21 @@ func (q *DBQuerier) FindLtreeInput(ctx context.Context, inLtree pgtype.Text, inL
 	return item, nil
 }
 
+// QueueFindLtreeInput implements Querier.QueueFindLtreeInput.
+func (q *DBQuerier) QueueFindLtreeInput(batch *pgx.Batch, inLtree pgtype.Text, inLtreeArray []string) {
+	batch.Queue(findLtreeInputSQL, inLtree, inLtreeArray)
+}
+
+// FindLtreeInputScan implements Querier.FindLtreeInputScan.
+func (q *DBQuerier) FindLtreeInputScan(results pgx.BatchResults) (FindLtreeInputRow, error) {
+	row := results.QueryRow()
+	var item FindLtreeInputRow
+	if err := row.Scan(&item.Ltree, &item.TextArr); err != nil {
+		return item, fmt.Errorf("scan FindLtreeInput batch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -0,0 +1,7 @@
+-- name: InsertBoard :one
+INSERT INTO board (cells)
+VALUES (pggen.arg('cells')::int4[][])
+RETURNING board_id;
+
+-- name: FindBoard :one
+SELECT cells FROM board WHERE board_id = pggen.arg('board_id');
@@ -0,0 +1,53 @@
+package multi_array
+
+import (
+	"testing"
+
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/jschaf/pggen/internal/ptrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuerier_FindBoard(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+
+	t.Run("FindBoard - nulls", func(t *testing.T) {
+		cells := [][]*int32{
+			{ptrs.Int32(1), nil, ptrs.Int32(3)},
+			{nil, ptrs.Int32(5), nil},
+		}
+		boardID, err := q.InsertBoard(t.Context(), cells)
+		require.NoError(t, err)
+		got, err := q.FindBoard(t.Context(), boardID)
+		require.NoError(t, err)
+		assert.Equal(t, cells, got)
+	})
+
+	t.Run("FindBoard - empty", func(t *testing.T) {
+		boardID, err := q.InsertBoard(t.Context(), [][]*int32{})
+		require.NoError(t, err)
+		got, err := q.FindBoard(t.Context(), boardID)
+		require.NoError(t, err)
+		assert.Equal(t, [][]*int32{}, got)
+	})
+
+	t.Run("InsertBoard - ragged", func(t *testing.T) {
+		_, err := q.InsertBoard(t.Context(), [][]*int32{
+			{ptrs.Int32(1), ptrs.Int32(2)},
+			{ptrs.Int32(3)},
+		})
+		assert.ErrorContains(t, err, "dimension 2: slices must have the same length; got lengths 2 and 1")
+	})
+
+	t.Run("FindBoard - wrong dimensions", func(t *testing.T) {
+		// Postgres doesn't enforce the declared dimensions of a column.
+		var boardID int32
+		err := conn.QueryRow(t.Context(), "INSERT INTO board (cells) VALUES ('{1,2}') RETURNING board_id").Scan(&boardID)
+		require.NoError(t, err)
+		_, err = q.FindBoard(t.Context(), boardID)
+		assert.ErrorContains(t, err, "got 1-dimensional array; want 2 dimensions")
+	})
+}
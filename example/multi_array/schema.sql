@@ -0,0 +1,4 @@
+CREATE TABLE board (
+  board_id serial PRIMARY KEY,
+  cells    int4[][] NOT NULL
+);
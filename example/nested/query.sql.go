@@ -13,8 +13,16 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	ArrayNested2(ctx context.Context) ([]ProductImageType, error)
+	// QueueArrayNested2 queues the ArrayNested2 query into batch to send with SendBatch.
+	QueueArrayNested2(batch *pgx.Batch)
+	// ArrayNested2Scan scans the results of a queued ArrayNested2 query.
+	ArrayNested2Scan(results pgx.BatchResults) ([]ProductImageType, error)
 
 	Nested3(ctx context.Context) ([]ProductImageSetType, error)
+	// QueueNested3 queues the Nested3 query into batch to send with SendBatch.
+	QueueNested3(batch *pgx.Batch)
+	// Nested3Scan scans the results of a queued Nested3 query.
+	Nested3Scan(results pgx.BatchResults) ([]ProductImageSetType, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -29,6 +37,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -193,6 +202,25 @@ func (q *DBQuerier) ArrayNested2(ctx context.Context) ([]ProductImageType, error
 	return item, nil
 }
 
+// QueueArrayNested2 implements Querier.QueueArrayNested2.
+func (q *DBQuerier) QueueArrayNested2(batch *pgx.Batch) {
+	batch.Queue(arrayNested2SQL)
+}
+
+// ArrayNested2Scan implements Querier.ArrayNested2Scan.
+func (q *DBQuerier) ArrayNested2Scan(results pgx.BatchResults) ([]ProductImageType, error) {
+	row := results.QueryRow()
+	item := []ProductImageType{}
+	imagesArray := q.types.newProductImageTypeArray()
+	if err := row.Scan(imagesArray); err != nil {
+		return item, fmt.Errorf("scan ArrayNested2 batch row: %w", err)
+	}
+	if err := imagesArray.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign ArrayNested2 row: %w", err)
+	}
+	return item, nil
+}
+
 const nested3SQL = `SELECT
   ROW (
     'name', -- name
@@ -229,6 +257,36 @@ func (q *DBQuerier) Nested3(ctx context.Context) ([]ProductImageSetType, error)
 	return items, err
 }
 
+// QueueNested3 implements Querier.QueueNested3.
+func (q *DBQuerier) QueueNested3(batch *pgx.Batch) {
+	batch.Queue(nested3SQL)
+}
+
+// Nested3Scan implements Querier.Nested3Scan.
+func (q *DBQuerier) Nested3Scan(results pgx.BatchResults) ([]ProductImageSetType, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query Nested3 batch: %w", err)
+	}
+	defer rows.Close()
+	items := []ProductImageSetType{}
+	rowRow := q.types.newProductImageSetType()
+	for rows.Next() {
+		var item ProductImageSetType
+		if err := rows.Scan(rowRow); err != nil {
+			return nil, fmt.Errorf("scan Nested3 batch row: %w", err)
+		}
+		if err := rowRow.AssignTo(&item); err != nil {
+			return nil, fmt.Errorf("assign Nested3 row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close Nested3 batch rows: %w", err)
+	}
+	return items, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
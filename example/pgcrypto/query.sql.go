@@ -13,8 +13,16 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	CreateUser(ctx context.Context, email string, password string) (pgconn.CommandTag, error)
+	// QueueCreateUser queues the CreateUser query into batch to send with SendBatch.
+	QueueCreateUser(batch *pgx.Batch, email string, password string)
+	// CreateUserScan scans the results of a queued CreateUser query.
+	CreateUserScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	FindUser(ctx context.Context, email string) (FindUserRow, error)
+	// QueueFindUser queues the FindUser query into batch to send with SendBatch.
+	QueueFindUser(batch *pgx.Batch, email string)
+	// FindUserScan scans the results of a queued FindUser query.
+	FindUserScan(results pgx.BatchResults) (FindUserRow, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -29,6 +37,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -78,6 +87,20 @@ func (q *DBQuerier) CreateUser(ctx context.Context, email string, password strin
 	return cmdTag, err
 }
 
+// QueueCreateUser implements Querier.QueueCreateUser.
+func (q *DBQuerier) QueueCreateUser(batch *pgx.Batch, email string, password string) {
+	batch.Queue(createUserSQL, email, password)
+}
+
+// CreateUserScan implements Querier.CreateUserScan.
+func (q *DBQuerier) CreateUserScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query CreateUser: %w", err)
+	}
+	return cmdTag, err
+}
+
 const findUserSQL = `SELECT email, pass from "user"
 where email = $1;`
 
@@ -97,6 +120,21 @@ func (q *DBQuerier) FindUser(ctx context.Context, email string) (FindUserRow, er
 	return item, nil
 }
 
+// QueueFindUser implements Querier.QueueFindUser.
+func (q *DBQuerier) QueueFindUser(batch *pgx.Batch, email string) {
+	batch.Queue(findUserSQL, email)
+}
+
+// FindUserScan implements Querier.FindUserScan.
+func (q *DBQuerier) FindUserScan(results pgx.BatchResults) (FindUserRow, error) {
+	row := results.QueryRow()
+	var item FindUserRow
+	if err := row.Scan(&item.Email, &item.Pass); err != nil {
+		return item, fmt.Errorf("scan FindUser batch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
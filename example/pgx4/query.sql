@@ -0,0 +1,34 @@
+-- InsertTasks copies tasks into the task table. The columns are in a
+-- different order than the table columns.
+-- name: InsertTasks :copyfrom
+INSERT INTO task (title, note, project)
+VALUES (pggen.arg('title'), pggen.arg('note'), pggen.arg('project'));
+
+-- FindTask finds one task by ID or returns nil if none exists.
+-- name: FindTask :opt row-type=Task
+SELECT task_id, project, title, note FROM task WHERE task_id = pggen.arg('task_id');
+
+-- FindTaskByTitle finds one task by title or returns nil if none exists.
+-- name: FindTaskByTitle :opt row-type=Task
+SELECT task_id, project, title, note FROM task WHERE title = pggen.arg('title');
+
+-- ListTasks lists the tasks of a project in chunks with a server-side cursor.
+-- name: ListTasks :many cursor=2 row-type=Task
+SELECT task_id, project, title, note
+FROM task
+WHERE project = pggen.arg('project')
+ORDER BY task_id;
+
+-- StreamTaskTitles yields the task titles of a project one at a time.
+-- name: StreamTaskTitles :iter
+SELECT title FROM task WHERE project = pggen.arg('project') ORDER BY task_id;
+
+-- RenameTask renames a task and errors if the task doesn't exist or another
+-- update changed the version.
+-- name: RenameTask :exec affected=1
+UPDATE task
+SET title = pggen.arg('title'), version = version + 1
+WHERE task_id = pggen.arg('task_id') AND version = pggen.arg('version');
+
+-- name: DeleteProjectTasks :execrows
+DELETE FROM task WHERE project = pggen.arg('project');
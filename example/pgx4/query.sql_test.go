@@ -0,0 +1,185 @@
+package pgx4
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/jschaf/pggen/internal/ptrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuerier_InsertTasks(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+
+	// The copy columns are in a different order than the table columns, so
+	// swapped values would land in the wrong columns.
+	n, err := q.InsertTasks(t.Context(), []InsertTasksParams{
+		{Title: "draft", Note: "first pass", Project: "docs"},
+		{Title: "review", Note: "second pass", Project: "docs"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	task, err := q.FindTaskByTitle(t.Context(), "draft")
+	require.NoError(t, err)
+	require.NotNil(t, task)
+	assert.Equal(t, "docs", task.Project)
+	assert.Equal(t, "draft", task.Title)
+	assert.Equal(t, ptrs.String("first pass"), task.Note)
+
+	var titles []string
+	for title, err := range q.StreamTaskTitles(t.Context(), "docs") {
+		require.NoError(t, err)
+		titles = append(titles, title)
+	}
+	assert.Equal(t, []string{"draft", "review"}, titles)
+}
+
+func TestNewQuerier_FindTask(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	insertTasks(t, q, "docs", "draft", "draft")
+
+	t.Run("FindTask", func(t *testing.T) {
+		tasks, err := q.ListTasks(t.Context(), "docs")
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		task, err := q.FindTask(t.Context(), tasks[0].TaskID)
+		require.NoError(t, err)
+		assert.Equal(t, &tasks[0], task)
+	})
+
+	t.Run("FindTask - none-exists", func(t *testing.T) {
+		task, err := q.FindTask(t.Context(), 888)
+		require.NoError(t, err)
+		assert.Nil(t, task)
+	})
+
+	t.Run("FindTaskByTitle - too many rows", func(t *testing.T) {
+		_, err := q.FindTaskByTitle(t.Context(), "draft")
+		if !errors.Is(err, ErrTooManyRows) {
+			t.Fatalf("expected error to wrap ErrTooManyRows; got %v", err)
+		}
+	})
+}
+
+func TestNewQuerier_ListTasksCursor(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	insertTasks(t, q, "docs", "draft", "review", "publish")
+
+	t.Run("ListTasksCursor", func(t *testing.T) {
+		tx, err := conn.Begin(t.Context())
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback(t.Context()) }()
+		var chunkSizes []int
+		var titles []string
+		for tasks, err := range q.ListTasksCursor(t.Context(), tx, "docs") {
+			require.NoError(t, err)
+			chunkSizes = append(chunkSizes, len(tasks))
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+		}
+		assert.Equal(t, []int{2, 1}, chunkSizes)
+		assert.Equal(t, []string{"draft", "review", "publish"}, titles)
+		assert.Equal(t, 0, countCursors(t, tx))
+	})
+
+	t.Run("ListTasksCursor - stop early", func(t *testing.T) {
+		tx, err := conn.Begin(t.Context())
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback(t.Context()) }()
+		for _, err := range q.ListTasksCursor(t.Context(), tx, "docs") {
+			require.NoError(t, err)
+			break
+		}
+		assert.Equal(t, 0, countCursors(t, tx))
+	})
+
+	t.Run("ListTasksCursor - canceled", func(t *testing.T) {
+		tx, err := conn.Begin(t.Context())
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback(t.Context()) }()
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		var errs []error
+		for _, err := range q.ListTasksCursor(ctx, tx, "docs") {
+			errs = append(errs, err)
+			cancel() // cancel before fetching the next chunk
+		}
+		require.Len(t, errs, 2)
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], context.Canceled)
+		assert.Equal(t, 0, countCursors(t, tx))
+	})
+}
+
+func TestNewQuerier_RenameTask(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	insertTasks(t, q, "docs", "draft")
+	task, err := q.FindTaskByTitle(t.Context(), "draft")
+	require.NoError(t, err)
+	require.NotNil(t, task)
+
+	t.Run("RenameTask", func(t *testing.T) {
+		_, err := q.RenameTask(t.Context(), RenameTaskParams{Title: "outline", TaskID: task.TaskID, Version: 1})
+		require.NoError(t, err)
+		renamed, err := q.FindTask(t.Context(), task.TaskID)
+		require.NoError(t, err)
+		require.NotNil(t, renamed)
+		assert.Equal(t, "outline", renamed.Title)
+	})
+
+	t.Run("RenameTask - stale version", func(t *testing.T) {
+		_, err := q.RenameTask(t.Context(), RenameTaskParams{Title: "final", TaskID: task.TaskID, Version: 1})
+		if !errors.Is(err, ErrUnexpectedRowsAffected) {
+			t.Fatalf("expected error to wrap ErrUnexpectedRowsAffected; got %v", err)
+		}
+	})
+}
+
+func TestNewQuerier_DeleteProjectTasks(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	insertTasks(t, q, "docs", "draft", "review")
+	insertTasks(t, q, "site", "deploy")
+
+	n, err := q.DeleteProjectTasks(t.Context(), "docs")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = q.DeleteProjectTasks(t.Context(), "docs")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
+
+func insertTasks(t *testing.T, q *DBQuerier, project string, titles ...string) {
+	t.Helper()
+	params := make([]InsertTasksParams, len(titles))
+	for i, title := range titles {
+		params[i] = InsertTasksParams{Title: title, Project: project}
+	}
+	_, err := q.InsertTasks(t.Context(), params)
+	require.NoError(t, err, "insert tasks")
+}
+
+// countCursors counts the open server-side cursors in tx.
+func countCursors(t *testing.T, tx pgx.Tx) int {
+	t.Helper()
+	var n int
+	err := tx.QueryRow(t.Context(), "SELECT count(*) FROM pg_cursors").Scan(&n)
+	require.NoError(t, err, "count cursors")
+	return n
+}
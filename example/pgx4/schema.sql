@@ -0,0 +1,7 @@
+CREATE TABLE task (
+  task_id serial PRIMARY KEY,
+  project text NOT NULL,
+  title   text NOT NULL,
+  note    text NULL,
+  version int4 NOT NULL DEFAULT 1
+);
@@ -0,0 +1,30 @@
+-- name: InsertAuthor :one
+INSERT INTO author (first_name, last_name)
+VALUES (pggen.arg('first_name'), pggen.arg('last_name'))
+RETURNING author_id;
+
+-- name: InsertAuthors :copyfrom
+INSERT INTO author (first_name, last_name)
+VALUES (pggen.arg('first_name'), pggen.arg('last_name'));
+
+-- FindAuthorByID finds one author by ID or returns nil if none exists.
+-- name: FindAuthorByID :opt
+SELECT * FROM author WHERE author_id = pggen.arg('author_id');
+
+-- name: FindAuthorsByStatus :many
+SELECT author_id, first_name, status FROM author WHERE status = pggen.arg('status');
+
+-- StreamAuthors yields authors one at a time without loading every row.
+-- name: StreamAuthors :iter
+SELECT author_id, first_name FROM author WHERE author_id > pggen.arg('after_id');
+
+-- ListAuthors fetches authors in chunks with a server-side cursor.
+-- name: ListAuthors :many cursor=2
+SELECT author_id, first_name FROM author WHERE author_id > pggen.arg('after_id');
+
+-- RetireAuthor retires an author and errors if the author doesn't exist.
+-- name: RetireAuthor :exec affected=1
+UPDATE author SET status = 'retired' WHERE author_id = pggen.arg('author_id');
+
+-- name: DeleteRetiredAuthors :execrows
+DELETE FROM author WHERE status = 'retired';
@@ -0,0 +1,170 @@
+package pgx5
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConn opens a pgx v5 connection to a new schema with the types used by
+// the queries registered.
+func newTestConn(t *testing.T) (*pgx.Conn, pgtest.CleanupFunc) {
+	t.Helper()
+	schemaConn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	conn, err := pgx.Connect(t.Context(), schemaConn.Config().ConnString())
+	if err != nil {
+		cleanup()
+		t.Fatalf("connect with pgx v5: %s", err)
+	}
+	if err := RegisterTypes(t.Context(), conn); err != nil {
+		cleanup()
+		t.Fatalf("register types: %s", err)
+	}
+	return conn, func() {
+		if err := conn.Close(t.Context()); err != nil {
+			t.Errorf("close pgx v5 conn: %s", err)
+		}
+		cleanup()
+	}
+}
+
+func TestNewQuerier_FindAuthorByID(t *testing.T) {
+	conn, cleanup := newTestConn(t)
+	defer cleanup()
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+
+	t.Run("FindAuthorByID", func(t *testing.T) {
+		author, err := q.FindAuthorByID(t.Context(), adamsID)
+		require.NoError(t, err)
+		assert.Equal(t, &FindAuthorByIDRow{
+			AuthorID:  adamsID,
+			FirstName: "john",
+			LastName:  "adams",
+			Suffix:    nil,
+			Status:    AuthorStatusActive,
+		}, author)
+	})
+
+	t.Run("FindAuthorByID - none-exists", func(t *testing.T) {
+		author, err := q.FindAuthorByID(t.Context(), 888)
+		require.NoError(t, err)
+		assert.Nil(t, author)
+	})
+}
+
+func TestNewQuerier_FindAuthorsByStatus(t *testing.T) {
+	conn, cleanup := newTestConn(t)
+	defer cleanup()
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	washingtonID := insertAuthor(t, q, "george", "washington")
+	_, err := q.RetireAuthor(t.Context(), washingtonID)
+	require.NoError(t, err)
+
+	t.Run("FindAuthorsByStatus", func(t *testing.T) {
+		authors, err := q.FindAuthorsByStatus(t.Context(), AuthorStatusRetired)
+		require.NoError(t, err)
+		assert.Equal(t, []FindAuthorsByStatusRow{
+			{AuthorID: washingtonID, FirstName: "george", Status: AuthorStatusRetired},
+		}, authors)
+	})
+
+	t.Run("FindAuthorsByStatus - batch", func(t *testing.T) {
+		batch := &pgx.Batch{}
+		q.QueueFindAuthorsByStatus(batch, AuthorStatusActive)
+		results := conn.SendBatch(t.Context(), batch)
+		defer results.Close()
+		authors, err := q.FindAuthorsByStatusScan(results)
+		require.NoError(t, err)
+		assert.Equal(t, []FindAuthorsByStatusRow{
+			{AuthorID: adamsID, FirstName: "john", Status: AuthorStatusActive},
+		}, authors)
+	})
+}
+
+func TestNewQuerier_InsertAuthors(t *testing.T) {
+	conn, cleanup := newTestConn(t)
+	defer cleanup()
+	q := NewQuerier(conn)
+
+	n, err := q.InsertAuthors(t.Context(), []InsertAuthorsParams{
+		{FirstName: "john", LastName: "adams"},
+		{FirstName: "george", LastName: "washington"},
+		{FirstName: "thomas", LastName: "jefferson"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	var names []string
+	for author, err := range q.StreamAuthors(t.Context(), 0) {
+		require.NoError(t, err)
+		names = append(names, author.FirstName)
+	}
+	assert.ElementsMatch(t, []string{"john", "george", "thomas"}, names)
+}
+
+func TestNewQuerier_ListAuthorsCursor(t *testing.T) {
+	conn, cleanup := newTestConn(t)
+	defer cleanup()
+	q := NewQuerier(conn)
+	for _, name := range []string{"john", "george", "thomas"} {
+		insertAuthor(t, q, name, "smith")
+	}
+
+	tx, err := conn.Begin(t.Context())
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(t.Context()) }()
+	var chunkSizes []int
+	var names []string
+	for authors, err := range q.ListAuthorsCursor(t.Context(), tx, 0) {
+		require.NoError(t, err)
+		chunkSizes = append(chunkSizes, len(authors))
+		for _, author := range authors {
+			names = append(names, author.FirstName)
+		}
+	}
+	assert.Equal(t, []int{2, 1}, chunkSizes)
+	assert.ElementsMatch(t, []string{"john", "george", "thomas"}, names)
+}
+
+func TestNewQuerier_RetireAuthor(t *testing.T) {
+	conn, cleanup := newTestConn(t)
+	defer cleanup()
+	q := NewQuerier(conn)
+	adamsID := insertAuthor(t, q, "john", "adams")
+	insertAuthor(t, q, "george", "washington")
+
+	t.Run("RetireAuthor", func(t *testing.T) {
+		_, err := q.RetireAuthor(t.Context(), adamsID)
+		require.NoError(t, err)
+		author, err := q.FindAuthorByID(t.Context(), adamsID)
+		require.NoError(t, err)
+		require.NotNil(t, author)
+		assert.Equal(t, AuthorStatusRetired, author.Status)
+	})
+
+	t.Run("RetireAuthor - none-exists", func(t *testing.T) {
+		_, err := q.RetireAuthor(t.Context(), 888)
+		if !errors.Is(err, ErrUnexpectedRowsAffected) {
+			t.Fatalf("expected error to wrap ErrUnexpectedRowsAffected; got %v", err)
+		}
+	})
+
+	t.Run("DeleteRetiredAuthors", func(t *testing.T) {
+		n, err := q.DeleteRetiredAuthors(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+}
+
+func insertAuthor(t *testing.T, q *DBQuerier, first, last string) int32 {
+	t.Helper()
+	authorID, err := q.InsertAuthor(t.Context(), first, last)
+	require.NoError(t, err, "insert author")
+	return authorID
+}
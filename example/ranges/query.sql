@@ -0,0 +1,15 @@
+-- name: InsertBooking :one
+INSERT INTO booking (period, seats, price)
+VALUES (pggen.arg('period'), pggen.arg('seats'), pggen.arg('price'))
+RETURNING booking_id;
+
+-- name: FindBooking :one
+SELECT period, seats, price FROM booking WHERE booking_id = pggen.arg('booking_id');
+
+-- FindBookingsDuring finds the bookings with a period that overlaps the
+-- period.
+-- name: FindBookingsDuring :many
+SELECT booking_id FROM booking WHERE period && pggen.arg('period') ORDER BY booking_id;
+
+-- name: ClearBookingPrice :exec
+UPDATE booking SET price = NULL WHERE booking_id = pggen.arg('booking_id');
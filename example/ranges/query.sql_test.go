@@ -0,0 +1,115 @@
+package ranges
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	jan1 = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb1 = time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mar1 = time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestNewQuerier_FindBooking(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+
+	t.Run("FindBooking - inclusive and exclusive bounds", func(t *testing.T) {
+		bookingID, err := q.InsertBooking(t.Context(), InsertBookingParams{
+			Period: Range[time.Time]{Lower: jan1, Upper: feb1, LowerInclusive: true},
+			Seats:  Range[int32]{Lower: 2, Upper: 4, LowerInclusive: true, UpperInclusive: true},
+			Price:  Range[float64]{Lower: 1.5, Upper: 2.5, UpperInclusive: true},
+		})
+		require.NoError(t, err)
+		booking, err := q.FindBooking(t.Context(), bookingID)
+		require.NoError(t, err)
+		assert.Equal(t, FindBookingRow{
+			Period: Range[time.Time]{Lower: jan1, Upper: feb1, LowerInclusive: true},
+			// Postgres normalizes discrete ranges to an exclusive upper bound.
+			Seats: Range[int32]{Lower: 2, Upper: 5, LowerInclusive: true},
+			Price: &Range[float64]{Lower: 1.5, Upper: 2.5, UpperInclusive: true},
+		}, inUTC(booking))
+	})
+
+	t.Run("FindBooking - unbounded", func(t *testing.T) {
+		bookingID, err := q.InsertBooking(t.Context(), InsertBookingParams{
+			Period: Range[time.Time]{Lower: feb1, LowerInclusive: true, UpperInfinite: true},
+			Seats:  Range[int32]{Upper: 10, LowerInfinite: true},
+			Price:  Range[float64]{LowerInfinite: true, UpperInfinite: true},
+		})
+		require.NoError(t, err)
+		booking, err := q.FindBooking(t.Context(), bookingID)
+		require.NoError(t, err)
+		assert.Equal(t, FindBookingRow{
+			Period: Range[time.Time]{Lower: feb1, LowerInclusive: true, UpperInfinite: true},
+			Seats:  Range[int32]{Upper: 10, LowerInfinite: true},
+			Price:  &Range[float64]{LowerInfinite: true, UpperInfinite: true},
+		}, inUTC(booking))
+	})
+
+	t.Run("FindBooking - empty and null", func(t *testing.T) {
+		bookingID, err := q.InsertBooking(t.Context(), InsertBookingParams{
+			Period: Range[time.Time]{Lower: jan1, Upper: jan1, LowerInclusive: true},
+			Seats:  Range[int32]{Empty: true},
+			Price:  Range[float64]{Lower: 1, Upper: 2, LowerInclusive: true},
+		})
+		require.NoError(t, err)
+		_, err = q.ClearBookingPrice(t.Context(), bookingID)
+		require.NoError(t, err)
+		booking, err := q.FindBooking(t.Context(), bookingID)
+		require.NoError(t, err)
+		// Postgres normalizes a range with equal exclusive bounds to empty.
+		assert.Equal(t, FindBookingRow{
+			Period: Range[time.Time]{Empty: true},
+			Seats:  Range[int32]{Empty: true},
+			Price:  nil,
+		}, inUTC(booking))
+	})
+}
+
+func TestNewQuerier_FindBookingsDuring(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	seats := Range[int32]{Lower: 1, Upper: 2, LowerInclusive: true}
+	price := Range[float64]{Lower: 1, Upper: 2, LowerInclusive: true}
+	janID, err := q.InsertBooking(t.Context(), InsertBookingParams{
+		Period: Range[time.Time]{Lower: jan1, Upper: feb1, LowerInclusive: true},
+		Seats:  seats,
+		Price:  price,
+	})
+	require.NoError(t, err)
+	febID, err := q.InsertBooking(t.Context(), InsertBookingParams{
+		Period: Range[time.Time]{Lower: feb1, Upper: mar1, LowerInclusive: true},
+		Seats:  seats,
+		Price:  price,
+	})
+	require.NoError(t, err)
+
+	t.Run("FindBookingsDuring - exclusive upper bound", func(t *testing.T) {
+		// January ends before February 1, so only February overlaps.
+		ids, err := q.FindBookingsDuring(t.Context(), Range[time.Time]{Lower: feb1, Upper: feb1, LowerInclusive: true, UpperInclusive: true})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{febID}, ids)
+	})
+
+	t.Run("FindBookingsDuring - unbounded", func(t *testing.T) {
+		ids, err := q.FindBookingsDuring(t.Context(), Range[time.Time]{LowerInfinite: true, UpperInfinite: true})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{janID, febID}, ids)
+	})
+}
+
+// inUTC converts the time bounds of the booking to UTC since pgx decodes
+// timestamptz values in the local time zone.
+func inUTC(b FindBookingRow) FindBookingRow {
+	b.Period.Lower = b.Period.Lower.UTC()
+	b.Period.Upper = b.Period.Upper.UTC()
+	return b
+}
@@ -0,0 +1,8 @@
+CREATE TYPE floatrange AS RANGE (subtype = float8);
+
+CREATE TABLE booking (
+  booking_id serial PRIMARY KEY,
+  period     tstzrange  NOT NULL,
+  seats      int4range  NOT NULL,
+  price      floatrange NULL
+);
@@ -0,0 +1,21 @@
+-- name: InsertOrder :one
+INSERT INTO orders (billing_status, shipping_status)
+VALUES (pggen.arg('billing_status'), pggen.arg('shipping_status'))
+RETURNING order_id;
+
+-- name: FindOrder :one
+SELECT billing_status, shipping_status FROM orders WHERE order_id = pggen.arg('order_id');
+
+-- FindOrdersByShippingStatus finds the orders with any of the shipping
+-- statuses.
+-- name: FindOrdersByShippingStatus :many
+SELECT order_id, billing_status
+FROM orders
+WHERE shipping_status = ANY(pggen.arg('statuses')::shipping.status[])
+ORDER BY order_id;
+
+-- FindOrderStatuses returns the statuses of an order as arrays.
+-- name: FindOrderStatuses :one
+SELECT ARRAY[billing_status] AS billing, ARRAY[shipping_status] AS shipping
+FROM orders
+WHERE order_id = pggen.arg('order_id');
@@ -0,0 +1,56 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuerier_FindOrder(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	orderID, err := q.InsertOrder(t.Context(), BillingStatusPaid, ShippingStatusShipped)
+	require.NoError(t, err)
+
+	t.Run("FindOrder", func(t *testing.T) {
+		order, err := q.FindOrder(t.Context(), orderID)
+		require.NoError(t, err)
+		assert.Equal(t, FindOrderRow{
+			BillingStatus:  BillingStatusPaid,
+			ShippingStatus: ShippingStatusShipped,
+		}, order)
+	})
+
+	t.Run("FindOrderStatuses", func(t *testing.T) {
+		statuses, err := q.FindOrderStatuses(t.Context(), orderID)
+		require.NoError(t, err)
+		assert.Equal(t, FindOrderStatusesRow{
+			Billing:  []BillingStatus{BillingStatusPaid},
+			Shipping: []ShippingStatus{ShippingStatusShipped},
+		}, statuses)
+	})
+}
+
+func TestNewQuerier_FindOrdersByShippingStatus(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchema(t, []string{"schema.sql"})
+	defer cleanup()
+	q := NewQuerier(conn)
+	// Both enums have a pending label, so each label must encode with the enum
+	// of the right schema.
+	pendingID, err := q.InsertOrder(t.Context(), BillingStatusPending, ShippingStatusPending)
+	require.NoError(t, err)
+	shippedID, err := q.InsertOrder(t.Context(), BillingStatusPaid, ShippingStatusShipped)
+	require.NoError(t, err)
+	_, err = q.InsertOrder(t.Context(), BillingStatusRefunded, ShippingStatusDelivered)
+	require.NoError(t, err)
+
+	orders, err := q.FindOrdersByShippingStatus(t.Context(), []ShippingStatus{ShippingStatusPending, ShippingStatusShipped})
+	require.NoError(t, err)
+	assert.Equal(t, []FindOrdersByShippingStatusRow{
+		{OrderID: pendingID, BillingStatus: BillingStatusPending},
+		{OrderID: shippedID, BillingStatus: BillingStatusPaid},
+	}, orders)
+}
@@ -0,0 +1,17 @@
+-- The tests load this file into a new schema for each test, but the billing
+-- and shipping schemas outlive the test schema, so recreate them.
+DROP SCHEMA IF EXISTS billing CASCADE;
+DROP SCHEMA IF EXISTS shipping CASCADE;
+
+CREATE SCHEMA billing;
+CREATE SCHEMA shipping;
+
+-- Two enums with the same name in different schemas.
+CREATE TYPE billing.status AS ENUM ('pending', 'paid', 'refunded');
+CREATE TYPE shipping.status AS ENUM ('pending', 'shipped', 'delivered');
+
+CREATE TABLE orders (
+  order_id        serial PRIMARY KEY,
+  billing_status  billing.status  NOT NULL,
+  shipping_status shipping.status NOT NULL
+);
@@ -13,12 +13,28 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	AlphaNested(ctx context.Context) (string, error)
+	// QueueAlphaNested queues the AlphaNested query into batch to send with SendBatch.
+	QueueAlphaNested(batch *pgx.Batch)
+	// AlphaNestedScan scans the results of a queued AlphaNested query.
+	AlphaNestedScan(results pgx.BatchResults) (string, error)
 
 	AlphaCompositeArray(ctx context.Context) ([]Alpha, error)
+	// QueueAlphaCompositeArray queues the AlphaCompositeArray query into batch to send with SendBatch.
+	QueueAlphaCompositeArray(batch *pgx.Batch)
+	// AlphaCompositeArrayScan scans the results of a queued AlphaCompositeArray query.
+	AlphaCompositeArrayScan(results pgx.BatchResults) ([]Alpha, error)
 
 	Alpha(ctx context.Context) (string, error)
+	// QueueAlpha queues the Alpha query into batch to send with SendBatch.
+	QueueAlpha(batch *pgx.Batch)
+	// AlphaScan scans the results of a queued Alpha query.
+	AlphaScan(results pgx.BatchResults) (string, error)
 
 	Bravo(ctx context.Context) (string, error)
+	// QueueBravo queues the Bravo query into batch to send with SendBatch.
+	QueueBravo(batch *pgx.Batch)
+	// BravoScan scans the results of a queued Bravo query.
+	BravoScan(results pgx.BatchResults) (string, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -33,6 +49,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -153,6 +170,21 @@ func (q *DBQuerier) AlphaNested(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueAlphaNested implements Querier.QueueAlphaNested.
+func (q *DBQuerier) QueueAlphaNested(batch *pgx.Batch) {
+	batch.Queue(alphaNestedSQL)
+}
+
+// AlphaNestedScan implements Querier.AlphaNestedScan.
+func (q *DBQuerier) AlphaNestedScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan AlphaNested batch row: %w", err)
+	}
+	return item, nil
+}
+
 const alphaCompositeArraySQL = `SELECT ARRAY[ROW('key')]::alpha[];`
 
 // AlphaCompositeArray implements Querier.AlphaCompositeArray.
@@ -170,6 +202,25 @@ func (q *DBQuerier) AlphaCompositeArray(ctx context.Context) ([]Alpha, error) {
 	return item, nil
 }
 
+// QueueAlphaCompositeArray implements Querier.QueueAlphaCompositeArray.
+func (q *DBQuerier) QueueAlphaCompositeArray(batch *pgx.Batch) {
+	batch.Queue(alphaCompositeArraySQL)
+}
+
+// AlphaCompositeArrayScan implements Querier.AlphaCompositeArrayScan.
+func (q *DBQuerier) AlphaCompositeArrayScan(results pgx.BatchResults) ([]Alpha, error) {
+	row := results.QueryRow()
+	item := []Alpha{}
+	arrayArray := q.types.newAlphaArray()
+	if err := row.Scan(arrayArray); err != nil {
+		return item, fmt.Errorf("scan AlphaCompositeArray batch row: %w", err)
+	}
+	if err := arrayArray.AssignTo(&item); err != nil {
+		return item, fmt.Errorf("assign AlphaCompositeArray row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
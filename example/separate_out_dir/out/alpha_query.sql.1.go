@@ -5,6 +5,7 @@ package out
 import (
 	"context"
 	"fmt"
+	"github.com/jackc/pgx/v4"
 )
 
 const alphaSQL = `SELECT 'alpha' as output;`
@@ -19,3 +20,18 @@ func (q *DBQuerier) Alpha(ctx context.Context) (string, error) {
 	}
 	return item, nil
 }
+
+// QueueAlpha implements Querier.QueueAlpha.
+func (q *DBQuerier) QueueAlpha(batch *pgx.Batch) {
+	batch.Queue(alphaSQL)
+}
+
+// AlphaScan implements Querier.AlphaScan.
+func (q *DBQuerier) AlphaScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan Alpha batch row: %w", err)
+	}
+	return item, nil
+}
@@ -5,6 +5,7 @@ package out
 import (
 	"context"
 	"fmt"
+	"github.com/jackc/pgx/v4"
 )
 
 const bravoSQL = `SELECT 'bravo' as output;`
@@ -19,3 +20,18 @@ func (q *DBQuerier) Bravo(ctx context.Context) (string, error) {
 	}
 	return item, nil
 }
+
+// QueueBravo implements Querier.QueueBravo.
+func (q *DBQuerier) QueueBravo(batch *pgx.Batch) {
+	batch.Queue(bravoSQL)
+}
+
+// BravoScan implements Querier.BravoScan.
+func (q *DBQuerier) BravoScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan Bravo batch row: %w", err)
+	}
+	return item, nil
+}
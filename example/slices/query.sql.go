@@ -14,12 +14,28 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	GetBools(ctx context.Context, data []bool) ([]bool, error)
+	// QueueGetBools queues the GetBools query into batch to send with SendBatch.
+	QueueGetBools(batch *pgx.Batch, data []bool)
+	// GetBoolsScan scans the results of a queued GetBools query.
+	GetBoolsScan(results pgx.BatchResults) ([]bool, error)
 
 	GetOneTimestamp(ctx context.Context, data *time.Time) (*time.Time, error)
+	// QueueGetOneTimestamp queues the GetOneTimestamp query into batch to send with SendBatch.
+	QueueGetOneTimestamp(batch *pgx.Batch, data *time.Time)
+	// GetOneTimestampScan scans the results of a queued GetOneTimestamp query.
+	GetOneTimestampScan(results pgx.BatchResults) (*time.Time, error)
 
 	GetManyTimestamptzs(ctx context.Context, data []time.Time) ([]*time.Time, error)
+	// QueueGetManyTimestamptzs queues the GetManyTimestamptzs query into batch to send with SendBatch.
+	QueueGetManyTimestamptzs(batch *pgx.Batch, data []time.Time)
+	// GetManyTimestamptzsScan scans the results of a queued GetManyTimestamptzs query.
+	GetManyTimestamptzsScan(results pgx.BatchResults) ([]*time.Time, error)
 
 	GetManyTimestamps(ctx context.Context, data []*time.Time) ([]*time.Time, error)
+	// QueueGetManyTimestamps queues the GetManyTimestamps query into batch to send with SendBatch.
+	QueueGetManyTimestamps(batch *pgx.Batch, data []*time.Time)
+	// GetManyTimestampsScan scans the results of a queued GetManyTimestamps query.
+	GetManyTimestampsScan(results pgx.BatchResults) ([]*time.Time, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -34,6 +50,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -144,6 +161,21 @@ func (q *DBQuerier) GetBools(ctx context.Context, data []bool) ([]bool, error) {
 	return item, nil
 }
 
+// QueueGetBools implements Querier.QueueGetBools.
+func (q *DBQuerier) QueueGetBools(batch *pgx.Batch, data []bool) {
+	batch.Queue(getBoolsSQL, data)
+}
+
+// GetBoolsScan implements Querier.GetBoolsScan.
+func (q *DBQuerier) GetBoolsScan(results pgx.BatchResults) ([]bool, error) {
+	row := results.QueryRow()
+	item := []bool{}
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetBools batch row: %w", err)
+	}
+	return item, nil
+}
+
 const getOneTimestampSQL = `SELECT $1::timestamp;`
 
 // GetOneTimestamp implements Querier.GetOneTimestamp.
@@ -157,6 +189,21 @@ func (q *DBQuerier) GetOneTimestamp(ctx context.Context, data *time.Time) (*time
 	return item, nil
 }
 
+// QueueGetOneTimestamp implements Querier.QueueGetOneTimestamp.
+func (q *DBQuerier) QueueGetOneTimestamp(batch *pgx.Batch, data *time.Time) {
+	batch.Queue(getOneTimestampSQL, data)
+}
+
+// GetOneTimestampScan implements Querier.GetOneTimestampScan.
+func (q *DBQuerier) GetOneTimestampScan(results pgx.BatchResults) (*time.Time, error) {
+	row := results.QueryRow()
+	var item *time.Time
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetOneTimestamp batch row: %w", err)
+	}
+	return item, nil
+}
+
 const getManyTimestamptzsSQL = `SELECT *
 FROM unnest($1::timestamptz[]);`
 
@@ -182,6 +229,32 @@ func (q *DBQuerier) GetManyTimestamptzs(ctx context.Context, data []time.Time) (
 	return items, err
 }
 
+// QueueGetManyTimestamptzs implements Querier.QueueGetManyTimestamptzs.
+func (q *DBQuerier) QueueGetManyTimestamptzs(batch *pgx.Batch, data []time.Time) {
+	batch.Queue(getManyTimestamptzsSQL, data)
+}
+
+// GetManyTimestamptzsScan implements Querier.GetManyTimestamptzsScan.
+func (q *DBQuerier) GetManyTimestamptzsScan(results pgx.BatchResults) ([]*time.Time, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query GetManyTimestamptzs batch: %w", err)
+	}
+	defer rows.Close()
+	items := []*time.Time{}
+	for rows.Next() {
+		var item time.Time
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan GetManyTimestamptzs batch row: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GetManyTimestamptzs batch rows: %w", err)
+	}
+	return items, err
+}
+
 const getManyTimestampsSQL = `SELECT *
 FROM unnest($1::timestamp[]);`
 
@@ -207,6 +280,32 @@ func (q *DBQuerier) GetManyTimestamps(ctx context.Context, data []*time.Time) ([
 	return items, err
 }
 
+// QueueGetManyTimestamps implements Querier.QueueGetManyTimestamps.
+func (q *DBQuerier) QueueGetManyTimestamps(batch *pgx.Batch, data []*time.Time) {
+	batch.Queue(getManyTimestampsSQL, data)
+}
+
+// GetManyTimestampsScan implements Querier.GetManyTimestampsScan.
+func (q *DBQuerier) GetManyTimestampsScan(results pgx.BatchResults) ([]*time.Time, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query GetManyTimestamps batch: %w", err)
+	}
+	defer rows.Close()
+	items := []*time.Time{}
+	for rows.Next() {
+		var item time.Time
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan GetManyTimestamps batch row: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GetManyTimestamps batch rows: %w", err)
+	}
+	return items, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -14,29 +14,65 @@ import (
 type Querier interface {
 	// Query to test escaping in generated Go.
 	Backtick(ctx context.Context) (string, error)
+	// QueueBacktick queues the Backtick query into batch to send with SendBatch.
+	QueueBacktick(batch *pgx.Batch)
+	// BacktickScan scans the results of a queued Backtick query.
+	BacktickScan(results pgx.BatchResults) (string, error)
 
 	// Query to test escaping in generated Go.
 	BacktickQuoteBacktick(ctx context.Context) (string, error)
+	// QueueBacktickQuoteBacktick queues the BacktickQuoteBacktick query into batch to send with SendBatch.
+	QueueBacktickQuoteBacktick(batch *pgx.Batch)
+	// BacktickQuoteBacktickScan scans the results of a queued BacktickQuoteBacktick query.
+	BacktickQuoteBacktickScan(results pgx.BatchResults) (string, error)
 
 	// Query to test escaping in generated Go.
 	BacktickNewline(ctx context.Context) (string, error)
+	// QueueBacktickNewline queues the BacktickNewline query into batch to send with SendBatch.
+	QueueBacktickNewline(batch *pgx.Batch)
+	// BacktickNewlineScan scans the results of a queued BacktickNewline query.
+	BacktickNewlineScan(results pgx.BatchResults) (string, error)
 
 	// Query to test escaping in generated Go.
 	BacktickDoubleQuote(ctx context.Context) (string, error)
+	// QueueBacktickDoubleQuote queues the BacktickDoubleQuote query into batch to send with SendBatch.
+	QueueBacktickDoubleQuote(batch *pgx.Batch)
+	// BacktickDoubleQuoteScan scans the results of a queued BacktickDoubleQuote query.
+	BacktickDoubleQuoteScan(results pgx.BatchResults) (string, error)
 
 	// Query to test escaping in generated Go.
 	BacktickBackslashN(ctx context.Context) (string, error)
+	// QueueBacktickBackslashN queues the BacktickBackslashN query into batch to send with SendBatch.
+	QueueBacktickBackslashN(batch *pgx.Batch)
+	// BacktickBackslashNScan scans the results of a queued BacktickBackslashN query.
+	BacktickBackslashNScan(results pgx.BatchResults) (string, error)
 
 	// Illegal names.
 	IllegalNameSymbols(ctx context.Context, helloWorld string) (IllegalNameSymbolsRow, error)
+	// QueueIllegalNameSymbols queues the IllegalNameSymbols query into batch to send with SendBatch.
+	QueueIllegalNameSymbols(batch *pgx.Batch, helloWorld string)
+	// IllegalNameSymbolsScan scans the results of a queued IllegalNameSymbols query.
+	IllegalNameSymbolsScan(results pgx.BatchResults) (IllegalNameSymbolsRow, error)
 
 	// Space after pggen.arg
 	SpaceAfter(ctx context.Context, space string) (string, error)
+	// QueueSpaceAfter queues the SpaceAfter query into batch to send with SendBatch.
+	QueueSpaceAfter(batch *pgx.Batch, space string)
+	// SpaceAfterScan scans the results of a queued SpaceAfter query.
+	SpaceAfterScan(results pgx.BatchResults) (string, error)
 
 	// Enum named 123.
 	BadEnumName(ctx context.Context) (UnnamedEnum123, error)
+	// QueueBadEnumName queues the BadEnumName query into batch to send with SendBatch.
+	QueueBadEnumName(batch *pgx.Batch)
+	// BadEnumNameScan scans the results of a queued BadEnumName query.
+	BadEnumNameScan(results pgx.BatchResults) (UnnamedEnum123, error)
 
 	GoKeyword(ctx context.Context, go_ string) (string, error)
+	// QueueGoKeyword queues the GoKeyword query into batch to send with SendBatch.
+	QueueGoKeyword(batch *pgx.Batch, go_ string)
+	// GoKeywordScan scans the results of a queued GoKeyword query.
+	GoKeywordScan(results pgx.BatchResults) (string, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -51,6 +87,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -112,6 +149,21 @@ func (q *DBQuerier) Backtick(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueBacktick implements Querier.QueueBacktick.
+func (q *DBQuerier) QueueBacktick(batch *pgx.Batch) {
+	batch.Queue(backtickSQL)
+}
+
+// BacktickScan implements Querier.BacktickScan.
+func (q *DBQuerier) BacktickScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan Backtick batch row: %w", err)
+	}
+	return item, nil
+}
+
 const backtickQuoteBacktickSQL = "SELECT '`\"`';"
 
 // BacktickQuoteBacktick implements Querier.BacktickQuoteBacktick.
@@ -125,6 +177,21 @@ func (q *DBQuerier) BacktickQuoteBacktick(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueBacktickQuoteBacktick implements Querier.QueueBacktickQuoteBacktick.
+func (q *DBQuerier) QueueBacktickQuoteBacktick(batch *pgx.Batch) {
+	batch.Queue(backtickQuoteBacktickSQL)
+}
+
+// BacktickQuoteBacktickScan implements Querier.BacktickQuoteBacktickScan.
+func (q *DBQuerier) BacktickQuoteBacktickScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan BacktickQuoteBacktick batch row: %w", err)
+	}
+	return item, nil
+}
+
 const backtickNewlineSQL = "SELECT '`\n';"
 
 // BacktickNewline implements Querier.BacktickNewline.
@@ -138,6 +205,21 @@ func (q *DBQuerier) BacktickNewline(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueBacktickNewline implements Querier.QueueBacktickNewline.
+func (q *DBQuerier) QueueBacktickNewline(batch *pgx.Batch) {
+	batch.Queue(backtickNewlineSQL)
+}
+
+// BacktickNewlineScan implements Querier.BacktickNewlineScan.
+func (q *DBQuerier) BacktickNewlineScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan BacktickNewline batch row: %w", err)
+	}
+	return item, nil
+}
+
 const backtickDoubleQuoteSQL = "SELECT '`\"';"
 
 // BacktickDoubleQuote implements Querier.BacktickDoubleQuote.
@@ -151,6 +233,21 @@ func (q *DBQuerier) BacktickDoubleQuote(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueBacktickDoubleQuote implements Querier.QueueBacktickDoubleQuote.
+func (q *DBQuerier) QueueBacktickDoubleQuote(batch *pgx.Batch) {
+	batch.Queue(backtickDoubleQuoteSQL)
+}
+
+// BacktickDoubleQuoteScan implements Querier.BacktickDoubleQuoteScan.
+func (q *DBQuerier) BacktickDoubleQuoteScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan BacktickDoubleQuote batch row: %w", err)
+	}
+	return item, nil
+}
+
 const backtickBackslashNSQL = "SELECT '`\\n';"
 
 // BacktickBackslashN implements Querier.BacktickBackslashN.
@@ -164,6 +261,21 @@ func (q *DBQuerier) BacktickBackslashN(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueBacktickBackslashN implements Querier.QueueBacktickBackslashN.
+func (q *DBQuerier) QueueBacktickBackslashN(batch *pgx.Batch) {
+	batch.Queue(backtickBackslashNSQL)
+}
+
+// BacktickBackslashNScan implements Querier.BacktickBackslashNScan.
+func (q *DBQuerier) BacktickBackslashNScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan BacktickBackslashN batch row: %w", err)
+	}
+	return item, nil
+}
+
 const illegalNameSymbolsSQL = "SELECT '`\\n' as \"$\", $1 as \"foo.bar!@#$%&*()\"\"--+\";"
 
 type IllegalNameSymbolsRow struct {
@@ -182,6 +294,21 @@ func (q *DBQuerier) IllegalNameSymbols(ctx context.Context, helloWorld string) (
 	return item, nil
 }
 
+// QueueIllegalNameSymbols implements Querier.QueueIllegalNameSymbols.
+func (q *DBQuerier) QueueIllegalNameSymbols(batch *pgx.Batch, helloWorld string) {
+	batch.Queue(illegalNameSymbolsSQL, helloWorld)
+}
+
+// IllegalNameSymbolsScan implements Querier.IllegalNameSymbolsScan.
+func (q *DBQuerier) IllegalNameSymbolsScan(results pgx.BatchResults) (IllegalNameSymbolsRow, error) {
+	row := results.QueryRow()
+	var item IllegalNameSymbolsRow
+	if err := row.Scan(&item.UnnamedColumn0, &item.FooBar); err != nil {
+		return item, fmt.Errorf("scan IllegalNameSymbols batch row: %w", err)
+	}
+	return item, nil
+}
+
 const spaceAfterSQL = `SELECT $1;`
 
 // SpaceAfter implements Querier.SpaceAfter.
@@ -195,6 +322,21 @@ func (q *DBQuerier) SpaceAfter(ctx context.Context, space string) (string, error
 	return item, nil
 }
 
+// QueueSpaceAfter implements Querier.QueueSpaceAfter.
+func (q *DBQuerier) QueueSpaceAfter(batch *pgx.Batch, space string) {
+	batch.Queue(spaceAfterSQL, space)
+}
+
+// SpaceAfterScan implements Querier.SpaceAfterScan.
+func (q *DBQuerier) SpaceAfterScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan SpaceAfter batch row: %w", err)
+	}
+	return item, nil
+}
+
 const badEnumNameSQL = `SELECT 'inconvertible_enum_name'::"123";`
 
 // BadEnumName implements Querier.BadEnumName.
@@ -208,6 +350,21 @@ func (q *DBQuerier) BadEnumName(ctx context.Context) (UnnamedEnum123, error) {
 	return item, nil
 }
 
+// QueueBadEnumName implements Querier.QueueBadEnumName.
+func (q *DBQuerier) QueueBadEnumName(batch *pgx.Batch) {
+	batch.Queue(badEnumNameSQL)
+}
+
+// BadEnumNameScan implements Querier.BadEnumNameScan.
+func (q *DBQuerier) BadEnumNameScan(results pgx.BatchResults) (UnnamedEnum123, error) {
+	row := results.QueryRow()
+	var item UnnamedEnum123
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan BadEnumName batch row: %w", err)
+	}
+	return item, nil
+}
+
 const goKeywordSQL = `SELECT $1::text;`
 
 // GoKeyword implements Querier.GoKeyword.
@@ -221,6 +378,21 @@ func (q *DBQuerier) GoKeyword(ctx context.Context, go_ string) (string, error) {
 	return item, nil
 }
 
+// QueueGoKeyword implements Querier.QueueGoKeyword.
+func (q *DBQuerier) QueueGoKeyword(batch *pgx.Batch, go_ string) {
+	batch.Queue(goKeywordSQL, go_)
+}
+
+// GoKeywordScan implements Querier.GoKeywordScan.
+func (q *DBQuerier) GoKeywordScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GoKeyword batch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -13,14 +13,34 @@ import (
 // Querier is a typesafe Go interface backed by SQL queries.
 type Querier interface {
 	VoidOnly(ctx context.Context) (pgconn.CommandTag, error)
+	// QueueVoidOnly queues the VoidOnly query into batch to send with SendBatch.
+	QueueVoidOnly(batch *pgx.Batch)
+	// VoidOnlyScan scans the results of a queued VoidOnly query.
+	VoidOnlyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	VoidOnlyTwoParams(ctx context.Context, id int32) (pgconn.CommandTag, error)
+	// QueueVoidOnlyTwoParams queues the VoidOnlyTwoParams query into batch to send with SendBatch.
+	QueueVoidOnlyTwoParams(batch *pgx.Batch, id int32)
+	// VoidOnlyTwoParamsScan scans the results of a queued VoidOnlyTwoParams query.
+	VoidOnlyTwoParamsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
 	VoidTwo(ctx context.Context) (string, error)
+	// QueueVoidTwo queues the VoidTwo query into batch to send with SendBatch.
+	QueueVoidTwo(batch *pgx.Batch)
+	// VoidTwoScan scans the results of a queued VoidTwo query.
+	VoidTwoScan(results pgx.BatchResults) (string, error)
 
 	VoidThree(ctx context.Context) (VoidThreeRow, error)
+	// QueueVoidThree queues the VoidThree query into batch to send with SendBatch.
+	QueueVoidThree(batch *pgx.Batch)
+	// VoidThreeScan scans the results of a queued VoidThree query.
+	VoidThreeScan(results pgx.BatchResults) (VoidThreeRow, error)
 
 	VoidThree2(ctx context.Context) ([]string, error)
+	// QueueVoidThree2 queues the VoidThree2 query into batch to send with SendBatch.
+	QueueVoidThree2(batch *pgx.Batch)
+	// VoidThree2Scan scans the results of a queued VoidThree2 query.
+	VoidThree2Scan(results pgx.BatchResults) ([]string, error)
 }
 
 var _ Querier = &DBQuerier{}
@@ -35,6 +55,7 @@ type genericConn interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
 }
 
 // NewQuerier creates a DBQuerier that implements Querier.
@@ -83,6 +104,20 @@ func (q *DBQuerier) VoidOnly(ctx context.Context) (pgconn.CommandTag, error) {
 	return cmdTag, err
 }
 
+// QueueVoidOnly implements Querier.QueueVoidOnly.
+func (q *DBQuerier) QueueVoidOnly(batch *pgx.Batch) {
+	batch.Queue(voidOnlySQL)
+}
+
+// VoidOnlyScan implements Querier.VoidOnlyScan.
+func (q *DBQuerier) VoidOnlyScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query VoidOnly: %w", err)
+	}
+	return cmdTag, err
+}
+
 const voidOnlyTwoParamsSQL = `SELECT void_fn_two_params($1, 'text');`
 
 // VoidOnlyTwoParams implements Querier.VoidOnlyTwoParams.
@@ -95,6 +130,20 @@ func (q *DBQuerier) VoidOnlyTwoParams(ctx context.Context, id int32) (pgconn.Com
 	return cmdTag, err
 }
 
+// QueueVoidOnlyTwoParams implements Querier.QueueVoidOnlyTwoParams.
+func (q *DBQuerier) QueueVoidOnlyTwoParams(batch *pgx.Batch, id int32) {
+	batch.Queue(voidOnlyTwoParamsSQL, id)
+}
+
+// VoidOnlyTwoParamsScan implements Querier.VoidOnlyTwoParamsScan.
+func (q *DBQuerier) VoidOnlyTwoParamsScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec batch query VoidOnlyTwoParams: %w", err)
+	}
+	return cmdTag, err
+}
+
 const voidTwoSQL = `SELECT void_fn(), 'foo' as name;`
 
 // VoidTwo implements Querier.VoidTwo.
@@ -108,6 +157,21 @@ func (q *DBQuerier) VoidTwo(ctx context.Context) (string, error) {
 	return item, nil
 }
 
+// QueueVoidTwo implements Querier.QueueVoidTwo.
+func (q *DBQuerier) QueueVoidTwo(batch *pgx.Batch) {
+	batch.Queue(voidTwoSQL)
+}
+
+// VoidTwoScan implements Querier.VoidTwoScan.
+func (q *DBQuerier) VoidTwoScan(results pgx.BatchResults) (string, error) {
+	row := results.QueryRow()
+	var item string
+	if err := row.Scan(nil, &item); err != nil {
+		return item, fmt.Errorf("scan VoidTwo batch row: %w", err)
+	}
+	return item, nil
+}
+
 const voidThreeSQL = `SELECT void_fn(), 'foo' as foo, 'bar' as bar;`
 
 type VoidThreeRow struct {
@@ -126,6 +190,21 @@ func (q *DBQuerier) VoidThree(ctx context.Context) (VoidThreeRow, error) {
 	return item, nil
 }
 
+// QueueVoidThree implements Querier.QueueVoidThree.
+func (q *DBQuerier) QueueVoidThree(batch *pgx.Batch) {
+	batch.Queue(voidThreeSQL)
+}
+
+// VoidThreeScan implements Querier.VoidThreeScan.
+func (q *DBQuerier) VoidThreeScan(results pgx.BatchResults) (VoidThreeRow, error) {
+	row := results.QueryRow()
+	var item VoidThreeRow
+	if err := row.Scan(nil, &item.Foo, &item.Bar); err != nil {
+		return item, fmt.Errorf("scan VoidThree batch row: %w", err)
+	}
+	return item, nil
+}
+
 const voidThree2SQL = `SELECT 'foo' as foo, void_fn(), void_fn();`
 
 // VoidThree2 implements Querier.VoidThree2.
@@ -150,6 +229,32 @@ func (q *DBQuerier) VoidThree2(ctx context.Context) ([]string, error) {
 	return items, err
 }
 
+// QueueVoidThree2 implements Querier.QueueVoidThree2.
+func (q *DBQuerier) QueueVoidThree2(batch *pgx.Batch) {
+	batch.Queue(voidThree2SQL)
+}
+
+// VoidThree2Scan implements Querier.VoidThree2Scan.
+func (q *DBQuerier) VoidThree2Scan(results pgx.BatchResults) ([]string, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query VoidThree2 batch: %w", err)
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item, nil, nil); err != nil {
+			return nil, fmt.Errorf("scan VoidThree2 batch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close VoidThree2 batch rows: %w", err)
+	}
+	return items, err
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
@@ -14,6 +14,7 @@ import (
 	"github.com/jschaf/pggen/internal/ast"
 	"github.com/jschaf/pggen/internal/codegen"
 	"github.com/jschaf/pggen/internal/codegen/golang"
+	"github.com/jschaf/pggen/internal/codegen/proto"
 	"github.com/jschaf/pggen/internal/errs"
 	"github.com/jschaf/pggen/internal/parser"
 	"github.com/jschaf/pggen/internal/pgdocker"
@@ -24,9 +25,14 @@ import (
 type Lang string
 
 const (
-	LangGo Lang = "go"
+	LangGo    Lang = "go"
+	LangProto Lang = "proto"
 )
 
+// ProtoNumbersFile is the name of the file in the output directory that
+// records the field numbers of generated protobuf messages for LangProto.
+const ProtoNumbersFile = proto.NumbersFile
+
 // GenerateOptions are the unparsed options that controls the generated Go code.
 type GenerateOptions struct {
 	// What language to generate code in.
@@ -56,13 +62,76 @@ type GenerateOptions struct {
 	// A map of lowercase acronyms to the upper case equivalent, like:
 	// "api" => "API", or "apis" => "APIs".
 	Acronyms map[string]string
-	// A map from a Postgres type name to a fully qualified Go type.
+	// A map from a Postgres type name to a fully qualified Go type. The
+	// Postgres type name may be schema-qualified, like "billing.status". A
+	// Postgres type name with a "?" suffix, like "int8?", only applies to
+	// nullable values.
 	TypeOverrides map[string]string
+	// Named sets of Go types for builtin Postgres types: "stdlib",
+	// "google-uuid", "shopspring-decimal", or "pgtype". Later presets take
+	// precedence over earlier presets, and TypeOverrides take precedence over
+	// all presets.
+	TypePresets []string
+	// A map from a column to a fully qualified Go type. The key is a table
+	// column, like "users.settings", a query output column, or a query param,
+	// like "FindUser.settings". Takes precedence over TypeOverrides. Queries
+	// also support a go-type pragma, like go-type=settings:example.com/user.Settings.
+	ColumnTypeOverrides map[string]string
+	// How to name Go types for Postgres types with the same name in different
+	// schemas, like billing.status and shipping.status. One of:
+	//
+	//   - "collision": (default) prefix the schema to the Go type name only if
+	//     the name collides, like BillingStatus. Types in the public schema keep
+	//     the unqualified name.
+	//   - "always": prefix the schema for all types not in the public schema.
+	//   - "never": never prefix the schema; colliding names are an error.
+	SchemaNaming string
+	// If true, use nullable Go types for all composite type fields. By default,
+	// fields of a table's row type backed by a NOT NULL column use non-nullable
+	// Go types, like string instead of *string. A whole-row NULL, like from an
+	// outer join, fails to decode into non-nullable fields.
+	NullableCompositeFields bool
+	// The major version of pgx for the generated Go code, either 4 or 5.
+	// Defaults to 4 if 0. The pgx v5 code scans into native Go types and pgx v5
+	// pgtype types, and declares a RegisterTypes function to load enums and
+	// composite types into the pgtype.Map of a connection.
+	PgxVersion int
+	// The Go database API for the generated Go code, either "pgx" or
+	// "database/sql". Defaults to "pgx" if empty. The database/sql code runs
+	// queries with *sql.DB or *sql.Tx and encodes enums, composite types, and
+	// arrays with sql.Scanner and driver.Valuer implementations that use the
+	// Postgres text format.
+	Driver string
+	// How to choose the Go type for nullable builtin Postgres types, like a
+	// nullable varchar column: "pgtype", "pointer", "sqlnull", or "generic".
+	// Empty uses the default Go type for each Postgres type, a mix of pointers
+	// and pgtype types.
+	NullableStyle string
+	// Nullable styles by Postgres type name, like "varchar" => "pointer".
+	// Overrides NullableStyle for the type.
+	NullableTypeStyles map[string]string
 	// What log level to log at.
 	LogLevel slog.Level
 	// How many params to inline when calling querier methods.
 	// Set to 0 to always create a struct for params.
 	InlineParamCount int
+	// If true, check that each Go type in TypeOverrides, ColumnTypeOverrides,
+	// and go-type pragmas exists, is exported, and implements the interfaces
+	// the generated code needs. Loads the Go packages from OutputDir, so
+	// OutputDir must be in a Go module that can resolve the packages.
+	CheckGoTypes bool
+	// A map from a protobuf package, like "foo.bar", to the Go package path of
+	// the code generated by protoc-gen-go, like "example.com/foobarpb". A query
+	// with a proto-type pragma, like proto-type=foo.bar.User, scans output rows
+	// into the Go struct for the protobuf message.
+	ProtoGoPackages map[string]string
+	// The protobuf package for generated .proto files, like "acme.users.v1".
+	// Defaults to the base name of OutputDir if empty. Only used for
+	// LangProto.
+	ProtoPackage string
+	// The go_package option for generated .proto files, like
+	// "example.com/gen/userpb". Omitted if empty. Only used for LangProto.
+	ProtoGoPackageOption string
 }
 
 // Generate generates language specific code to safely wrap each SQL
@@ -106,16 +175,40 @@ func Generate(opts GenerateOptions) (mErr error) {
 	}
 	switch opts.Language {
 	case LangGo:
+		nullTypeStyles := make(map[string]golang.NullableStyle, len(opts.NullableTypeStyles))
+		for name, style := range opts.NullableTypeStyles {
+			nullTypeStyles[name] = golang.NullableStyle(style)
+		}
 		goOpts := golang.GenerateOptions{
-			GoPkg:            opts.GoPackage,
-			OutputDir:        opts.OutputDir,
-			Acronyms:         opts.Acronyms,
-			TypeOverrides:    opts.TypeOverrides,
-			InlineParamCount: opts.InlineParamCount,
+			GoPkg:                   opts.GoPackage,
+			OutputDir:               opts.OutputDir,
+			Acronyms:                opts.Acronyms,
+			TypeOverrides:           opts.TypeOverrides,
+			TypePresets:             opts.TypePresets,
+			ColumnTypeOverrides:     opts.ColumnTypeOverrides,
+			SchemaNaming:            golang.SchemaNaming(opts.SchemaNaming),
+			NullableCompositeFields: opts.NullableCompositeFields,
+			PgxVersion:              opts.PgxVersion,
+			Driver:                  golang.Driver(opts.Driver),
+			NullableStyle:           golang.NullableStyle(opts.NullableStyle),
+			NullableTypeStyles:      nullTypeStyles,
+			InlineParamCount:        opts.InlineParamCount,
+			CheckGoTypes:            opts.CheckGoTypes,
+			ProtoGoPackages:         opts.ProtoGoPackages,
 		}
 		if err := golang.Generate(goOpts, queryFiles); err != nil {
 			return fmt.Errorf("generate go code: %w", err)
 		}
+	case LangProto:
+		protoOpts := proto.GenerateOptions{
+			OutputDir:    opts.OutputDir,
+			ProtoPackage: opts.ProtoPackage,
+			GoPackage:    opts.ProtoGoPackageOption,
+			Acronyms:     opts.Acronyms,
+		}
+		if err := proto.Generate(protoOpts, queryFiles); err != nil {
+			return fmt.Errorf("generate proto code: %w", err)
+		}
 	default:
 		return fmt.Errorf("unsupported output language %q", opts.Language)
 	}
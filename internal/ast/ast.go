@@ -71,12 +71,67 @@ type ResultKind string
 const (
 	ResultKindMany ResultKind = ":many"
 	ResultKindOne  ResultKind = ":one"
+	// ResultKindOpt returns a pointer to a single row or nil if the query
+	// returns no rows, instead of an error like ResultKindOne.
+	ResultKindOpt  ResultKind = ":opt"
 	ResultKindExec ResultKind = ":exec"
+	// ResultKindExecRows returns the number of rows affected by the query
+	// instead of the command tag returned by ResultKindExec.
+	ResultKindExecRows ResultKind = ":execrows"
+	// ResultKindMap returns a map of rows keyed by the output column named by
+	// the key pragma.
+	ResultKindMap ResultKind = ":map"
+	// ResultKindIter streams rows with an iterator instead of buffering all rows
+	// into a slice like ResultKindMany.
+	ResultKindIter ResultKind = ":iter"
+	// ResultKindCopyFrom inserts a slice of params with the Postgres COPY
+	// protocol. The query must be an INSERT INTO ... VALUES statement with a
+	// pggen.arg for each column.
+	ResultKindCopyFrom ResultKind = ":copyfrom"
 )
 
 // Pragmas are options to control generated code for a single query.
 type Pragmas struct {
 	ProtobufType string // package qualified protocol buffer message type to use for output rows
+	// The fields of output columns with the record pseudo-type, keyed by the
+	// output column name, like record=pair(id:int8,name:text).
+	RecordTypes map[string][]RecordField
+	// Fully qualified Go types for params and output columns, keyed by the
+	// param or column name, like go-type=settings:example.com/user.Settings.
+	GoTypes map[string]string
+	// The number of rows to fetch at a time with a server-side cursor, like
+	// cursor=1000. If positive, generate a method that declares a cursor for
+	// the query in a transaction and yields the rows in chunks.
+	CursorSize int
+	// The number of rows an :exec or :execrows query must affect, like
+	// affected=1. If set, the query returns an error wrapping
+	// ErrUnexpectedRowsAffected when the number of affected rows differs. Nil
+	// if the query doesn't check the affected rows.
+	AffectedRows *int
+	// The output column to key the rows of a :map query by, like
+	// key=author_id.
+	MapKey string
+	// The output column to group the rows of a :many query by, like
+	// group-by=author_id. Output columns named with a prefix, like
+	// "books.title", belong to a slice of child rows in each group.
+	GroupBy string
+	// The name of the Go struct for output rows, like row-type=Author. Queries
+	// with the same row type share the struct instead of each query declaring
+	// its own <Name>Row struct.
+	RowType string
+}
+
+// CopyFromTarget is the table and columns that a :copyfrom query inserts into,
+// like author and first_name in "INSERT INTO author (first_name) VALUES (...)".
+type CopyFromTarget struct {
+	Table   []string // parts of the possibly schema-qualified table name, like ["public", "author"]
+	Columns []string // column names in the same order as the query params
+}
+
+// RecordField is a field of an anonymous record declared with a record pragma.
+type RecordField struct {
+	Name string // name of the field, like "id" in record=pair(id:int8)
+	Type string // Postgres type of the field, like "int8" in record=pair(id:int8)
 }
 
 // An query is represented by one of the following query nodes.
@@ -89,15 +144,16 @@ type (
 
 	// An SourceQuery node represents a query entry from the source code.
 	SourceQuery struct {
-		Name        string        // name of the query
-		Doc         *CommentGroup // associated documentation; or nil
-		Start       gotok.Pos     // position of the start token, like 'SELECT' or 'UPDATE'
-		SourceSQL   string        // the complete sql query as it appeared in the source file
-		PreparedSQL string        // the sql query with args replaced by $1, $2, etc.
-		ParamNames  []string      // the name of each param in the PreparedSQL, the nth entry is the $n+1 param
-		ResultKind  ResultKind    // the result output type
-		Pragmas     Pragmas       // optional query options
-		Semi        gotok.Pos     // position of the closing semicolon
+		Name        string         // name of the query
+		Doc         *CommentGroup  // associated documentation; or nil
+		Start       gotok.Pos      // position of the start token, like 'SELECT' or 'UPDATE'
+		SourceSQL   string         // the complete sql query as it appeared in the source file
+		PreparedSQL string         // the sql query with args replaced by $1, $2, etc.
+		ParamNames  []string       // the name of each param in the PreparedSQL, the nth entry is the $n+1 param
+		ResultKind  ResultKind     // the result output type
+		Pragmas     Pragmas        // optional query options
+		CopyFrom    CopyFromTarget // the table and columns for a :copyfrom query
+		Semi        gotok.Pos      // position of the closing semicolon
 	}
 )
 
@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
 )
 
 // Declarer is implemented by any value that needs to declare types, data, or
@@ -55,16 +56,27 @@ func FindInputDeclarers(typ gotype.Type) DeclarerSet {
 			NewTypeResolverDeclarer(),
 			NewCompositeInitDeclarer(typ),
 		)
+	case *gotype.RangeType:
+		decls.AddAll(NewRangeInitDeclarer(typ))
+	case *gotype.MultirangeType:
+		decls.AddAll(NewMultirangeInitDeclarer(typ))
 	case *gotype.ArrayType:
 		if gotype.IsPgxSupportedArray(typ) {
 			break
 		}
 		switch gotype.UnwrapNestedType(typ.Elem).(type) {
-		case *gotype.CompositeType, *gotype.EnumType:
+		case *gotype.CompositeType, *gotype.EnumType, *gotype.ArrayType:
 			decls.AddAll(
 				NewTypeResolverDeclarer(),
 				NewArrayInitDeclarer(typ),
 			)
+		default:
+			if gotype.HasOpaqueElem(typ) {
+				decls.AddAll(
+					NewTypeResolverDeclarer(),
+					NewArrayInitDeclarer(typ),
+				)
+			}
 		}
 	}
 	decls.AddAll(NewTypeResolverInitDeclarer()) // always add
@@ -133,12 +145,52 @@ func findOutputDeclsHelper(typ gotype.Type, decls DeclarerSet, hadCompositeParen
 		if gotype.IsPgxSupportedArray(typ) {
 			return
 		}
+		if elem, dims := gotype.ArrayDimensions(typ); dims > 1 {
+			if _, ok := gotype.FindKnownTypePgx(typ.PgArray.OID()); ok && hadCompositeParent {
+				// The pgtype array registered in pgtype.ConnInfo supports multiple
+				// dimensions for composite fields.
+				return
+			}
+			decls.AddAll(
+				NewTypeResolverDeclarer(),
+				NewMultiArrayValueDeclarer(),
+				NewArrayDecoderDeclarer(typ),
+			)
+			// Elements of a multi-dimensional array need a transcoder like
+			// composite fields.
+			findOutputDeclsHelper(elem, decls, true)
+			return
+		}
 		decls.AddAll(NewTypeResolverDeclarer())
 		switch gotype.UnwrapNestedType(typ.Elem).(type) {
 		case *gotype.CompositeType, *gotype.EnumType:
 			decls.AddAll(NewArrayDecoderDeclarer(typ))
+		default:
+			if gotype.HasOpaqueElem(typ) {
+				decls.AddAll(NewArrayDecoderDeclarer(typ))
+			}
 		}
-		findOutputDeclsHelper(typ.Elem, decls, hadCompositeParent)
+		// The array decoder transcodes elements like composite fields.
+		findOutputDeclsHelper(typ.Elem, decls, true)
+
+	case *gotype.RangeType:
+		decls.AddAll(
+			NewRangeTypeDeclarer(),
+			NewRangeValueDeclarer(),
+			NewRangeTranscoderDeclarer(typ),
+		)
+		// Range bounds need a transcoder like composite fields.
+		findOutputDeclsHelper(typ.Elem, decls, true)
+
+	case *gotype.MultirangeType:
+		decls.AddAll(
+			NewRangeTypeDeclarer(),
+			NewRangeValueDeclarer(),
+			NewMultirangeTypeDeclarer(),
+			NewMultirangeValueDeclarer(),
+			NewMultirangeTranscoderDeclarer(typ),
+		)
+		findOutputDeclsHelper(typ.Range.Elem, decls, true)
 
 	default:
 		return
@@ -187,6 +239,19 @@ func (tr *typeResolver) setValue(vt pgtype.ValueTranscoder, val interface{}) pgt
 	return vt
 }`
 
+// runtimeTypeName returns the name the generated typeResolver uses for the
+// Postgres type, like "int4" or "billing.status". Qualifies types outside the
+// public schema so that types with the same name in different schemas don't
+// collide at runtime.
+func runtimeTypeName(pgt pg.Type) string {
+	switch schema := pg.SchemaName(pgt); schema {
+	case "", "public", "pg_catalog":
+		return pgt.String()
+	default:
+		return schema + "." + pgt.String()
+	}
+}
+
 // NewTypeResolverInitDeclarer declare type resolver init code always needed.
 func NewTypeResolverInitDeclarer() ConstantDeclarer {
 	return NewConstantDeclarer("type_resolver::00_common", typeResolverInitDecl)
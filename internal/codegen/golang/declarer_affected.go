@@ -0,0 +1,14 @@
+package golang
+
+// NewAffectedRowsDeclarer declares the ErrUnexpectedRowsAffected error
+// returned by queries with an affected pragma.
+func NewAffectedRowsDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(affectedRowsKey, affectedRowsDecl)
+}
+
+const affectedRowsKey = "affected_rows"
+
+const affectedRowsDecl = `// ErrUnexpectedRowsAffected is the error wrapped by queries with an affected
+// pragma when the query affects a different number of rows than expected, like
+// an update guarded by an optimistic-lock version that matched no rows.
+var ErrUnexpectedRowsAffected = errors.New("unexpected number of rows affected")`
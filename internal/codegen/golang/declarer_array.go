@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
 )
 
 // NameArrayTranscoderFunc returns the function name that creates a
 // pgtype.ValueTranscoder for the array type that's used to decode rows returned
 // by Postgres.
 func NameArrayTranscoderFunc(typ *gotype.ArrayType) string {
+	if _, dims := gotype.ArrayDimensions(typ); dims > 1 {
+		return "new" + nameMultiArray(typ)
+	}
+	if gotype.HasOpaqueElem(typ) {
+		return "new" + nameOpaqueArrayElem(typ) + "Array"
+	}
 	return "new" + typ.Elem.BaseName() + "Array"
 }
 
@@ -20,6 +28,9 @@ func NameArrayTranscoderFunc(typ *gotype.ArrayType) string {
 // query parameters. This function is only necessary for top-level types.
 // Descendant types use the raw functions, named by NameArrayRawFunc.
 func NameArrayInitFunc(typ *gotype.ArrayType) string {
+	if _, dims := gotype.ArrayDimensions(typ); dims > 1 {
+		return "new" + nameMultiArray(typ) + "Init"
+	}
 	elem := typ.Elem
 	if t, ok := elem.(*gotype.ImportType); ok {
 		elem = t.Type
@@ -29,10 +40,14 @@ func NameArrayInitFunc(typ *gotype.ArrayType) string {
 		hasPtr = true
 		elem = t.Elem
 	}
+	name := elem.BaseName()
+	if gotype.HasOpaqueElem(typ) {
+		name = nameOpaqueArrayElem(typ)
+	}
 	if hasPtr {
-		return "new" + elem.BaseName() + "PtrArrayInit"
+		return "new" + name + "PtrArrayInit"
 	} else {
-		return "new" + elem.BaseName() + "ArrayInit"
+		return "new" + name + "ArrayInit"
 	}
 }
 
@@ -40,6 +55,9 @@ func NameArrayInitFunc(typ *gotype.ArrayType) string {
 // array for the array type so that we can use it with a parent encoder
 // function, like NameCompositeInitFunc, in the pgtype.Value Set call.
 func NameArrayRawFunc(typ *gotype.ArrayType) string {
+	if _, dims := gotype.ArrayDimensions(typ); dims > 1 {
+		return "new" + nameMultiArray(typ) + "Raw"
+	}
 	elem := typ.Elem
 	if t, ok := elem.(*gotype.ImportType); ok {
 		elem = t.Type
@@ -49,11 +67,69 @@ func NameArrayRawFunc(typ *gotype.ArrayType) string {
 		hasPtr = true
 		elem = t.Elem
 	}
+	name := elem.BaseName()
+	if gotype.HasOpaqueElem(typ) {
+		name = nameOpaqueArrayElem(typ)
+	}
 	if hasPtr {
-		return "new" + elem.BaseName() + "PtrArrayRaw"
+		return "new" + name + "PtrArrayRaw"
 	} else {
-		return "new" + elem.BaseName() + "ArrayRaw"
+		return "new" + name + "ArrayRaw"
+	}
+}
+
+// nameMultiArray returns the name for a multi-dimensional array type based on
+// the innermost element type, like Int32Array2D for [][]int32.
+func nameMultiArray(typ *gotype.ArrayType) string {
+	elem, dims := gotype.ArrayDimensions(typ)
+	if t, ok := elem.(*gotype.ImportType); ok {
+		elem = t.Type
+	}
+	ptr := ""
+	if t, ok := elem.(*gotype.PointerType); ok {
+		ptr = "Ptr"
+		elem = gotype.UnwrapNestedType(t.Elem)
+	}
+	return toFuncIdent(elem.BaseName()) + ptr + "Array" + strconv.Itoa(dims) + "D"
+}
+
+// nameOpaqueArrayElem returns the name for the elements of a slice of a
+// user-provided Go type, like Int8ID for an int8 array of ids.ID. Includes the
+// Postgres element type name because different Postgres array types might use
+// the same Go type.
+func nameOpaqueArrayElem(typ *gotype.ArrayType) string {
+	return toFuncIdent(nameArrayElem(typ.PgArray)) + toFuncIdent(gotype.UnwrapNestedType(typ.Elem).BaseName())
+}
+
+// toFuncIdent converts a type name to UpperCamelCase for use in a function
+// name by removing characters that aren't letters or digits, like OptionInt
+// for Option[int] or MyId for my_id.
+func toFuncIdent(name string) string {
+	sb := strings.Builder{}
+	isWordStart := true
+	for _, ch := range name {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) {
+			isWordStart = true
+			continue
+		}
+		if isWordStart {
+			ch = unicode.ToUpper(ch)
+			isWordStart = false
+		}
+		sb.WriteRune(ch)
+	}
+	return sb.String()
+}
+
+// arrayDedupeName returns the name of an array type for the dedupe key of a
+// declarer that declares funcName. Slices of user-provided Go types use the
+// function name because different Postgres array types might use the same Go
+// type.
+func arrayDedupeName(typ *gotype.ArrayType, funcName string) string {
+	if gotype.HasOpaqueElem(typ) {
+		return funcName
 	}
+	return typ.BaseName()
 }
 
 // ArrayTranscoderDeclarer declares a new Go function that creates a
@@ -67,10 +143,13 @@ func NewArrayDecoderDeclarer(typ *gotype.ArrayType) ArrayTranscoderDeclarer {
 }
 
 func (a ArrayTranscoderDeclarer) DedupeKey() string {
-	return "type_resolver::" + a.typ.BaseName() + "_01_transcoder"
+	return "type_resolver::" + arrayDedupeName(a.typ, NameArrayTranscoderFunc(a.typ)) + "_01_transcoder"
 }
 
-func (a ArrayTranscoderDeclarer) Declare(string) (string, error) {
+func (a ArrayTranscoderDeclarer) Declare(pkgPath string) (string, error) {
+	if _, dims := gotype.ArrayDimensions(a.typ); dims > 1 {
+		return a.declareMulti(pkgPath, dims)
+	}
 	sb := &strings.Builder{}
 	funcName := NameArrayTranscoderFunc(a.typ)
 
@@ -89,9 +168,9 @@ func (a ArrayTranscoderDeclarer) Declare(string) (string, error) {
 
 	// newArrayValue call
 	sb.WriteString("return tr.newArrayValue(")
-	sb.WriteString(strconv.Quote(a.typ.PgArray.Name))
+	sb.WriteString(strconv.Quote(runtimeTypeName(a.typ.PgArray)))
 	sb.WriteString(", ")
-	sb.WriteString(strconv.Quote(a.typ.PgArray.Elem.String()))
+	sb.WriteString(strconv.Quote(runtimeTypeName(a.typ.PgArray.Elem)))
 	sb.WriteString(", ")
 
 	// Default element transcoder
@@ -102,7 +181,11 @@ func (a ArrayTranscoderDeclarer) Declare(string) (string, error) {
 	case *gotype.EnumType:
 		sb.WriteString(NameEnumTranscoderFunc(elem))
 	default:
-		return "", fmt.Errorf("array composite decoder only supports composite and enum elems; got %T", a.typ.Elem)
+		if !gotype.HasOpaqueElem(a.typ) {
+			return "", fmt.Errorf("array composite decoder only supports composite, enum, and opaque elems; got %T", a.typ.Elem)
+		}
+		// The element transcoder assigns to the user-provided Go type.
+		sb.WriteString(declareDefaultElemTranscoder(a.typ.PgArray, pkgPath))
 	}
 	sb.WriteString(")")
 	sb.WriteString("\n")
@@ -111,6 +194,87 @@ func (a ArrayTranscoderDeclarer) Declare(string) (string, error) {
 	return sb.String(), nil
 }
 
+// declareMulti declares the transcoder for a multi-dimensional array type
+// using the multiArrayValue transcoder.
+func (a ArrayTranscoderDeclarer) declareMulti(pkgPath string, dims int) (string, error) {
+	sb := &strings.Builder{}
+	funcName := NameArrayTranscoderFunc(a.typ)
+
+	// Doc comment
+	sb.WriteString("// ")
+	sb.WriteString(funcName)
+	sb.WriteString(" creates a new pgtype.ValueTranscoder for the Postgres\n")
+	sb.WriteString("// ")
+	sb.WriteString(strconv.Itoa(dims))
+	sb.WriteString("-dimensional '")
+	sb.WriteString(a.typ.PgArray.Name)
+	sb.WriteString("' array type.\n")
+
+	// Function signature
+	sb.WriteString("func (tr *typeResolver) ")
+	sb.WriteString(funcName)
+	sb.WriteString("() pgtype.ValueTranscoder {\n\t")
+
+	// newMultiArrayValue call
+	sb.WriteString("return tr.newMultiArrayValue(")
+	sb.WriteString(strconv.Quote(runtimeTypeName(a.typ.PgArray)))
+	sb.WriteString(", ")
+	sb.WriteString(strconv.Quote(nameArrayElem(a.typ.PgArray)))
+	sb.WriteString(", ")
+	sb.WriteString(strconv.Itoa(dims))
+	sb.WriteString(", ")
+
+	// Default element transcoder
+	elem, _ := gotype.ArrayDimensions(a.typ)
+	switch elem := gotype.UnwrapNestedType(elem).(type) {
+	case *gotype.CompositeType:
+		sb.WriteString("tr.")
+		sb.WriteString(NameCompositeTranscoderFunc(elem))
+	case *gotype.EnumType:
+		sb.WriteString(NameEnumTranscoderFunc(elem))
+	default:
+		sb.WriteString(declareDefaultElemTranscoder(a.typ.PgArray, pkgPath))
+	}
+	sb.WriteString(")")
+	sb.WriteString("\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// declareDefaultElemTranscoder returns a function that creates the
+// pgtype.ValueTranscoder for the elements of a Postgres array type. Only used
+// if the element type isn't registered in pgtype.ConnInfo. Uses the pgtype
+// type for the base type of a domain and pgtype.GenericText for other unknown
+// types.
+func declareDefaultElemTranscoder(pgArray pg.ArrayType, pkgPath string) string {
+	decoder := "pgtype.GenericText"
+	pgElem := pgArray.Elem
+	for {
+		domain, ok := pgElem.(pg.DomainType)
+		if !ok {
+			break
+		}
+		pgElem = domain.BaseType
+	}
+	if pgElem != nil {
+		if decoderType, ok := gotype.FindKnownTypePgx(pgElem.OID()); ok {
+			decoder = gotype.QualifyType(decoderType, pkgPath)
+		}
+	}
+	return "func() pgtype.ValueTranscoder { return &" + decoder + "{} }" // pgx needs pointers to types
+}
+
+// nameArrayElem returns the Postgres type name of the array elements. Known
+// array types, like pg.Int4Array, don't have an element type, so use the
+// Postgres naming convention where the array type name is the element type
+// name prefixed with an underscore.
+func nameArrayElem(typ pg.ArrayType) string {
+	if typ.Elem != nil {
+		return runtimeTypeName(typ.Elem)
+	}
+	return strings.TrimPrefix(typ.Name, "_")
+}
+
 // ArrayInitDeclarer declares a new Go function that creates an *initialized*
 // pgtype.ValueTranscoder for the Postgres type represented by the
 // gotype.ArrayType.
@@ -132,10 +296,13 @@ func NewArrayInitDeclarer(typ *gotype.ArrayType) ArrayInitDeclarer {
 }
 
 func (a ArrayInitDeclarer) DedupeKey() string {
-	return "type_resolver::" + a.typ.BaseName() + "_02_init"
+	return "type_resolver::" + arrayDedupeName(a.typ, NameArrayInitFunc(a.typ)) + "_02_init"
 }
 
-func (a ArrayInitDeclarer) Declare(string) (string, error) {
+func (a ArrayInitDeclarer) Declare(pkgPath string) (string, error) {
+	if _, dims := gotype.ArrayDimensions(a.typ); dims > 1 {
+		return a.declareMulti()
+	}
 	funcName := NameArrayInitFunc(a.typ)
 	sb := &strings.Builder{}
 	sb.Grow(256)
@@ -152,7 +319,7 @@ func (a ArrayInitDeclarer) Declare(string) (string, error) {
 	sb.WriteString("func (tr *typeResolver) ")
 	sb.WriteString(funcName)
 	sb.WriteString("(ps ")
-	sb.WriteString(a.typ.BaseName())
+	sb.WriteString(gotype.QualifyType(a.typ, pkgPath))
 	sb.WriteString(") pgtype.ValueTranscoder {\n\t")
 
 	// Function body
@@ -166,12 +333,50 @@ func (a ArrayInitDeclarer) Declare(string) (string, error) {
 	sb.WriteString(" // should always succeed\n\t")
 	sb.WriteString("}\n\t")
 	sb.WriteString("return textPreferrer{ValueTranscoder: dec, typeName: ")
-	sb.WriteString(strconv.Quote(a.typ.PgArray.Name))
+	sb.WriteString(strconv.Quote(runtimeTypeName(a.typ.PgArray)))
 	sb.WriteString("}\n")
 	sb.WriteString("}")
 	return sb.String(), nil
 }
 
+// declareMulti declares the init function for a multi-dimensional array type.
+// Unlike one-dimensional arrays, setting the transcoder might fail if the
+// nested slices aren't rectangular, so the transcoder reports the error when
+// pgx encodes the query parameter instead of panicking.
+func (a ArrayInitDeclarer) declareMulti() (string, error) {
+	funcName := NameArrayInitFunc(a.typ)
+	sb := &strings.Builder{}
+	sb.Grow(256)
+
+	// Doc comment
+	sb.WriteString("// ")
+	sb.WriteString(funcName)
+	sb.WriteString(" creates an initialized pgtype.ValueTranscoder for the\n")
+	sb.WriteString("// Postgres array type '")
+	sb.WriteString(a.typ.PgArray.Name)
+	sb.WriteString("' to encode query parameters.\n")
+
+	// Function signature
+	sb.WriteString("func (tr *typeResolver) ")
+	sb.WriteString(funcName)
+	sb.WriteString("(ps ")
+	sb.WriteString(a.typ.BaseName())
+	sb.WriteString(") pgtype.ValueTranscoder {\n\t")
+
+	// Function body
+	sb.WriteString("dec := tr.")
+	sb.WriteString(NameArrayTranscoderFunc(a.typ))
+	sb.WriteString("()\n\t")
+	sb.WriteString("// Okay to ignore error because the transcoder reports the error, like for\n\t")
+	sb.WriteString("// ragged slices, when encoding the query parameter.\n\t")
+	sb.WriteString("_ = dec.Set(tr.")
+	sb.WriteString(NameArrayRawFunc(a.typ))
+	sb.WriteString("(ps))\n\t")
+	sb.WriteString("return dec\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
 // ArrayRawDeclarer declares a new Go function that returns all fields
 // as a generic array: []interface{}. Necessary because we can only set
 // pgtype.ArrayType from a []interface{}.
@@ -184,7 +389,7 @@ func NewArrayRawDeclarer(typ *gotype.ArrayType) ArrayRawDeclarer {
 }
 
 func (a ArrayRawDeclarer) DedupeKey() string {
-	return "type_resolver::" + a.typ.BaseName() + "_03_raw"
+	return "type_resolver::" + arrayDedupeName(a.typ, NameArrayRawFunc(a.typ)) + "_03_raw"
 }
 
 func (a ArrayRawDeclarer) Declare(pkgPath string) (string, error) {
@@ -215,6 +420,14 @@ func (a ArrayRawDeclarer) Declare(pkgPath string) (string, error) {
 		sb.WriteString("tr.")
 		sb.WriteString(NameCompositeRawFunc(elem))
 		sb.WriteString("(v)")
+	case *gotype.ArrayType:
+		if gotype.IsPgxSupportedArray(elem) {
+			sb.WriteString("v")
+			break
+		}
+		sb.WriteString("tr.")
+		sb.WriteString(NameArrayRawFunc(elem))
+		sb.WriteString("(v)")
 	default:
 		sb.WriteString("v")
 	}
@@ -224,3 +437,338 @@ func (a ArrayRawDeclarer) Declare(pkgPath string) (string, error) {
 	sb.WriteString("}")
 	return sb.String(), nil
 }
+
+const multiArrayValueDecl = `// multiArrayValue is a pgtype.ValueTranscoder for a multi-dimensional Postgres
+// array. pgtype.ArrayType only sets and assigns one-dimensional slices, so
+// multiArrayValue walks nested slices with reflection. Postgres requires
+// multi-dimensional arrays to be rectangular, meaning all slices in the same
+// dimension must have the same length.
+type multiArrayValue struct {
+	typeName string                        // Postgres array type name
+	elemOID  uint32                        // OID of the element type or unknownOID
+	dims     int                           // number of dimensions of the Go slice
+	newElem  func() pgtype.ValueTranscoder // creates a transcoder for an element
+	shape    []pgtype.ArrayDimension       // length of each dimension, nil if empty
+	elems    []pgtype.ValueTranscoder      // elements in row-major order
+	status   pgtype.Status
+	err      error // error from Set, reported when encoding
+}
+
+// newMultiArrayValue creates a multiArrayValue for the array type name with
+// dims dimensions. Transcodes elements with the pgtype.ValueTranscoder
+// registered for elemName, falling back to defaultVal.
+func (tr *typeResolver) newMultiArrayValue(name, elemName string, dims int, defaultVal func() pgtype.ValueTranscoder) pgtype.ValueTranscoder {
+	elemOID, elemVal, ok := tr.findValue(elemName)
+	newElem := func() pgtype.ValueTranscoder {
+		return pgtype.NewValue(elemVal).(pgtype.ValueTranscoder)
+	}
+	if !ok {
+		elemOID = unknownOID
+		newElem = defaultVal
+	}
+	return &multiArrayValue{typeName: name, elemOID: elemOID, dims: dims, newElem: newElem}
+}
+
+// Set implements pgtype.Value. Set accepts nested slices of any type that the
+// element transcoder accepts, including nested []interface{}.
+func (v *multiArrayValue) Set(src interface{}) error {
+	v.shape, v.elems, v.err = nil, nil, nil
+	rv := reflect.ValueOf(src)
+	if src == nil || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+		v.status = pgtype.Null
+		return nil
+	}
+	shape := make([]pgtype.ArrayDimension, v.dims)
+	if err := v.setDimension(rv, 0, shape); err != nil {
+		v.shape, v.elems, v.status = nil, nil, pgtype.Undefined
+		v.err = fmt.Errorf("set %d-dimensional array %s: %w", v.dims, v.typeName, err)
+		return v.err
+	}
+	if len(v.elems) > 0 {
+		v.shape = shape
+	}
+	v.status = pgtype.Present
+	return nil
+}
+
+// setDimension appends the elements of the nested slice rv for dimension dim
+// to v.elems. Returns an error if the slice has a different length than other
+// slices in the same dimension.
+func (v *multiArrayValue) setDimension(rv reflect.Value, dim int, shape []pgtype.ArrayDimension) error {
+	if dim == v.dims {
+		elem := v.newElem()
+		if err := elem.Set(rv.Interface()); err != nil {
+			return err
+		}
+		v.elems = append(v.elems, elem)
+		return nil
+	}
+	if rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return fmt.Errorf("dimension %d: want a slice; got %v", dim+1, rv)
+	}
+	n := int32(rv.Len())
+	switch {
+	case shape[dim].LowerBound == 0: // first slice in this dimension
+		shape[dim] = pgtype.ArrayDimension{Length: n, LowerBound: 1}
+	case shape[dim].Length != n:
+		return fmt.Errorf("dimension %d: slices must have the same length; got lengths %d and %d", dim+1, shape[dim].Length, n)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := v.setDimension(rv.Index(i), dim+1, shape); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get implements pgtype.Value.
+func (v *multiArrayValue) Get() interface{} {
+	switch v.status {
+	case pgtype.Present:
+		return v
+	case pgtype.Null:
+		return nil
+	default:
+		return v.status
+	}
+}
+
+// AssignTo implements pgtype.Value. dst must be a pointer to a nested slice
+// with the same number of dimensions as the array.
+func (v *multiArrayValue) AssignTo(dst interface{}) error {
+	switch v.status {
+	case pgtype.Present:
+		rv := reflect.ValueOf(dst)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("cannot assign array %s to non-pointer %T", v.typeName, dst)
+		}
+		if len(v.shape) > 0 && len(v.shape) != v.dims {
+			return fmt.Errorf("cannot assign %d-dimensional array %s to %d-dimensional %T", len(v.shape), v.typeName, v.dims, dst)
+		}
+		idx := 0
+		if err := v.assignDimension(rv.Elem(), 0, &idx); err != nil {
+			return fmt.Errorf("assign %d-dimensional array %s to %T: %w", v.dims, v.typeName, dst, err)
+		}
+		return nil
+	case pgtype.Null:
+		return pgtype.NullAssignTo(dst)
+	}
+	return fmt.Errorf("cannot decode %#v into %T", v, dst)
+}
+
+// assignDimension assigns the elements starting at v.elems[*idx] to the nested
+// slice rv for dimension dim.
+func (v *multiArrayValue) assignDimension(rv reflect.Value, dim int, idx *int) error {
+	if dim == v.dims {
+		err := v.elems[*idx].AssignTo(rv.Addr().Interface())
+		*idx++
+		return err
+	}
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("dimension %d: want a slice; got %s", dim+1, rv.Type())
+	}
+	n := 0
+	if len(v.shape) > 0 {
+		n = int(v.shape[dim].Length)
+	}
+	rv.Set(reflect.MakeSlice(rv.Type(), n, n))
+	for i := 0; i < n; i++ {
+		if err := v.assignDimension(rv.Index(i), dim+1, idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDimensions returns an error if a decoded array isn't empty and doesn't
+// have the same number of dimensions as the Go slice.
+func (v *multiArrayValue) checkDimensions(shape []pgtype.ArrayDimension) error {
+	if len(shape) == 0 || len(shape) == v.dims {
+		return nil
+	}
+	return fmt.Errorf("decode array %s: got %d-dimensional array; want %d dimensions", v.typeName, len(shape), v.dims)
+}
+
+// DecodeText implements pgtype.TextDecoder.
+func (v *multiArrayValue) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	v.shape, v.elems, v.err = nil, nil, nil
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	uta, err := pgtype.ParseUntypedTextArray(string(src))
+	if err != nil {
+		return fmt.Errorf("decode array %s: %w", v.typeName, err)
+	}
+	if err := v.checkDimensions(uta.Dimensions); err != nil {
+		return err
+	}
+	elems := make([]pgtype.ValueTranscoder, len(uta.Elements))
+	for i, s := range uta.Elements {
+		elem := v.newElem()
+		var elemSrc []byte
+		if s != "NULL" || uta.Quoted[i] {
+			elemSrc = []byte(s)
+		}
+		if err := elem.DecodeText(ci, elemSrc); err != nil {
+			return fmt.Errorf("decode array %s element: %w", v.typeName, err)
+		}
+		elems[i] = elem
+	}
+	if len(elems) > 0 {
+		v.shape = uta.Dimensions
+	}
+	v.elems = elems
+	v.status = pgtype.Present
+	return nil
+}
+
+// DecodeBinary implements pgtype.BinaryDecoder.
+func (v *multiArrayValue) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	v.shape, v.elems, v.err = nil, nil, nil
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	var header pgtype.ArrayHeader
+	rp, err := header.DecodeBinary(ci, src)
+	if err != nil {
+		return fmt.Errorf("decode array %s: %w", v.typeName, err)
+	}
+	if err := v.checkDimensions(header.Dimensions); err != nil {
+		return err
+	}
+	count := 0
+	if len(header.Dimensions) > 0 {
+		count = 1
+		for _, d := range header.Dimensions {
+			count *= int(d.Length)
+		}
+	}
+	elems := make([]pgtype.ValueTranscoder, count)
+	for i := range elems {
+		if len(src) < rp+4 {
+			return fmt.Errorf("decode array %s: element %d too short", v.typeName, i)
+		}
+		n := int(int32(uint32(src[rp])<<24 | uint32(src[rp+1])<<16 | uint32(src[rp+2])<<8 | uint32(src[rp+3])))
+		rp += 4
+		var elemSrc []byte
+		if n >= 0 {
+			if len(src) < rp+n {
+				return fmt.Errorf("decode array %s: element %d too short", v.typeName, i)
+			}
+			elemSrc = src[rp : rp+n]
+			rp += n
+		}
+		elem := v.newElem()
+		if err := elem.DecodeBinary(ci, elemSrc); err != nil {
+			return fmt.Errorf("decode array %s element: %w", v.typeName, err)
+		}
+		elems[i] = elem
+	}
+	if len(elems) > 0 {
+		v.shape = header.Dimensions
+	}
+	v.elems = elems
+	v.status = pgtype.Present
+	return nil
+}
+
+// EncodeText implements pgtype.TextEncoder.
+func (v *multiArrayValue) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch {
+	case v.err != nil:
+		return nil, v.err
+	case v.status == pgtype.Null:
+		return nil, nil
+	case v.status == pgtype.Undefined:
+		return nil, fmt.Errorf("cannot encode undefined array %s", v.typeName)
+	case len(v.shape) == 0:
+		return append(buf, '{', '}'), nil
+	}
+	// dimCounts is the number of elements in each sub-array of a dimension. For
+	// example, an array with lengths [3,5,2] has dimCounts of [30,10,2].
+	dimCounts := make([]int, len(v.shape))
+	dimCounts[len(dimCounts)-1] = int(v.shape[len(v.shape)-1].Length)
+	for i := len(dimCounts) - 2; i >= 0; i-- {
+		dimCounts[i] = int(v.shape[i].Length) * dimCounts[i+1]
+	}
+	for i, elem := range v.elems {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		for _, n := range dimCounts {
+			if i%n == 0 {
+				buf = append(buf, '{')
+			}
+		}
+		elemBuf, err := elem.EncodeText(ci, nil)
+		if err != nil {
+			return nil, fmt.Errorf("encode array %s element: %w", v.typeName, err)
+		}
+		if elemBuf == nil {
+			buf = append(buf, "NULL"...)
+		} else {
+			buf = append(buf, pgtype.QuoteArrayElementIfNeeded(string(elemBuf))...)
+		}
+		for _, n := range dimCounts {
+			if (i+1)%n == 0 {
+				buf = append(buf, '}')
+			}
+		}
+	}
+	return buf, nil
+}
+
+// EncodeBinary implements pgtype.BinaryEncoder.
+func (v *multiArrayValue) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch {
+	case v.err != nil:
+		return nil, v.err
+	case v.status == pgtype.Null:
+		return nil, nil
+	case v.status == pgtype.Undefined:
+		return nil, fmt.Errorf("cannot encode undefined array %s", v.typeName)
+	case v.elemOID == unknownOID:
+		return nil, fmt.Errorf("cannot encode array %s in binary format with unknown element type", v.typeName)
+	}
+	header := pgtype.ArrayHeader{ElementOID: int32(v.elemOID), Dimensions: v.shape}
+	for _, elem := range v.elems {
+		if elem.Get() == nil {
+			header.ContainsNull = true
+			break
+		}
+	}
+	buf = header.EncodeBinary(ci, buf)
+	for _, elem := range v.elems {
+		start := len(buf)
+		buf = append(buf, 0xff, 0xff, 0xff, 0xff) // length of -1 for null
+		elemBuf, err := elem.EncodeBinary(ci, buf)
+		if err != nil {
+			return nil, fmt.Errorf("encode array %s element: %w", v.typeName, err)
+		}
+		if elemBuf != nil {
+			buf = elemBuf
+			n := uint32(len(buf) - start - 4)
+			buf[start], buf[start+1], buf[start+2], buf[start+3] = byte(n>>24), byte(n>>16), byte(n>>8), byte(n)
+		}
+	}
+	return buf, nil
+}
+
+// PreferredParamFormat implements pgtype.ParamFormatPreferrer. The text format
+// doesn't require the OID of the element type, which pggen might not know.
+func (v *multiArrayValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }`
+
+// NewMultiArrayValueDeclarer declares the pgtype.ValueTranscoder for all
+// multi-dimensional Postgres array types.
+func NewMultiArrayValueDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(multiArrayValueKey, multiArrayValueDecl)
+}
+
+// multiArrayValueKey is the dedupe key for the multiArrayValue declarer. The
+// leader file imports reflect if it declares multiArrayValue.
+const multiArrayValueKey = "type_resolver::02_array"
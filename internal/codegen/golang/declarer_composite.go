@@ -48,7 +48,11 @@ func (c CompositeTypeDeclarer) DedupeKey() string {
 func (c CompositeTypeDeclarer) Declare(pkgPath string) (string, error) {
 	sb := &strings.Builder{}
 	// Doc string
-	if c.comp.PgComposite.Name != "" {
+	if c.comp.PgComposite.IsRecord() {
+		sb.WriteString("// ")
+		sb.WriteString(c.comp.Name)
+		sb.WriteString(" represents an anonymous Postgres record, like ROW(1, 'foo').\n")
+	} else if c.comp.PgComposite.Name != "" {
 		sb.WriteString("// ")
 		sb.WriteString(c.comp.Name)
 		sb.WriteString(" represents the Postgres composite type ")
@@ -141,7 +145,7 @@ func (c CompositeTranscoderDeclarer) Declare(pkgPath string) (string, error) {
 
 	// newCompositeValue call
 	sb.WriteString("return tr.newCompositeValue(\n\t\t")
-	sb.WriteString(strconv.Quote(c.typ.PgComposite.Name))
+	sb.WriteString(strconv.Quote(runtimeTypeName(c.typ.PgComposite)))
 	sb.WriteString(",")
 
 	// newCompositeValue - field names of the composite type
@@ -150,7 +154,7 @@ func (c CompositeTranscoderDeclarer) Declare(pkgPath string) (string, error) {
 		sb.WriteString(`compositeField{name: `)
 		sb.WriteString(strconv.Quote(c.typ.PgComposite.ColumnNames[i])) // field name
 		sb.WriteString(", typeName: ")
-		sb.WriteString(strconv.Quote(c.typ.PgComposite.ColumnTypes[i].String())) // field type name
+		sb.WriteString(strconv.Quote(runtimeTypeName(c.typ.PgComposite.ColumnTypes[i]))) // field type name
 		sb.WriteString(", defaultVal: ")
 
 		// field default pgtype.ValueTranscoder
@@ -173,6 +177,14 @@ func (c CompositeTranscoderDeclarer) Declare(pkgPath string) (string, error) {
 			sb.WriteString("tr.")
 			sb.WriteString(NameArrayTranscoderFunc(fieldType))
 			sb.WriteString("()")
+		case *gotype.RangeType:
+			sb.WriteString("tr.")
+			sb.WriteString(NameRangeTranscoderFunc(fieldType))
+			sb.WriteString("()")
+		case *gotype.MultirangeType:
+			sb.WriteString("tr.")
+			sb.WriteString(NameMultirangeTranscoderFunc(fieldType))
+			sb.WriteString("()")
 		case *gotype.VoidType:
 			// skip
 		default:
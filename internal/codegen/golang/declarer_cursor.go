@@ -0,0 +1,14 @@
+package golang
+
+// NewCursorSeqDeclarer declares the counter that cursor methods use to name
+// server-side cursors.
+func NewCursorSeqDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(cursorSeqKey, cursorSeqDecl)
+}
+
+const cursorSeqKey = "cursor_seq"
+
+const cursorSeqDecl = `// cursorSeq numbers the server-side cursors declared by cursor methods so each
+// call declares a cursor with a unique name, even if a transaction runs the
+// same cursor method more than once.
+var cursorSeq atomic.Uint64`
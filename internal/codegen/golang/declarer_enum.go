@@ -102,7 +102,7 @@ func (e EnumTranscoderDeclarer) Declare(string) (string, error) {
 
 	// NewEnumType call
 	sb.WriteString("return pgtype.NewEnumType(\n\t\t")
-	sb.WriteString(strconv.Quote(e.typ.PgEnum.Name))
+	sb.WriteString(strconv.Quote(runtimeTypeName(e.typ.PgEnum)))
 	sb.WriteString(",\n\t\t")
 	sb.WriteString(`[]string{`)
 	for _, label := range e.typ.Labels {
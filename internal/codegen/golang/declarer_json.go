@@ -0,0 +1,55 @@
+package golang
+
+// NewJSONValueDeclarer declares the jsonValue type that encodes params and
+// decodes columns of Go types stored as Postgres json or jsonb.
+func NewJSONValueDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(jsonValueKey, jsonValueDecl)
+}
+
+// jsonValueKey is the dedupe key for the jsonValue declarer. The leader file
+// imports jsonValueImports if it declares jsonValue.
+const jsonValueKey = "json_value"
+
+// jsonValueImports are the packages used by jsonValueDecl.
+//
+//nolint:gochecknoglobals
+var jsonValueImports = []string{"database/sql/driver", "encoding/json", "fmt", "reflect"}
+
+const jsonValueDecl = `// jsonValue encodes a Go value as JSON for a Postgres json or jsonb param and
+// decodes a json or jsonb column into a Go value. jsonValue implements
+// driver.Valuer and sql.Scanner.
+type jsonValue struct {
+	name string // the param or column name for error messages
+	v    any    // the param value or a pointer to the column destination
+}
+
+// Value implements driver.Valuer. A nil pointer encodes as NULL.
+func (j jsonValue) Value() (driver.Value, error) {
+	if rv := reflect.ValueOf(j.v); !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return nil, nil
+	}
+	data, err := json.Marshal(j.v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s as JSON: %w", j.name, err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner. NULL leaves the destination unchanged.
+func (j jsonValue) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return fmt.Errorf("scan %s as JSON: unsupported type %T", j.name, src)
+	}
+	if err := json.Unmarshal(data, j.v); err != nil {
+		return fmt.Errorf("unmarshal %s as JSON: %w", j.name, err)
+	}
+	return nil
+}`
@@ -0,0 +1,13 @@
+package golang
+
+// NewDuplicateKeyDeclarer declares the ErrDuplicateKey error returned by :map
+// queries.
+func NewDuplicateKeyDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(duplicateKeyKey, duplicateKeyDecl)
+}
+
+const duplicateKeyKey = "duplicate_key"
+
+const duplicateKeyDecl = `// ErrDuplicateKey is the error wrapped by :map queries when more than one row
+// has the same key.
+var ErrDuplicateKey = errors.New("duplicate key in query rows")`
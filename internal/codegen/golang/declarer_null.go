@@ -0,0 +1,94 @@
+package golang
+
+import (
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+)
+
+// FindNullTypeDeclarers finds the declarer for the generic Null type if typ or
+// any descendant type uses the Null type declared by pggen. Returns nil if no
+// declarers are needed.
+func FindNullTypeDeclarers(typ gotype.Type) DeclarerSet {
+	if !hasGenericNullType(typ) {
+		return nil
+	}
+	return NewDeclarerSet(NewNullTypeDeclarer())
+}
+
+func hasGenericNullType(typ gotype.Type) bool {
+	switch typ := typ.(type) {
+	case *gotype.NullType:
+		return typ.PkgPath == ""
+	case *gotype.ImportType:
+		return hasGenericNullType(typ.Type)
+	case *gotype.PointerType:
+		return hasGenericNullType(typ.Elem)
+	case *gotype.ArrayType:
+		return hasGenericNullType(typ.Elem)
+	case *gotype.CompositeType:
+		for _, fieldType := range typ.FieldTypes {
+			if hasGenericNullType(fieldType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewNullTypeDeclarer declares the generic Null type used by the generic
+// nullable style.
+func NewNullTypeDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(nullTypeKey, nullTypeDecl)
+}
+
+// nullTypeKey is the dedupe key for the Null type declarer. The leader file
+// imports nullTypeImports if it declares the Null type.
+const nullTypeKey = "null_type"
+
+// nullTypeImports are the packages used by nullTypeDecl.
+//
+//nolint:gochecknoglobals
+var nullTypeImports = []string{"database/sql", "database/sql/driver", "encoding/json"}
+
+const nullTypeDecl = `// Null represents a value of type T that may be NULL. Null implements
+// sql.Scanner and driver.Valuer like sql.Null, but marshals NULL to JSON null
+// and other values to the JSON of the value.
+type Null[T any] struct {
+	V     T
+	Valid bool // Valid is true if V is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *Null[T]) Scan(src any) error {
+	var sn sql.Null[T]
+	if err := sn.Scan(src); err != nil {
+		return err
+	}
+	n.V, n.Valid = sn.V, sn.Valid
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n Null[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: n.V, Valid: n.Valid}.Value()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}`
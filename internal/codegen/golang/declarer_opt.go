@@ -0,0 +1,13 @@
+package golang
+
+// NewTooManyRowsDeclarer declares the ErrTooManyRows error returned by :opt
+// queries.
+func NewTooManyRowsDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer(tooManyRowsKey, tooManyRowsDecl)
+}
+
+const tooManyRowsKey = "too_many_rows"
+
+const tooManyRowsDecl = `// ErrTooManyRows is the error wrapped by :opt queries when the query returns
+// more than one row.
+var ErrTooManyRows = errors.New("query returned more than one row")`
@@ -0,0 +1,165 @@
+package golang
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
+)
+
+// Package paths used by the generated code for pgx v5.
+const (
+	pgx5Pkg       = "github.com/jackc/pgx/v5"
+	pgx5PgconnPkg = "github.com/jackc/pgx/v5/pgconn"
+	pgx5PgtypePkg = "github.com/jackc/pgx/v5/pgtype"
+)
+
+// firstNormalOID is the first OID that Postgres assigns to user-defined
+// objects, FirstNormalObjectId in the Postgres source. Builtin types have a
+// lower OID.
+const firstNormalOID = 16384
+
+// FindDeclarersPgx5 finds all necessary Declarers for types that appear in
+// the input parameters or output rows when generating code for pgx v5. pgx v5
+// encodes and decodes enums and composite types with the types loaded by
+// RegisterTypes, so only the Go types need a declaration.
+func FindDeclarersPgx5(typ gotype.Type) DeclarerSet {
+	decls := NewDeclarerSet()
+	findDeclsPgx5Helper(typ, decls)
+	return decls
+}
+
+func findDeclsPgx5Helper(typ gotype.Type, decls DeclarerSet) {
+	switch typ := gotype.UnwrapNestedType(typ).(type) {
+	case *gotype.EnumType:
+		decls.AddAll(NewEnumTypeDeclarer(typ))
+	case *gotype.CompositeType:
+		decls.AddAll(NewCompositeTypeDeclarer(typ))
+		for _, childType := range typ.FieldTypes {
+			findDeclsPgx5Helper(childType, decls)
+		}
+	case *gotype.ArrayType:
+		findDeclsPgx5Helper(typ.Elem, decls)
+	case *gotype.RangeType:
+		findDeclsPgx5Helper(typ.Elem, decls)
+	case *gotype.MultirangeType:
+		findDeclsPgx5Helper(typ.Range.Elem, decls)
+	}
+}
+
+// RegisterTypesDeclarer declares the RegisterTypes function that loads the
+// user-defined Postgres types, like enums and composite types, into the
+// pgtype.Map of a pgx v5 connection.
+type RegisterTypesDeclarer struct {
+	typeNames []string // Postgres type names ordered so dependencies come first
+}
+
+func NewRegisterTypesDeclarer(typeNames []string) RegisterTypesDeclarer {
+	return RegisterTypesDeclarer{typeNames: typeNames}
+}
+
+func (r RegisterTypesDeclarer) DedupeKey() string {
+	return "register_types"
+}
+
+func (r RegisterTypesDeclarer) Declare(string) (string, error) {
+	sb := &strings.Builder{}
+	sb.WriteString("// registerTypeNames are the user-defined Postgres types, like enums and\n")
+	sb.WriteString("// composite types, that pgx must load to encode and decode query params and\n")
+	sb.WriteString("// rows. Types come after the types they depend on.\n")
+	sb.WriteString("var registerTypeNames = []string{")
+	if len(r.typeNames) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, name := range r.typeNames {
+		sb.WriteString("\t")
+		sb.WriteString(strconv.Quote(name))
+		sb.WriteString(",\n")
+	}
+	sb.WriteString("}\n\n")
+	sb.WriteString(registerTypesDecl)
+	return sb.String(), nil
+}
+
+const registerTypesDecl = `// RegisterTypes loads the user-defined Postgres types used by the queries and
+// registers the types in the pgtype.Map of conn. Call RegisterTypes for each
+// new connection, like in pgxpool.Config.AfterConnect.
+func RegisterTypes(ctx context.Context, conn *pgx.Conn) error {
+	for _, name := range registerTypeNames {
+		typ, err := conn.LoadType(ctx, name)
+		if err != nil {
+			return fmt.Errorf("load type %s: %w", name, err)
+		}
+		conn.TypeMap().RegisterType(typ)
+	}
+	return nil
+}`
+
+// listRegisterTypeNames lists the names of the user-defined Postgres types
+// that pgx v5 must load to encode and decode types, including descendant
+// types. Dependencies come before the types that depend on them because
+// pgx.Conn.LoadType requires that the dependencies are registered.
+func listRegisterTypeNames(types []pg.Type) []string {
+	var names []string
+	registered := make(map[string]bool) // by qualified name
+	var register func(pgt pg.Type) bool
+	register = func(pgt pg.Type) bool {
+		qualName := pg.QualifiedName(pgt)
+		if isRegistered, ok := registered[qualName]; ok {
+			return isRegistered
+		}
+		isRegistered := false
+		switch pgt := pgt.(type) {
+		case pg.EnumType:
+			isRegistered = true
+		case pg.CompositeType:
+			for _, colType := range pgt.ColumnTypes {
+				register(colType)
+			}
+			// Anonymous records don't have a named type to load. pgx decodes
+			// records using the OIDs of the fields.
+			isRegistered = !pgt.IsRecord()
+		case pg.ArrayType:
+			isRegistered = register(pgt.Elem)
+		case pg.DomainType:
+			isRegistered = register(pgt.BaseType) || pgt.BaseType.OID() < firstNormalOID
+		case pg.RangeType:
+			register(pgt.Subtype)
+			isRegistered = pgt.OID() >= firstNormalOID
+		case pg.MultirangeType:
+			isRegistered = register(pgt.Range) && pgt.OID() >= firstNormalOID
+		}
+		registered[qualName] = isRegistered
+		if isRegistered {
+			names = append(names, qualifyTypeName(pgt))
+		}
+		return isRegistered
+	}
+	for _, typ := range types {
+		register(typ)
+	}
+	return names
+}
+
+// qualifyTypeName returns the name of the Postgres type to look up with
+// pgx.Conn.LoadType, like "device_type" or "billing.status". Quotes names
+// that aren't lowercase identifiers.
+func qualifyTypeName(pgt pg.Type) string {
+	name := quoteTypeIdent(pgt.String())
+	if schema := pg.SchemaName(pgt); schema != "" && schema != "public" {
+		return quoteTypeIdent(schema) + "." + name
+	}
+	return name
+}
+
+func quoteTypeIdent(s string) string {
+	for i, ch := range s {
+		isLower := ch >= 'a' && ch <= 'z'
+		isDigit := ch >= '0' && ch <= '9'
+		if !isLower && ch != '_' && (!isDigit || i == 0) {
+			return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+		}
+	}
+	return s
+}
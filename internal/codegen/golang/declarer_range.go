@@ -0,0 +1,829 @@
+package golang
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+)
+
+// NameRangeTranscoderFunc returns the function name that creates a
+// pgtype.ValueTranscoder for the range type that's used to decode rows
+// returned by Postgres.
+func NameRangeTranscoderFunc(typ *gotype.RangeType) string {
+	return "new" + typ.Name
+}
+
+// NameRangeInitFunc returns the name of the function that creates an
+// initialized pgtype.ValueTranscoder for the range type used as a query
+// parameter. This function is only necessary for top-level types. Descendant
+// types set the transcoder directly with the Range value.
+func NameRangeInitFunc(typ *gotype.RangeType) string {
+	return "new" + typ.Name + "Init"
+}
+
+// NameMultirangeTranscoderFunc returns the function name that creates a
+// pgtype.ValueTranscoder for the multirange type that's used to decode rows
+// returned by Postgres.
+func NameMultirangeTranscoderFunc(typ *gotype.MultirangeType) string {
+	return "new" + typ.Name
+}
+
+// NameMultirangeInitFunc returns the name of the function that creates an
+// initialized pgtype.ValueTranscoder for the multirange type used as a query
+// parameter.
+func NameMultirangeInitFunc(typ *gotype.MultirangeType) string {
+	return "new" + typ.Name + "Init"
+}
+
+// RangeTranscoderDeclarer declares a new Go function that creates a
+// pgtype.ValueTranscoder for the Postgres range type represented by the
+// gotype.RangeType.
+type RangeTranscoderDeclarer struct {
+	typ *gotype.RangeType
+}
+
+func NewRangeTranscoderDeclarer(typ *gotype.RangeType) RangeTranscoderDeclarer {
+	return RangeTranscoderDeclarer{typ}
+}
+
+func (r RangeTranscoderDeclarer) DedupeKey() string {
+	return "type_resolver::" + r.typ.Name + "_01_transcoder"
+}
+
+func (r RangeTranscoderDeclarer) Declare(pkgPath string) (string, error) {
+	funcName := NameRangeTranscoderFunc(r.typ)
+	sb := &strings.Builder{}
+	sb.Grow(256)
+
+	// Doc comment
+	sb.WriteString("// ")
+	sb.WriteString(funcName)
+	sb.WriteString(" creates a new pgtype.ValueTranscoder for the Postgres\n")
+	sb.WriteString("// range type '")
+	sb.WriteString(r.typ.PgRange.Name)
+	sb.WriteString("'.\n")
+
+	// Function signature
+	sb.WriteString("func (tr *typeResolver) ")
+	sb.WriteString(funcName)
+	sb.WriteString("() pgtype.ValueTranscoder {\n\t")
+
+	// newRangeValue call
+	sb.WriteString("return tr.newRangeValue(")
+	sb.WriteString(strconv.Quote(runtimeTypeName(r.typ.PgRange)))
+	sb.WriteString(", ")
+	sb.WriteString(strconv.Quote(runtimeTypeName(r.typ.PgRange.Subtype)))
+	sb.WriteString(", ")
+	writeRangeBoundTranscoder(sb, r.typ, pkgPath)
+	sb.WriteString(")\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// writeRangeBoundTranscoder writes a func that creates the default
+// pgtype.ValueTranscoder for the bounds of the range type. The default is only
+// used if the range subtype isn't registered in pgtype.ConnInfo.
+func writeRangeBoundTranscoder(sb *strings.Builder, typ *gotype.RangeType, pkgPath string) {
+	if elem, ok := gotype.UnwrapNestedType(typ.Elem).(*gotype.EnumType); ok {
+		sb.WriteString(NameEnumTranscoderFunc(elem))
+		return
+	}
+	sb.WriteString("func() pgtype.ValueTranscoder { return &") // pgx needs pointers to types
+	if decoderType, ok := gotype.FindKnownTypePgx(typ.PgRange.Subtype.OID()); ok {
+		sb.WriteString(gotype.QualifyType(decoderType, pkgPath))
+	} else {
+		// Attempt to use the original, provided type.
+		sb.WriteString(gotype.QualifyType(typ.Elem, pkgPath))
+	}
+	sb.WriteString("{} }")
+}
+
+// RangeInitDeclarer declares a new Go function that creates an initialized
+// pgtype.ValueTranscoder for the Postgres range type represented by the
+// gotype.RangeType to encode query parameters.
+type RangeInitDeclarer struct {
+	typ *gotype.RangeType
+}
+
+func NewRangeInitDeclarer(typ *gotype.RangeType) RangeInitDeclarer {
+	return RangeInitDeclarer{typ}
+}
+
+func (r RangeInitDeclarer) DedupeKey() string {
+	return "type_resolver::" + r.typ.Name + "_02_init"
+}
+
+func (r RangeInitDeclarer) Declare(pkgPath string) (string, error) {
+	funcName := NameRangeInitFunc(r.typ)
+	sb := &strings.Builder{}
+	sb.Grow(256)
+
+	// Doc comment
+	sb.WriteString("// ")
+	sb.WriteString(funcName)
+	sb.WriteString(" creates an initialized pgtype.ValueTranscoder for the\n")
+	sb.WriteString("// Postgres range type '")
+	sb.WriteString(r.typ.PgRange.Name)
+	sb.WriteString("' to encode query parameters.\n")
+
+	// Function signature
+	sb.WriteString("func (tr *typeResolver) ")
+	sb.WriteString(funcName)
+	sb.WriteString("(v ")
+	sb.WriteString(gotype.QualifyType(r.typ, pkgPath))
+	sb.WriteString(") pgtype.ValueTranscoder {\n\t")
+
+	// Function body
+	sb.WriteString("return tr.setValue(tr.")
+	sb.WriteString(NameRangeTranscoderFunc(r.typ))
+	sb.WriteString("(), v)\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// MultirangeTranscoderDeclarer declares a new Go function that creates a
+// pgtype.ValueTranscoder for the Postgres multirange type represented by the
+// gotype.MultirangeType.
+type MultirangeTranscoderDeclarer struct {
+	typ *gotype.MultirangeType
+}
+
+func NewMultirangeTranscoderDeclarer(typ *gotype.MultirangeType) MultirangeTranscoderDeclarer {
+	return MultirangeTranscoderDeclarer{typ}
+}
+
+func (m MultirangeTranscoderDeclarer) DedupeKey() string {
+	return "type_resolver::" + m.typ.Name + "_01_transcoder"
+}
+
+func (m MultirangeTranscoderDeclarer) Declare(pkgPath string) (string, error) {
+	funcName := NameMultirangeTranscoderFunc(m.typ)
+	sb := &strings.Builder{}
+	sb.Grow(256)
+
+	// Doc comment
+	sb.WriteString("// ")
+	sb.WriteString(funcName)
+	sb.WriteString(" creates a new pgtype.ValueTranscoder for the Postgres\n")
+	sb.WriteString("// multirange type '")
+	sb.WriteString(m.typ.PgMultirange.Name)
+	sb.WriteString("'.\n")
+
+	// Function signature
+	sb.WriteString("func (tr *typeResolver) ")
+	sb.WriteString(funcName)
+	sb.WriteString("() pgtype.ValueTranscoder {\n\t")
+
+	// newMultirangeValue call
+	sb.WriteString("return tr.newMultirangeValue(")
+	sb.WriteString(strconv.Quote(runtimeTypeName(m.typ.PgMultirange)))
+	sb.WriteString(", ")
+	sb.WriteString(strconv.Quote(runtimeTypeName(m.typ.Range.PgRange)))
+	sb.WriteString(", ")
+	sb.WriteString(strconv.Quote(runtimeTypeName(m.typ.Range.PgRange.Subtype)))
+	sb.WriteString(", ")
+	writeRangeBoundTranscoder(sb, m.typ.Range, pkgPath)
+	sb.WriteString(")\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// MultirangeInitDeclarer declares a new Go function that creates an
+// initialized pgtype.ValueTranscoder for the Postgres multirange type
+// represented by the gotype.MultirangeType to encode query parameters.
+type MultirangeInitDeclarer struct {
+	typ *gotype.MultirangeType
+}
+
+func NewMultirangeInitDeclarer(typ *gotype.MultirangeType) MultirangeInitDeclarer {
+	return MultirangeInitDeclarer{typ}
+}
+
+func (m MultirangeInitDeclarer) DedupeKey() string {
+	return "type_resolver::" + m.typ.Name + "_02_init"
+}
+
+func (m MultirangeInitDeclarer) Declare(pkgPath string) (string, error) {
+	funcName := NameMultirangeInitFunc(m.typ)
+	sb := &strings.Builder{}
+	sb.Grow(256)
+
+	// Doc comment
+	sb.WriteString("// ")
+	sb.WriteString(funcName)
+	sb.WriteString(" creates an initialized pgtype.ValueTranscoder for the\n")
+	sb.WriteString("// Postgres multirange type '")
+	sb.WriteString(m.typ.PgMultirange.Name)
+	sb.WriteString("' to encode query parameters.\n")
+
+	// Function signature
+	sb.WriteString("func (tr *typeResolver) ")
+	sb.WriteString(funcName)
+	sb.WriteString("(v ")
+	sb.WriteString(gotype.QualifyType(m.typ, pkgPath))
+	sb.WriteString(") pgtype.ValueTranscoder {\n\t")
+
+	// Function body
+	sb.WriteString("return tr.setValue(tr.")
+	sb.WriteString(NameMultirangeTranscoderFunc(m.typ))
+	sb.WriteString("(), v)\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+const rangeTypeDecl = `// Range represents a Postgres range type, like tstzrange, with bounds of type
+// T.
+type Range[T any] struct {
+	Lower          T    // lower bound, unset if LowerInfinite or Empty
+	Upper          T    // upper bound, unset if UpperInfinite or Empty
+	LowerInclusive bool // if the range includes the lower bound, like '[1,'
+	UpperInclusive bool // if the range includes the upper bound, like ',2]'
+	LowerInfinite  bool // if the range has no lower bound, like '(,2)'
+	UpperInfinite  bool // if the range has no upper bound, like '(1,)'
+	Empty          bool // if the range contains no points, like 'empty'
+}
+
+// setRangeValue sets the rangeValue v to r.
+func (r Range[T]) setRangeValue(v *rangeValue) error {
+	if r.Empty {
+		v.lowerType, v.upperType = pgtype.Empty, pgtype.Empty
+		return nil
+	}
+	v.lowerType = newRangeBoundType(r.LowerInclusive, r.LowerInfinite)
+	v.upperType = newRangeBoundType(r.UpperInclusive, r.UpperInfinite)
+	if !r.LowerInfinite {
+		if err := setRangeBound(v.lower, r.Lower); err != nil {
+			return fmt.Errorf("set lower bound: %w", err)
+		}
+	}
+	if !r.UpperInfinite {
+		if err := setRangeBound(v.upper, r.Upper); err != nil {
+			return fmt.Errorf("set upper bound: %w", err)
+		}
+	}
+	return nil
+}
+
+// assignRangeValue assigns the rangeValue v to r.
+func (r *Range[T]) assignRangeValue(v *rangeValue) error {
+	*r = Range[T]{
+		LowerInclusive: v.lowerType == pgtype.Inclusive,
+		UpperInclusive: v.upperType == pgtype.Inclusive,
+		LowerInfinite:  v.lowerType == pgtype.Unbounded,
+		UpperInfinite:  v.upperType == pgtype.Unbounded,
+		Empty:          v.lowerType == pgtype.Empty,
+	}
+	if hasRangeBound(v.lowerType) {
+		if err := assignRangeBound(v.lower, &r.Lower); err != nil {
+			return fmt.Errorf("assign lower bound: %w", err)
+		}
+	}
+	if hasRangeBound(v.upperType) {
+		if err := assignRangeBound(v.upper, &r.Upper); err != nil {
+			return fmt.Errorf("assign upper bound: %w", err)
+		}
+	}
+	return nil
+}
+
+// setRangeBound sets the range bound transcoder dst to src. Copies src if dst
+// has the same type, like pgtype.Numeric, because pgtype types don't always
+// support setting a value of the same type.
+func setRangeBound[T any](dst pgtype.ValueTranscoder, src T) error {
+	if d, ok := interface{}(dst).(*T); ok {
+		*d = src
+		return nil
+	}
+	return dst.Set(src)
+}
+
+// assignRangeBound assigns the range bound transcoder src to dst. Copies src if
+// dst has the same type, like pgtype.Numeric, because pgtype types don't
+// always support assigning to a value of the same type.
+func assignRangeBound[T any](src pgtype.ValueTranscoder, dst *T) error {
+	if s, ok := interface{}(src).(*T); ok {
+		*dst = *s
+		return nil
+	}
+	return src.AssignTo(dst)
+}`
+
+// NewRangeTypeDeclarer declares the generic Range type that represents all
+// Postgres range types.
+func NewRangeTypeDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer("range_type::01_range", rangeTypeDecl)
+}
+
+const multirangeTypeDecl = `// Multirange represents a Postgres multirange type, like tstzmultirange, as an
+// ordered list of non-overlapping ranges with bounds of type T.
+type Multirange[T any] []Range[T]
+
+// setMultirangeValue sets the multirangeValue v to m.
+func (m Multirange[T]) setMultirangeValue(v *multirangeValue) error {
+	v.ranges = make([]*rangeValue, len(m))
+	for i, r := range m {
+		rv := v.newRange()
+		if err := rv.Set(r); err != nil {
+			return fmt.Errorf("set range %d: %w", i, err)
+		}
+		v.ranges[i] = rv
+	}
+	return nil
+}
+
+// assignMultirangeValue assigns the multirangeValue v to m.
+func (m *Multirange[T]) assignMultirangeValue(v *multirangeValue) error {
+	rs := make(Multirange[T], len(v.ranges))
+	for i, rv := range v.ranges {
+		if err := rv.AssignTo(&rs[i]); err != nil {
+			return fmt.Errorf("assign range %d: %w", i, err)
+		}
+	}
+	*m = rs
+	return nil
+}`
+
+// NewMultirangeTypeDeclarer declares the generic Multirange type that
+// represents all Postgres multirange types.
+func NewMultirangeTypeDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer("range_type::02_multirange", multirangeTypeDecl)
+}
+
+const rangeValueDecl = `// rangeValue is a pgtype.ValueTranscoder for a Postgres range type. pgtype only
+// supports the builtin range types, so rangeValue transcodes the range bounds
+// with a pgtype.ValueTranscoder for the range subtype.
+type rangeValue struct {
+	typeName  string                        // Postgres range type name
+	newBound  func() pgtype.ValueTranscoder // creates a transcoder for a range bound
+	lower     pgtype.ValueTranscoder
+	upper     pgtype.ValueTranscoder
+	lowerType pgtype.BoundType
+	upperType pgtype.BoundType
+	status    pgtype.Status
+}
+
+// rangeSetter sets a rangeValue without knowing the type of the range bounds.
+// Implemented by Range.
+type rangeSetter interface {
+	setRangeValue(v *rangeValue) error
+}
+
+// rangeAssigner assigns a rangeValue without knowing the type of the range
+// bounds. Implemented by *Range.
+type rangeAssigner interface {
+	assignRangeValue(v *rangeValue) error
+}
+
+// newRangeValue creates a rangeValue for the range type name. Transcodes range
+// bounds with the pgtype.ValueTranscoder registered for subtypeName, falling
+// back to defaultVal.
+func (tr *typeResolver) newRangeValue(name, subtypeName string, defaultVal func() pgtype.ValueTranscoder) *rangeValue {
+	newBound := defaultVal
+	if _, val, ok := tr.findValue(subtypeName); ok {
+		newBound = func() pgtype.ValueTranscoder {
+			return pgtype.NewValue(val).(pgtype.ValueTranscoder)
+		}
+	}
+	return &rangeValue{typeName: name, newBound: newBound, lower: newBound(), upper: newBound()}
+}
+
+// Set implements pgtype.Value.
+func (v *rangeValue) Set(src interface{}) error {
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	s, ok := src.(rangeSetter)
+	if !ok {
+		return fmt.Errorf("cannot convert %v to range %s", src, v.typeName)
+	}
+	v.lower, v.upper = v.newBound(), v.newBound()
+	if err := s.setRangeValue(v); err != nil {
+		return fmt.Errorf("set range %s: %w", v.typeName, err)
+	}
+	v.status = pgtype.Present
+	return nil
+}
+
+// Get implements pgtype.Value.
+func (v *rangeValue) Get() interface{} {
+	switch v.status {
+	case pgtype.Present:
+		return v
+	case pgtype.Null:
+		return nil
+	default:
+		return v.status
+	}
+}
+
+// AssignTo implements pgtype.Value.
+func (v *rangeValue) AssignTo(dst interface{}) error {
+	switch v.status {
+	case pgtype.Present:
+		if a, ok := dst.(rangeAssigner); ok {
+			return a.assignRangeValue(v)
+		}
+		if nextDst, retry := pgtype.GetAssignToDstType(dst); retry {
+			return v.AssignTo(nextDst)
+		}
+		return fmt.Errorf("cannot assign range %s to %T", v.typeName, dst)
+	case pgtype.Null:
+		return pgtype.NullAssignTo(dst)
+	}
+	return fmt.Errorf("cannot decode %#v into %T", v, dst)
+}
+
+// DecodeText implements pgtype.TextDecoder.
+func (v *rangeValue) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	utr, err := pgtype.ParseUntypedTextRange(string(src))
+	if err != nil {
+		return fmt.Errorf("decode range %s: %w", v.typeName, err)
+	}
+	v.lower, v.upper = v.newBound(), v.newBound()
+	v.lowerType, v.upperType = utr.LowerType, utr.UpperType
+	if hasRangeBound(utr.LowerType) {
+		if err := v.lower.DecodeText(ci, []byte(utr.Lower)); err != nil {
+			return fmt.Errorf("decode range %s lower bound: %w", v.typeName, err)
+		}
+	}
+	if hasRangeBound(utr.UpperType) {
+		if err := v.upper.DecodeText(ci, []byte(utr.Upper)); err != nil {
+			return fmt.Errorf("decode range %s upper bound: %w", v.typeName, err)
+		}
+	}
+	v.status = pgtype.Present
+	return nil
+}
+
+// DecodeBinary implements pgtype.BinaryDecoder.
+func (v *rangeValue) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	ubr, err := pgtype.ParseUntypedBinaryRange(src)
+	if err != nil {
+		return fmt.Errorf("decode range %s: %w", v.typeName, err)
+	}
+	v.lower, v.upper = v.newBound(), v.newBound()
+	v.lowerType, v.upperType = ubr.LowerType, ubr.UpperType
+	if hasRangeBound(ubr.LowerType) {
+		if err := v.lower.DecodeBinary(ci, ubr.Lower); err != nil {
+			return fmt.Errorf("decode range %s lower bound: %w", v.typeName, err)
+		}
+	}
+	if hasRangeBound(ubr.UpperType) {
+		if err := v.upper.DecodeBinary(ci, ubr.Upper); err != nil {
+			return fmt.Errorf("decode range %s upper bound: %w", v.typeName, err)
+		}
+	}
+	v.status = pgtype.Present
+	return nil
+}
+
+// EncodeText implements pgtype.TextEncoder.
+func (v *rangeValue) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch v.status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, fmt.Errorf("cannot encode undefined range %s", v.typeName)
+	}
+	if v.lowerType == pgtype.Empty {
+		return append(buf, "empty"...), nil
+	}
+	if v.lowerType == pgtype.Inclusive {
+		buf = append(buf, '[')
+	} else {
+		buf = append(buf, '(')
+	}
+	if hasRangeBound(v.lowerType) {
+		bound, err := v.lower.EncodeText(ci, make([]byte, 0, 32))
+		if err != nil {
+			return nil, fmt.Errorf("encode range %s lower bound: %w", v.typeName, err)
+		}
+		if bound == nil {
+			return nil, fmt.Errorf("encode range %s: lower bound cannot be null", v.typeName)
+		}
+		buf = appendRangeBound(buf, bound)
+	}
+	buf = append(buf, ',')
+	if hasRangeBound(v.upperType) {
+		bound, err := v.upper.EncodeText(ci, make([]byte, 0, 32))
+		if err != nil {
+			return nil, fmt.Errorf("encode range %s upper bound: %w", v.typeName, err)
+		}
+		if bound == nil {
+			return nil, fmt.Errorf("encode range %s: upper bound cannot be null", v.typeName)
+		}
+		buf = appendRangeBound(buf, bound)
+	}
+	if v.upperType == pgtype.Inclusive {
+		buf = append(buf, ']')
+	} else {
+		buf = append(buf, ')')
+	}
+	return buf, nil
+}
+
+// EncodeBinary implements pgtype.BinaryEncoder.
+func (v *rangeValue) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch v.status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, fmt.Errorf("cannot encode undefined range %s", v.typeName)
+	}
+	// Range flags from src/include/utils/rangetypes.h in Postgres.
+	if v.lowerType == pgtype.Empty {
+		return append(buf, 0x01), nil
+	}
+	var flags byte
+	switch v.lowerType {
+	case pgtype.Inclusive:
+		flags |= 0x02
+	case pgtype.Unbounded:
+		flags |= 0x08
+	}
+	switch v.upperType {
+	case pgtype.Inclusive:
+		flags |= 0x04
+	case pgtype.Unbounded:
+		flags |= 0x10
+	}
+	buf = append(buf, flags)
+	var err error
+	if hasRangeBound(v.lowerType) {
+		if buf, err = appendRangeBoundBinary(ci, buf, v.lower); err != nil {
+			return nil, fmt.Errorf("encode range %s lower bound: %w", v.typeName, err)
+		}
+	}
+	if hasRangeBound(v.upperType) {
+		if buf, err = appendRangeBoundBinary(ci, buf, v.upper); err != nil {
+			return nil, fmt.Errorf("encode range %s upper bound: %w", v.typeName, err)
+		}
+	}
+	return buf, nil
+}
+
+// PreferredParamFormat implements pgtype.ParamFormatPreferrer. The text format
+// doesn't require the OID of the range type, which pggen might not know.
+func (v *rangeValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }
+
+// hasRangeBound returns true if a range bound of type bt has a value.
+func hasRangeBound(bt pgtype.BoundType) bool {
+	return bt == pgtype.Inclusive || bt == pgtype.Exclusive
+}
+
+// newRangeBoundType returns the pgtype.BoundType for a non-empty range bound.
+func newRangeBoundType(inclusive, infinite bool) pgtype.BoundType {
+	switch {
+	case infinite:
+		return pgtype.Unbounded
+	case inclusive:
+		return pgtype.Inclusive
+	default:
+		return pgtype.Exclusive
+	}
+}
+
+// appendRangeBound appends the text encoded range bound to buf, quoting the
+// bound if it's empty or contains special characters.
+func appendRangeBound(buf, bound []byte) []byte {
+	needsQuote := len(bound) == 0
+	for _, b := range bound {
+		switch b {
+		case ',', '(', ')', '[', ']', '"', '\\', ' ', '\t', '\n', '\r':
+			needsQuote = true
+		}
+	}
+	if !needsQuote {
+		return append(buf, bound...)
+	}
+	buf = append(buf, '"')
+	for _, b := range bound {
+		if b == '"' || b == '\\' {
+			buf = append(buf, '\\')
+		}
+		buf = append(buf, b)
+	}
+	return append(buf, '"')
+}
+
+// appendRangeBoundBinary appends the binary encoded range bound to buf,
+// prefixed by the length of the bound.
+func appendRangeBoundBinary(ci *pgtype.ConnInfo, buf []byte, bound pgtype.ValueTranscoder) ([]byte, error) {
+	start := len(buf)
+	buf = append(buf, 0, 0, 0, 0) // length placeholder
+	buf, err := bound.EncodeBinary(ci, buf)
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, fmt.Errorf("range bound cannot be null")
+	}
+	putRangeUint32(buf[start:], uint32(len(buf)-start-4))
+	return buf, nil
+}
+
+// putRangeUint32 writes n into the first 4 bytes of buf in network byte order.
+func putRangeUint32(buf []byte, n uint32) {
+	buf[0], buf[1], buf[2], buf[3] = byte(n>>24), byte(n>>16), byte(n>>8), byte(n)
+}
+
+// readRangeUint32 reads an uint32 from the first 4 bytes of buf in network
+// byte order.
+func readRangeUint32(buf []byte) uint32 {
+	return uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+}`
+
+// NewRangeValueDeclarer declares the pgtype.ValueTranscoder for all Postgres
+// range types.
+func NewRangeValueDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer("type_resolver::02_range", rangeValueDecl)
+}
+
+const multirangeValueDecl = `// multirangeValue is a pgtype.ValueTranscoder for a Postgres multirange type.
+// Transcodes each range with rangeValue.
+type multirangeValue struct {
+	typeName string             // Postgres multirange type name
+	newRange func() *rangeValue // creates a transcoder for a range
+	ranges   []*rangeValue
+	status   pgtype.Status
+}
+
+// multirangeSetter sets a multirangeValue without knowing the type of the
+// range bounds. Implemented by Multirange.
+type multirangeSetter interface {
+	setMultirangeValue(v *multirangeValue) error
+}
+
+// multirangeAssigner assigns a multirangeValue without knowing the type of the
+// range bounds. Implemented by *Multirange.
+type multirangeAssigner interface {
+	assignMultirangeValue(v *multirangeValue) error
+}
+
+// newMultirangeValue creates a multirangeValue for the multirange type name
+// with ranges of type rangeName.
+func (tr *typeResolver) newMultirangeValue(name, rangeName, subtypeName string, defaultVal func() pgtype.ValueTranscoder) *multirangeValue {
+	newRange := func() *rangeValue {
+		return tr.newRangeValue(rangeName, subtypeName, defaultVal)
+	}
+	return &multirangeValue{typeName: name, newRange: newRange}
+}
+
+// Set implements pgtype.Value.
+func (v *multirangeValue) Set(src interface{}) error {
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	s, ok := src.(multirangeSetter)
+	if !ok {
+		return fmt.Errorf("cannot convert %v to multirange %s", src, v.typeName)
+	}
+	if err := s.setMultirangeValue(v); err != nil {
+		return fmt.Errorf("set multirange %s: %w", v.typeName, err)
+	}
+	v.status = pgtype.Present
+	return nil
+}
+
+// Get implements pgtype.Value.
+func (v *multirangeValue) Get() interface{} {
+	switch v.status {
+	case pgtype.Present:
+		return v
+	case pgtype.Null:
+		return nil
+	default:
+		return v.status
+	}
+}
+
+// AssignTo implements pgtype.Value.
+func (v *multirangeValue) AssignTo(dst interface{}) error {
+	switch v.status {
+	case pgtype.Present:
+		if a, ok := dst.(multirangeAssigner); ok {
+			return a.assignMultirangeValue(v)
+		}
+		return fmt.Errorf("cannot assign multirange %s to %T", v.typeName, dst)
+	case pgtype.Null:
+		return pgtype.NullAssignTo(dst)
+	}
+	return fmt.Errorf("cannot decode %#v into %T", v, dst)
+}
+
+// DecodeText implements pgtype.TextDecoder.
+func (v *multirangeValue) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	utmr, err := pgtype.ParseUntypedTextMultirange(string(src))
+	if err != nil {
+		return fmt.Errorf("decode multirange %s: %w", v.typeName, err)
+	}
+	ranges := make([]*rangeValue, len(utmr.Elements))
+	for i, elem := range utmr.Elements {
+		ranges[i] = v.newRange()
+		if err := ranges[i].DecodeText(ci, []byte(elem)); err != nil {
+			return fmt.Errorf("decode multirange %s: %w", v.typeName, err)
+		}
+	}
+	v.ranges = ranges
+	v.status = pgtype.Present
+	return nil
+}
+
+// DecodeBinary implements pgtype.BinaryDecoder.
+func (v *multirangeValue) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+	if len(src) < 4 {
+		return fmt.Errorf("decode multirange %s: too few bytes for range count", v.typeName)
+	}
+	ranges := make([]*rangeValue, readRangeUint32(src))
+	rp := 4
+	for i := range ranges {
+		if len(src[rp:]) < 4 {
+			return fmt.Errorf("decode multirange %s: too few bytes for range length", v.typeName)
+		}
+		n := int(readRangeUint32(src[rp:]))
+		rp += 4
+		if len(src[rp:]) < n {
+			return fmt.Errorf("decode multirange %s: too few bytes for range", v.typeName)
+		}
+		ranges[i] = v.newRange()
+		if err := ranges[i].DecodeBinary(ci, src[rp:rp+n]); err != nil {
+			return fmt.Errorf("decode multirange %s: %w", v.typeName, err)
+		}
+		rp += n
+	}
+	v.ranges = ranges
+	v.status = pgtype.Present
+	return nil
+}
+
+// EncodeText implements pgtype.TextEncoder.
+func (v *multirangeValue) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch v.status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, fmt.Errorf("cannot encode undefined multirange %s", v.typeName)
+	}
+	buf = append(buf, '{')
+	for i, rv := range v.ranges {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = rv.EncodeText(ci, buf); err != nil {
+			return nil, fmt.Errorf("encode multirange %s: %w", v.typeName, err)
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// EncodeBinary implements pgtype.BinaryEncoder.
+func (v *multirangeValue) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch v.status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, fmt.Errorf("cannot encode undefined multirange %s", v.typeName)
+	}
+	buf = append(buf, 0, 0, 0, 0)
+	putRangeUint32(buf[len(buf)-4:], uint32(len(v.ranges)))
+	for _, rv := range v.ranges {
+		start := len(buf)
+		buf = append(buf, 0, 0, 0, 0) // length placeholder
+		var err error
+		if buf, err = rv.EncodeBinary(ci, buf); err != nil {
+			return nil, fmt.Errorf("encode multirange %s: %w", v.typeName, err)
+		}
+		putRangeUint32(buf[start:], uint32(len(buf)-start-4))
+	}
+	return buf, nil
+}
+
+// PreferredParamFormat implements pgtype.ParamFormatPreferrer. The text format
+// doesn't require the OID of the multirange type, which pggen might not know.
+func (v *multirangeValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }`
+
+// NewMultirangeValueDeclarer declares the pgtype.ValueTranscoder for all
+// Postgres multirange types.
+func NewMultirangeValueDeclarer() ConstantDeclarer {
+	return NewConstantDeclarer("type_resolver::03_multirange", multirangeValueDecl)
+}
@@ -0,0 +1,110 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RowTypeDeclarer declares the struct for a row-type pragma, like
+// row-type=Author. Every query with the same row type returns the struct, so
+// the columns of each query must match.
+type RowTypeDeclarer struct {
+	name  string            // name of the Go struct, like "Author"
+	query string            // name of the first query with the row type
+	outs  []TemplatedColumn // non-void output columns of the query
+}
+
+func NewRowTypeDeclarer(name, query string, outs []TemplatedColumn) RowTypeDeclarer {
+	return RowTypeDeclarer{name: name, query: query, outs: outs}
+}
+
+func (r RowTypeDeclarer) DedupeKey() string {
+	return "row_type::" + r.name
+}
+
+func (r RowTypeDeclarer) Declare(string) (string, error) {
+	sb := &strings.Builder{}
+	writeRowStruct(sb, r.name, r.outs)
+	doc := "// " + r.name + " is the row type of queries with the pragma row-type=" + r.name + ".\n"
+	return doc + strings.TrimPrefix(sb.String(), "\n\n"), nil
+}
+
+// checkSameColumns returns an error if the output columns of other differ from
+// r in name, Go type, or nullability.
+func (r RowTypeDeclarer) checkSameColumns(other RowTypeDeclarer) error {
+	if len(r.outs) != len(other.outs) {
+		return fmt.Errorf("row-type %s: query %s has %d output columns but query %s has %d",
+			r.name, r.query, len(r.outs), other.query, len(other.outs))
+	}
+	for i, want := range r.outs {
+		got := other.outs[i]
+		switch {
+		case want.PgName != got.PgName || want.UpperName != got.UpperName:
+			return fmt.Errorf("row-type %s: query %s has column %s but query %s has column %s at the same position",
+				r.name, r.query, want.PgName, other.query, got.PgName)
+		case want.QualType != got.QualType:
+			return fmt.Errorf("row-type %s: column %s has type %s in query %s but type %s in query %s",
+				r.name, want.PgName, want.QualType, r.query, got.QualType, other.query)
+		case want.Nullable != got.Nullable:
+			return fmt.Errorf("row-type %s: column %s is %s in query %s but %s in query %s",
+				r.name, want.PgName, nullability(want.Nullable), r.query, nullability(got.Nullable), other.query)
+		}
+	}
+	return nil
+}
+
+// addRowTypeDeclarer adds decl to decls unless decls already has the row type,
+// in which case the columns of both must match.
+func addRowTypeDeclarer(decls DeclarerSet, decl RowTypeDeclarer) error {
+	prev, ok := decls[decl.DedupeKey()].(RowTypeDeclarer)
+	if !ok {
+		decls.AddAll(decl)
+		return nil
+	}
+	return prev.checkSameColumns(decl)
+}
+
+// checkRowTypeNames returns an error if the name of a row type in decls
+// collides with another Go type declared in the generated package, like the
+// struct for a composite type or the row struct of a query in files.
+func checkRowTypeNames(decls DeclarerSet, files []TemplatedFile) error {
+	taken := map[string]string{
+		"Querier":   "the Querier interface",
+		"DBQuerier": "the DBQuerier struct",
+	}
+	for _, decl := range decls {
+		switch decl := decl.(type) {
+		case CompositeTypeDeclarer:
+			taken[decl.comp.Name] = "the Go type for composite type " + decl.comp.PgComposite.Name
+		case EnumTypeDeclarer:
+			taken[decl.enum.Name] = "the Go type for enum type " + decl.enum.PgEnum.Name
+		}
+	}
+	for _, file := range files {
+		for _, query := range file.Queries {
+			if query.RowType == "" {
+				taken[query.rowName()] = "the row struct of query " + query.Name
+			}
+			if query.GroupBy != "" {
+				taken[query.childRowName()] = "the child row struct of query " + query.Name
+			}
+		}
+	}
+	for _, decl := range decls.ListAll() {
+		rowDecl, ok := decl.(RowTypeDeclarer)
+		if !ok {
+			continue
+		}
+		if other, ok := taken[rowDecl.name]; ok {
+			return fmt.Errorf("row-type %s: name collides with %s", rowDecl.name, other)
+		}
+	}
+	return nil
+}
+
+func nullability(nullable bool) string {
+	if nullable {
+		return "nullable"
+	}
+	return "not null"
+}
@@ -0,0 +1,532 @@
+package golang
+
+import (
+	"strings"
+
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+)
+
+// FindDeclarersDatabaseSQL finds all necessary Declarers for types that appear
+// in the input parameters or output rows when generating code for
+// database/sql. Enums and composite types implement sql.Scanner and
+// driver.Valuer so database/sql can use the Go types directly. Composite types
+// and arrays need the text codec.
+func FindDeclarersDatabaseSQL(typ gotype.Type) DeclarerSet {
+	decls := NewDeclarerSet()
+	findDeclsDatabaseSQLHelper(typ, decls)
+	return decls
+}
+
+func findDeclsDatabaseSQLHelper(typ gotype.Type, decls DeclarerSet) {
+	switch typ := gotype.UnwrapNestedType(typ).(type) {
+	case *gotype.EnumType:
+		decls.AddAll(
+			NewEnumTypeDeclarer(typ),
+			NewEnumValuerDeclarer(typ),
+		)
+	case *gotype.CompositeType:
+		decls.AddAll(
+			NewCompositeTypeDeclarer(typ),
+			NewCompositeValuerDeclarer(typ),
+			textCodecDeclarer,
+		)
+		for _, childType := range typ.FieldTypes {
+			findDeclsDatabaseSQLHelper(childType, decls)
+		}
+	case *gotype.ArrayType:
+		if !gotype.IsByteSlice(typ) {
+			decls.AddAll(textCodecDeclarer)
+		}
+		findDeclsDatabaseSQLHelper(typ.Elem, decls)
+	}
+}
+
+// EnumValuerDeclarer declares the sql.Scanner and driver.Valuer methods for
+// the Go string type of a Postgres enum.
+type EnumValuerDeclarer struct {
+	enum *gotype.EnumType
+}
+
+func NewEnumValuerDeclarer(enum *gotype.EnumType) EnumValuerDeclarer {
+	return EnumValuerDeclarer{enum: enum}
+}
+
+func (e EnumValuerDeclarer) DedupeKey() string {
+	return "enum_type::" + e.enum.Name + "::valuer"
+}
+
+func (e EnumValuerDeclarer) Declare(string) (string, error) {
+	sb := &strings.Builder{}
+	name := e.enum.Name
+	dispatcher := strings.ToLower(name)[:1]
+	// Scanner
+	sb.WriteString("// Scan implements sql.Scanner.\n")
+	sb.WriteString("func (" + dispatcher + " *" + name + ") Scan(src any) error {\n")
+	sb.WriteString("\tswitch src := src.(type) {\n")
+	sb.WriteString("\tcase string:\n")
+	sb.WriteString("\t\t*" + dispatcher + " = " + name + "(src)\n")
+	sb.WriteString("\tcase []byte:\n")
+	sb.WriteString("\t\t*" + dispatcher + " = " + name + "(src)\n")
+	sb.WriteString("\tdefault:\n")
+	sb.WriteString("\t\treturn fmt.Errorf(\"scan " + name + ": unsupported source type %T\", src)\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn nil\n")
+	sb.WriteString("}\n\n")
+	// Valuer
+	sb.WriteString("// Value implements driver.Valuer.\n")
+	sb.WriteString("func (" + dispatcher + " " + name + ") Value() (driver.Value, error) { return string(" + dispatcher + "), nil }")
+	return sb.String(), nil
+}
+
+// CompositeValuerDeclarer declares the sql.Scanner and driver.Valuer methods
+// for the Go struct of a Postgres composite type. The methods use the Postgres
+// text format of composite types, like (1,"foo").
+type CompositeValuerDeclarer struct {
+	comp *gotype.CompositeType
+}
+
+func NewCompositeValuerDeclarer(comp *gotype.CompositeType) CompositeValuerDeclarer {
+	return CompositeValuerDeclarer{comp: comp}
+}
+
+func (c CompositeValuerDeclarer) DedupeKey() string {
+	return "composite::" + c.comp.Name + "::valuer"
+}
+
+func (c CompositeValuerDeclarer) Declare(string) (string, error) {
+	sb := &strings.Builder{}
+	name := c.comp.Name
+	dispatcher := strings.ToLower(name)[:1]
+	// Scanner
+	sb.WriteString("// Scan implements sql.Scanner by parsing the Postgres text format of the\n")
+	sb.WriteString("// composite type.\n")
+	sb.WriteString("func (" + dispatcher + " *" + name + ") Scan(src any) error {\n")
+	sb.WriteString("\treturn scanComposite(src")
+	for _, field := range c.comp.FieldNames {
+		sb.WriteString(", &" + dispatcher + "." + field)
+	}
+	sb.WriteString(")\n")
+	sb.WriteString("}\n\n")
+	// Valuer
+	sb.WriteString("// Value implements driver.Valuer by formatting the composite type in the\n")
+	sb.WriteString("// Postgres text format.\n")
+	sb.WriteString("func (" + dispatcher + " " + name + ") Value() (driver.Value, error) {\n")
+	sb.WriteString("\treturn formatComposite(")
+	for i, field := range c.comp.FieldNames {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(dispatcher + "." + field)
+	}
+	sb.WriteString(")\n")
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// textCodecImports are the packages used by textCodecDecl, in addition to
+// the packages that every file imports.
+//
+//nolint:gochecknoglobals
+var textCodecImports = []string{
+	"database/sql/driver",
+	"encoding/hex",
+	"reflect",
+	"strconv",
+	"strings",
+	"time",
+}
+
+// textCodecDeclarer declares the helpers to encode and decode composite types
+// and arrays in the Postgres text format for database/sql. database/sql only
+// supports scalar values, so Postgres drivers return composite types and
+// arrays in the text format.
+//
+//nolint:gochecknoglobals
+var textCodecDeclarer = NewConstantDeclarer(textCodecKey, textCodecDecl)
+
+const textCodecKey = "text_codec"
+
+const textCodecDecl = `// textArray is a slice that implements sql.Scanner and driver.Valuer with the
+// Postgres text format of arrays, like {1,2,NULL}.
+type textArray[T any] []T
+
+// newTextArray wraps a slice to encode it as a Postgres array.
+func newTextArray[T any](s []T) textArray[T] { return s }
+
+// scanTextArray wraps a pointer to a slice to decode a Postgres array into it.
+func scanTextArray[T any](s *[]T) *textArray[T] { return (*textArray[T])(s) }
+
+// Scan implements sql.Scanner.
+func (a *textArray[T]) Scan(src any) error {
+	s, err := srcText(src)
+	if err != nil {
+		return err
+	}
+	return scanTextValue((*[]T)(a), s)
+}
+
+// Value implements driver.Valuer.
+func (a textArray[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return formatArrayText(reflect.ValueOf([]T(a)))
+}
+
+// scanComposite decodes the Postgres text format of a composite type, like
+// (1,"foo"), into the pointers to the composite fields.
+func scanComposite(src any, fields ...any) error {
+	s, err := srcText(src)
+	if err != nil || s == nil {
+		return err
+	}
+	texts, err := parseCompositeText(*s)
+	if err != nil {
+		return err
+	}
+	if len(texts) != len(fields) {
+		return fmt.Errorf("scan composite %q: got %d fields, want %d", *s, len(texts), len(fields))
+	}
+	for i, text := range texts {
+		if err := scanTextValue(fields[i], text); err != nil {
+			return fmt.Errorf("scan composite field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// formatComposite encodes the composite fields in the Postgres text format of
+// a composite type, like (1,"foo").
+func formatComposite(fields ...any) (driver.Value, error) {
+	sb := &strings.Builder{}
+	sb.WriteByte('(')
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		text, err := formatTextValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("format composite field %d: %w", i, err)
+		}
+		if text != nil {
+			writeQuotedText(sb, *text)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String(), nil
+}
+
+// srcText converts a value from the database driver to text. Returns nil for
+// NULL.
+func srcText(src any) (*string, error) {
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &src, nil
+	case []byte:
+		s := string(src)
+		return &s, nil
+	default:
+		return nil, fmt.Errorf("unsupported source type %T; want text", src)
+	}
+}
+
+// scanTextValue decodes the Postgres text format of a value into dst, a
+// pointer. A nil text is NULL.
+func scanTextValue(dst any, text *string) error {
+	if scanner, ok := dst.(sql.Scanner); ok {
+		if text == nil {
+			return scanner.Scan(nil)
+		}
+		return scanner.Scan(*text)
+	}
+	v := reflect.ValueOf(dst).Elem()
+	if text == nil {
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Slice {
+			return fmt.Errorf("cannot scan NULL into %s", v.Type())
+		}
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		return scanTextValue(v.Interface(), text)
+	}
+	s := *text
+	if _, ok := dst.(*time.Time); ok {
+		t, err := parseTimeText(s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		v.SetBool(s == "t" || s == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			bs := []byte(s)
+			if strings.HasPrefix(s, "\\x") {
+				b, err := hex.DecodeString(s[2:])
+				if err != nil {
+					return err
+				}
+				bs = b
+			}
+			v.SetBytes(bs)
+			return nil
+		}
+		texts, err := parseArrayText(s)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), len(texts), len(texts))
+		for i, text := range texts {
+			if err := scanTextValue(slice.Index(i).Addr().Interface(), text); err != nil {
+				return fmt.Errorf("scan array element %d: %w", i, err)
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported scan type %s", v.Type())
+	}
+	return nil
+}
+
+// formatTextValue encodes v in the Postgres text format. Returns nil for NULL.
+func formatTextValue(v any) (*string, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return nil, nil
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		if _, isValuer := dv.(driver.Valuer); isValuer {
+			return nil, fmt.Errorf("value of %T is a driver.Valuer", v)
+		}
+		return formatTextValue(dv)
+	}
+	var s string
+	switch v := v.(type) {
+	case time.Time:
+		s = v.Format("2006-01-02 15:04:05.999999999Z07:00")
+	case []byte:
+		s = "\\x" + hex.EncodeToString(v)
+	default:
+		switch rv.Kind() {
+		case reflect.Pointer:
+			return formatTextValue(rv.Elem().Interface())
+		case reflect.String:
+			s = rv.String()
+		case reflect.Bool:
+			s = strconv.FormatBool(rv.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = strconv.FormatUint(rv.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			s = strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+		case reflect.Slice:
+			if rv.IsNil() {
+				return nil, nil
+			}
+			arr, err := formatArrayText(rv)
+			if err != nil {
+				return nil, err
+			}
+			s = arr.(string)
+		default:
+			return nil, fmt.Errorf("unsupported value type %T", v)
+		}
+	}
+	return &s, nil
+}
+
+// formatArrayText encodes a slice in the Postgres text format of arrays.
+// Nested slices, other than []byte, are multi-dimensional arrays.
+func formatArrayText(rv reflect.Value) (driver.Value, error) {
+	sb := &strings.Builder{}
+	sb.WriteByte('{')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Slice && elem.Type().Elem().Kind() != reflect.Uint8 {
+			sub, err := formatArrayText(elem)
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(sub.(string))
+			continue
+		}
+		text, err := formatTextValue(elem.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("format array element %d: %w", i, err)
+		}
+		if text == nil {
+			sb.WriteString("NULL")
+			continue
+		}
+		writeQuotedText(sb, *text)
+	}
+	sb.WriteByte('}')
+	return sb.String(), nil
+}
+
+// writeQuotedText writes s as a double-quoted composite field or array
+// element, escaping quotes and backslashes.
+func writeQuotedText(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(s[i])
+	}
+	sb.WriteByte('"')
+}
+
+// parseCompositeText splits the Postgres text format of a composite type, like
+// (1,"foo",), into the text of each field. A nil field is NULL.
+func parseCompositeText(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("invalid composite text %q", s)
+	}
+	body := s[1 : len(s)-1]
+	var fields []*string
+	sb := &strings.Builder{}
+	isNull, inQuotes := true, false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body):
+			i++
+			sb.WriteByte(body[i])
+			isNull = false
+		case c == '"' && inQuotes && i+1 < len(body) && body[i+1] == '"':
+			i++
+			sb.WriteByte('"')
+		case c == '"':
+			inQuotes = !inQuotes
+			isNull = false
+		case c == ',' && !inQuotes:
+			fields = append(fields, nullableText(sb.String(), isNull))
+			sb.Reset()
+			isNull = true
+		default:
+			sb.WriteByte(c)
+			isNull = false
+		}
+	}
+	return append(fields, nullableText(sb.String(), isNull)), nil
+}
+
+// parseArrayText splits the Postgres text format of an array, like
+// {1,"foo",NULL}, into the text of each element. A nil element is NULL. The
+// elements of a multi-dimensional array are the text of the sub-arrays.
+func parseArrayText(s string) ([]*string, error) {
+	if strings.HasPrefix(s, "[") {
+		// Skip the dimension decoration, like [0:1]={1,2}.
+		if _, after, ok := strings.Cut(s, "="); ok {
+			s = after
+		}
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("invalid array text %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if body == "" {
+		return []*string{}, nil
+	}
+	var elems []*string
+	sb := &strings.Builder{}
+	depth, inQuotes, isQuoted := 0, false, false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if depth > 0 {
+			// Keep the text of sub-arrays as is.
+			sb.WriteByte(c)
+			switch {
+			case c == '\\' && i+1 < len(body):
+				i++
+				sb.WriteByte(body[i])
+			case c == '"':
+				inQuotes = !inQuotes
+			case c == '{' && !inQuotes:
+				depth++
+			case c == '}' && !inQuotes:
+				depth--
+			}
+			continue
+		}
+		switch {
+		case c == '\\' && i+1 < len(body):
+			i++
+			sb.WriteByte(body[i])
+		case c == '"':
+			inQuotes = !inQuotes
+			isQuoted = true
+		case c == '{' && !inQuotes:
+			depth++
+			sb.WriteByte(c)
+		case c == ',' && !inQuotes:
+			elems = append(elems, nullableText(sb.String(), !isQuoted && sb.String() == "NULL"))
+			sb.Reset()
+			isQuoted = false
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return append(elems, nullableText(sb.String(), !isQuoted && sb.String() == "NULL")), nil
+}
+
+func nullableText(s string, isNull bool) *string {
+	if isNull {
+		return nil
+	}
+	return &s
+}
+
+// parseTimeText parses the Postgres text format of a date, timestamp, or
+// timestamptz.
+func parseTimeText(s string) (time.Time, error) {
+	layouts := []string{
+		"2006-01-02 15:04:05.999999999Z07:00:00",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999Z07",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time text %q", s)
+}`
@@ -31,6 +31,7 @@ func TestDeclarers(t *testing.T) {
 		FieldNames:  []string{"Foo", "BarBaz"},
 		FieldTypes:  []gotype.Type{gotype.Int16, gotype.PgText},
 	}
+	goTypeTstzrange := gotype.NewRangeType(pg.Tstzrange, gotype.Time, caser)
 	tests := []struct {
 		name    string
 		typ     gotype.Type
@@ -72,6 +73,31 @@ func TestDeclarers(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "composite_schema",
+			pkgPath: "example.com/foo",
+			typ: &gotype.CompositeType{
+				PgComposite: pg.CompositeType{
+					Name:        "invoice",
+					Schema:      "billing",
+					ColumnNames: []string{"id", "status"},
+					ColumnTypes: []pg.Type{
+						pg.Int8,
+						pg.EnumType{Name: "status", Schema: "billing", Labels: []string{"paid", "void"}},
+					},
+				},
+				Name:       "Invoice",
+				FieldNames: []string{"ID", "Status"},
+				FieldTypes: []gotype.Type{
+					gotype.Int,
+					gotype.NewEnumType(
+						emptyPkgPath,
+						pg.EnumType{Name: "status", Schema: "billing", Labels: []string{"paid", "void"}},
+						caser,
+					),
+				},
+			},
+		},
 		{
 			name:    "composite_nested",
 			pkgPath: "example.com/foo",
@@ -111,6 +137,81 @@ func TestDeclarers(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "range",
+			pkgPath: "example.com/foo",
+			typ:     goTypeTstzrange,
+		},
+		{
+			name:    "range_enum",
+			pkgPath: "example.com/foo",
+			typ: gotype.NewRangeType(
+				pg.RangeType{
+					Name:    "device_range",
+					Subtype: pg.EnumType{Name: "device_type", Labels: []string{"ios", "mobile"}},
+				},
+				gotype.NewEnumType(
+					emptyPkgPath,
+					pg.EnumType{Name: "device_type", Labels: []string{"ios", "mobile"}},
+					caser,
+				),
+				caser,
+			),
+		},
+		{
+			name:    "multirange",
+			pkgPath: "example.com/foo",
+			typ:     gotype.NewMultirangeType(pg.Tstzmultirange, goTypeTstzrange, caser),
+		},
+		{
+			name:    "array_2d",
+			pkgPath: "example.com/foo",
+			typ: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_int4", Elem: pg.Int4, Dimensions: 2},
+				Elem:    gotype.Int32Slice,
+			},
+		},
+		{
+			name:    "array_enum",
+			pkgPath: "example.com/foo",
+			typ: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_device_type", Elem: pg.EnumType{Name: "device_type"}},
+				Elem: gotype.NewEnumType(
+					emptyPkgPath,
+					pg.EnumType{Name: "device_type", Labels: []string{"ios", "mobile"}},
+					caser,
+				),
+			},
+		},
+		{
+			name:    "array_2d_enum",
+			pkgPath: "example.com/foo",
+			typ: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_device_type", Elem: pg.EnumType{Name: "device_type"}, Dimensions: 2},
+				Elem: &gotype.ArrayType{
+					PgArray: pg.ArrayType{Name: "_device_type", Elem: pg.EnumType{Name: "device_type"}, Dimensions: 1},
+					Elem: gotype.NewEnumType(
+						emptyPkgPath,
+						pg.EnumType{Name: "device_type", Labels: []string{"ios", "mobile"}},
+						caser,
+					),
+				},
+			},
+		},
+		{
+			name:    "array_3d_composite",
+			pkgPath: "example.com/foo",
+			typ: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_some_table", Elem: pgTypeSomeTable, Dimensions: 3},
+				Elem: &gotype.ArrayType{
+					PgArray: pg.ArrayType{Name: "_some_table", Elem: pgTypeSomeTable, Dimensions: 2},
+					Elem: &gotype.ArrayType{
+						PgArray: pg.ArrayType{Name: "_some_table", Elem: pgTypeSomeTable, Dimensions: 1},
+						Elem:    &gotype.ImportType{PkgPath: "example.com/foo", Type: goTypeSomeTable},
+					},
+				},
+			},
+		},
 		{
 			name: "enum_escaping",
 			typ: gotype.NewEnumType(
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strings"
 	"text/template"
 
 	"github.com/jschaf/pggen/internal/casing"
 	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
 )
 
 // GenerateOptions are options to control generated Go output.
@@ -18,11 +20,75 @@ type GenerateOptions struct {
 	// A map of lowercase acronyms to the upper case equivalent, like:
 	// "api" => "API".
 	Acronyms map[string]string
-	// A map from a Postgres type name to a fully qualified Go type.
+	// A map from a Postgres type name to a fully qualified Go type. The
+	// Postgres type name may be schema-qualified, like "billing.status". A
+	// Postgres type name with a "?" suffix, like "int8?", only applies to
+	// nullable values.
 	TypeOverrides map[string]string
+	// Named sets of Go types for builtin Postgres types, like "stdlib" or
+	// "google-uuid". Later presets take precedence over earlier presets, and
+	// TypeOverrides take precedence over all presets.
+	TypePresets []string
+	// A map from a table column, like "users.settings", a query output column,
+	// or a query param, like "FindUser.settings", to a fully qualified Go
+	// type. Takes precedence over TypeOverrides.
+	ColumnTypeOverrides map[string]string
+	// How to name Go types for Postgres types outside the public schema.
+	// Defaults to SchemaNamingCollision if empty.
+	SchemaNaming SchemaNaming
+	// If true, use nullable Go types for all composite type fields, even if
+	// the field is backed by a NOT NULL table column.
+	NullableCompositeFields bool
+	// The major version of pgx for the generated code, either 4 or 5. Defaults
+	// to 4 if 0. Only used for DriverPgx.
+	PgxVersion int
+	// The Go database API for the generated code. Defaults to DriverPgx if
+	// empty.
+	Driver Driver
+	// How to choose the Go type for nullable builtin Postgres types, like a
+	// nullable varchar column. Defaults to NullableStyleDefault if empty.
+	NullableStyle NullableStyle
+	// Nullable styles by Postgres type name, like "varchar" => "pointer".
+	// Overrides NullableStyle for the type.
+	NullableTypeStyles map[string]NullableStyle
 	// How many params to inline when calling querier methods.
 	// Set to 0 to always create a struct for params.
 	InlineParamCount int
+	// If true, load the package of each Go type from TypeOverrides,
+	// ColumnTypeOverrides, and go-type pragmas with go/packages from OutputDir
+	// to check that the type exists, is exported, and implements the
+	// interfaces the generated code needs.
+	CheckGoTypes bool
+	// A map from a protobuf package, like "foo.bar", to the Go package path of
+	// the code generated by protoc-gen-go for the package. Used to find the Go
+	// struct for the message named by a proto-type pragma. Loads the Go
+	// packages with go/packages from OutputDir.
+	ProtoGoPackages map[string]string
+}
+
+// Driver is the Go database API that the generated code uses to run queries.
+type Driver string
+
+const (
+	// DriverPgx generates code for the pgx API, like *pgx.Conn and
+	// *pgxpool.Pool.
+	DriverPgx Driver = "pgx"
+	// DriverDatabaseSQL generates code for the database/sql API, like *sql.DB
+	// and *sql.Tx, with any Postgres driver, like the pgx stdlib driver or
+	// lib/pq.
+	DriverDatabaseSQL Driver = "database/sql"
+)
+
+// ParseDriver parses the name of a Driver. The empty string is DriverPgx.
+func ParseDriver(s string) (Driver, error) {
+	switch Driver(s) {
+	case "", DriverPgx:
+		return DriverPgx, nil
+	case DriverDatabaseSQL:
+		return DriverDatabaseSQL, nil
+	default:
+		return "", fmt.Errorf("unknown driver %q; must be %q or %q", s, DriverPgx, DriverDatabaseSQL)
+	}
 }
 
 // Generate emits generated Go files for each of the queryFiles.
@@ -31,13 +97,69 @@ func Generate(opts GenerateOptions, queryFiles []codegen.QueryFile) error {
 	if pkgName == "" {
 		pkgName = filepath.Base(opts.OutputDir)
 	}
+	schemaNaming, err := ParseSchemaNaming(string(opts.SchemaNaming))
+	if err != nil {
+		return err
+	}
+	pgxVersion := opts.PgxVersion
+	switch pgxVersion {
+	case 0:
+		pgxVersion = 4
+	case 4, 5:
+		// okay
+	default:
+		return fmt.Errorf("unsupported pgx version %d; must be 4 or 5", pgxVersion)
+	}
+	driver, err := ParseDriver(string(opts.Driver))
+	if err != nil {
+		return err
+	}
+	if err := validateNullableStyle(opts.NullableStyle, driver, pgxVersion); err != nil {
+		return err
+	}
+	for name, style := range opts.NullableTypeStyles {
+		if err := validateNullableStyle(style, driver, pgxVersion); err != nil {
+			return fmt.Errorf("nullable style for type %s: %w", name, err)
+		}
+	}
+	typeOverrides, err := applyTypePresets(opts.TypePresets, opts.TypeOverrides, driver, pgxVersion)
+	if err != nil {
+		return err
+	}
+	if opts.CheckGoTypes {
+		overrides := listGoTypeOverrides(opts, queryFiles)
+		if err := checkGoTypeOverrides(opts.OutputDir, overrides, driver, pgxVersion); err != nil {
+			return fmt.Errorf("check go type overrides: %w", err)
+		}
+	}
+	protoMessages, err := loadProtoMessages(opts.OutputDir, opts.ProtoGoPackages, queryFiles)
+	if err != nil {
+		return fmt.Errorf("load proto types: %w", err)
+	}
 	caser := casing.NewCaser()
 	caser.AddAcronyms(opts.Acronyms)
+	resolver := NewTypeResolver(caser, typeOverrides, TypeResolverOpts{
+		SchemaNaming:            schemaNaming,
+		NullableCompositeFields: opts.NullableCompositeFields,
+		PgxVersion:              pgxVersion,
+		Driver:                  driver,
+		NullableStyle:           opts.NullableStyle,
+		NullableTypeStyles:      opts.NullableTypeStyles,
+	})
+	for key := range opts.ColumnTypeOverrides {
+		if prefix, name, ok := strings.Cut(key, "."); !ok || prefix == "" || name == "" {
+			return fmt.Errorf("column type override %q must have format <table>.<column> or <query>.<name>", key)
+		}
+	}
 	templater := NewTemplater(TemplaterOpts{
 		Caser:            caser,
-		Resolver:         NewTypeResolver(caser, opts.TypeOverrides),
+		Resolver:         resolver,
 		Pkg:              pkgName,
 		InlineParamCount: opts.InlineParamCount,
+		PgxVersion:       pgxVersion,
+		Driver:           driver,
+		ColumnOverrides:  opts.ColumnTypeOverrides,
+		ProtoMessages:    protoMessages,
 	})
 	templatedFiles, err := templater.TemplateAll(queryFiles)
 	if err != nil {
@@ -56,7 +178,7 @@ func Generate(opts GenerateOptions, queryFiles []codegen.QueryFile) error {
 		templatedFiles[i].Pkg = pkg
 	}
 
-	tmpl, err := parseQueryTemplate()
+	tmpl, err := parseQueryTemplate(driver, pgxVersion)
 	if err != nil {
 		return fmt.Errorf("parse generated Go code template: %w", err)
 	}
@@ -67,13 +189,91 @@ func Generate(opts GenerateOptions, queryFiles []codegen.QueryFile) error {
 	return nil
 }
 
+// validateNullableStyle checks that the generated code for the driver and pgx
+// version supports the nullable style.
+func validateNullableStyle(style NullableStyle, driver Driver, pgxVersion int) error {
+	if _, err := ParseNullableStyle(string(style)); err != nil {
+		return err
+	}
+	if style == NullableStylePgtype && (driver != DriverPgx || pgxVersion != 4) {
+		return fmt.Errorf("nullable style %q is only supported for pgx v4", style)
+	}
+	return nil
+}
+
+// applyTypePresets layers the type overrides on top of the Go types from the
+// named presets, in order. An override for a type without the "?" suffix, like
+// "uuid", also replaces the nullable Go type for the type from a preset, like
+// "uuid?".
+func applyTypePresets(presets []string, overrides map[string]string, driver Driver, pgxVersion int) (map[string]string, error) {
+	if len(presets) == 0 {
+		return overrides, nil
+	}
+	types := make(map[string]string)
+	for _, name := range presets {
+		preset, ok := gotype.FindPreset(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown type preset %q; must be one of %s",
+				name, strings.Join(gotype.ListPresetNames(), ", "))
+		}
+		if err := validateTypePreset(name, driver, pgxVersion); err != nil {
+			return nil, err
+		}
+		for pgName, goType := range preset {
+			types[pgName] = goType
+		}
+	}
+	for pgName := range overrides {
+		if strings.HasSuffix(pgName, "?") {
+			continue
+		}
+		for _, alias := range listAliases(strings.TrimPrefix(pgName, "pg_catalog.")) {
+			delete(types, alias)
+			delete(types, alias+"?")
+		}
+	}
+	for pgName, goType := range overrides {
+		types[pgName] = goType
+	}
+	return types, nil
+}
+
+func validateTypePreset(name string, driver Driver, pgxVersion int) error {
+	switch name {
+	case gotype.PresetStdlib:
+		if driver != DriverPgx {
+			return fmt.Errorf("type preset %q is only supported for pgx", name)
+		}
+	case gotype.PresetPgtype:
+		if driver != DriverPgx || pgxVersion != 4 {
+			return fmt.Errorf("type preset %q is only supported for pgx v4", name)
+		}
+	}
+	return nil
+}
+
 //go:embed query.gotemplate
 var queryTemplate string
 
-func parseQueryTemplate() (*template.Template, error) {
-	tmpl, err := template.New("gen_query").Parse(queryTemplate)
+//go:embed query_pgx5.gotemplate
+var queryPgx5Template string
+
+//go:embed query_sql.gotemplate
+var querySQLTemplate string
+
+// parseQueryTemplate parses the template for the generated Go code for the
+// driver and the major version of pgx.
+func parseQueryTemplate(driver Driver, pgxVersion int) (*template.Template, error) {
+	name, text := "query.gotemplate", queryTemplate
+	switch {
+	case driver == DriverDatabaseSQL:
+		name, text = "query_sql.gotemplate", querySQLTemplate
+	case pgxVersion == 5:
+		name, text = "query_pgx5.gotemplate", queryPgx5Template
+	}
+	tmpl, err := template.New("gen_query").Parse(text)
 	if err != nil {
-		return nil, fmt.Errorf("parse query.gotemplate: %w", err)
+		return nil, fmt.Errorf("parse %s: %w", name, err)
 	}
 	return tmpl, nil
 }
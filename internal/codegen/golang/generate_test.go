@@ -0,0 +1,1302 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/ast"
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pginfer"
+	"github.com/jschaf/pggen/internal/ptrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Pgx5(t *testing.T) {
+	deviceType := pg.EnumType{ID: 16400, Name: "device_type", Labels: []string{"phone", "laptop"}}
+	userType := pg.CompositeType{
+		ID:             16410,
+		Name:           "user",
+		ColumnNames:    []string{"id", "name"},
+		ColumnTypes:    []pg.Type{pg.Int8, pg.Text},
+		ColumnNotNulls: []bool{true, false},
+	}
+	deviceTypes := pg.ArrayType{ID: 16401, Name: "_device_type", Elem: deviceType}
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevices",
+				ResultKind:  ast.ResultKindMany,
+				Doc:         []string{"FindDevices finds devices by type."},
+				PreparedSQL: "SELECT id, name, type, owner, tags, active FROM device WHERE type = ANY($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "types", PgType: deviceTypes},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "type", PgType: deviceType},
+					{PgName: "owner", PgType: userType, Nullable: true},
+					{PgName: "tags", PgType: pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}},
+					{PgName: "active", PgType: pg.Tstzrange},
+				},
+			},
+			{
+				Name:        "InsertUser",
+				ResultKind:  ast.ResultKindExec,
+				PreparedSQL: "INSERT INTO device (owner) VALUES ($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "owner", PgType: userType},
+				},
+			},
+			{
+				Name:        "CountDevices",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT count(*) FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "count", PgType: pg.Int8},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:            "device",
+		OutputDir:        dir,
+		Acronyms:         map[string]string{"id": "ID"},
+		PgxVersion:       5,
+		InlineParamCount: 2,
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_pgx5.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_DatabaseSQL(t *testing.T) {
+	deviceType := pg.EnumType{ID: 16400, Name: "device_type", Labels: []string{"phone", "laptop"}}
+	userType := pg.CompositeType{
+		ID:             16410,
+		Name:           "user",
+		ColumnNames:    []string{"id", "name", "tags"},
+		ColumnTypes:    []pg.Type{pg.Int8, pg.Text, pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}},
+		ColumnNotNulls: []bool{true, false, false},
+	}
+	deviceTypes := pg.ArrayType{ID: 16401, Name: "_device_type", Elem: deviceType}
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevices",
+				ResultKind:  ast.ResultKindMany,
+				Doc:         []string{"FindDevices finds devices by type."},
+				PreparedSQL: "SELECT id, name, type, owner, tags, created_at, active FROM device WHERE type = ANY($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "types", PgType: deviceTypes},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "type", PgType: deviceType},
+					{PgName: "owner", PgType: userType, Nullable: true},
+					{PgName: "tags", PgType: pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}},
+					{PgName: "created_at", PgType: pg.Timestamptz, Nullable: true},
+					{PgName: "active", PgType: pg.Tstzrange},
+				},
+			},
+			{
+				Name:        "InsertUser",
+				ResultKind:  ast.ResultKindExec,
+				PreparedSQL: "INSERT INTO device (owner) VALUES ($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "owner", PgType: userType},
+				},
+			},
+			{
+				Name:        "CountDevices",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT count(*) FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "count", PgType: pg.Int8},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:            "device",
+		OutputDir:        dir,
+		Acronyms:         map[string]string{"id": "ID"},
+		Driver:           DriverDatabaseSQL,
+		InlineParamCount: 2,
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_database_sql.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_NullableStyleGeneric(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindAuthors",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, name, bio, born_at, balance FROM author WHERE name = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "name", PgType: pg.Varchar},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int4},
+					{PgName: "name", PgType: pg.Varchar},
+					{PgName: "bio", PgType: pg.Text, Nullable: true},
+					{PgName: "born_at", PgType: pg.Timestamptz, Nullable: true},
+					{PgName: "balance", PgType: pg.Numeric, Nullable: true},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:            "author",
+		OutputDir:        dir,
+		Acronyms:         map[string]string{"id": "ID"},
+		NullableStyle:    NullableStyleGeneric,
+		InlineParamCount: 2,
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_nullable_generic.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_NullableStylePgtypeUnsupported(t *testing.T) {
+	err := Generate(GenerateOptions{
+		OutputDir:     t.TempDir(),
+		PgxVersion:    5,
+		NullableStyle: NullableStylePgtype,
+	}, nil)
+	require.ErrorContains(t, err, `nullable style "pgtype" is only supported for pgx v4`)
+}
+
+func TestGenerate_ColumnTypeOverrides(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindUser",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT user_id, settings, settings || '{}' AS flags FROM users WHERE user_id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "user_id", PgType: pg.Int8},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "user_id", PgType: pg.Int8, TableName: "users", TableColumn: "user_id"},
+					{PgName: "settings", PgType: pg.JSONB, Nullable: true, TableName: "users", TableColumn: "settings"},
+					{PgName: "flags", PgType: pg.JSONB, Nullable: true},
+				},
+				GoTypes: map[string]string{"flags": "encoding/json.RawMessage"},
+			},
+			{
+				Name:        "FindTeamSettings",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT settings FROM teams;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "settings", PgType: pg.JSONB, Nullable: true, TableName: "teams", TableColumn: "settings"},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:     "user",
+		OutputDir: dir,
+		Acronyms:  map[string]string{"id": "ID"},
+		ColumnTypeOverrides: map[string]string{
+			"users.settings":   "github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Settings",
+			"FindUser.user_id": "github.com/jschaf/pggen/internal/codegen/golang/test_resolve.UserID",
+		},
+		InlineParamCount: 2,
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_column_overrides.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_JSONTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		pgxVersion int
+		driver     Driver
+	}{
+		{"pgx4", 4, DriverPgx},
+		{"pgx5", 5, DriverPgx},
+		{"database_sql", 0, DriverDatabaseSQL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := codegen.QueryFile{
+				SourcePath: filepath.Join(dir, "query.sql"),
+				Queries: []pginfer.TypedQuery{
+					{
+						Name:        "FindSettings",
+						ResultKind:  ast.ResultKindMany,
+						PreparedSQL: "SELECT settings, raw FROM users WHERE settings @> $1;",
+						Inputs: []pginfer.InputParam{
+							{PgName: "filter", PgType: pg.JSONB},
+						},
+						Outputs: []pginfer.OutputColumn{
+							{PgName: "settings", PgType: pg.JSONB, Nullable: true, TableName: "users", TableColumn: "settings"},
+							{PgName: "raw", PgType: pg.JSON, Nullable: true},
+						},
+						GoTypes: map[string]string{
+							"filter": "map[string]any",
+							"raw":    "encoding/json.RawMessage",
+						},
+					},
+					{
+						Name:        "UpdateSettings",
+						ResultKind:  ast.ResultKindExec,
+						PreparedSQL: "UPDATE users SET settings = $1;",
+						Inputs: []pginfer.InputParam{
+							{PgName: "settings", PgType: pg.JSONB},
+						},
+						GoTypes: map[string]string{
+							"settings": "*github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Settings",
+						},
+					},
+				},
+			}
+
+			err := Generate(GenerateOptions{
+				GoPkg:      "user",
+				OutputDir:  dir,
+				PgxVersion: tt.pgxVersion,
+				Driver:     tt.driver,
+				ColumnTypeOverrides: map[string]string{
+					"users.settings": "*github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Settings",
+				},
+				InlineParamCount: 2,
+			}, []codegen.QueryFile{file})
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+			require.NoError(t, err)
+			golden := "testdata/generate_json_" + tt.name + ".golden"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got, 0o644))
+				return
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func TestGenerate_ColumnTypeOverridesUnmatched(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindUser",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT settings FROM users;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "settings", PgType: pg.JSONB, Nullable: true, TableName: "users", TableColumn: "settings"},
+				},
+			},
+		},
+	}
+	err := Generate(GenerateOptions{
+		GoPkg:               "user",
+		OutputDir:           dir,
+		ColumnTypeOverrides: map[string]string{"user.settings": "string"},
+	}, []codegen.QueryFile{file})
+	require.ErrorContains(t, err, `column type override "user.settings" does not match any query param, query column, or table column`)
+
+	err = Generate(GenerateOptions{
+		GoPkg:               "user",
+		OutputDir:           dir,
+		ColumnTypeOverrides: map[string]string{"settings": "string"},
+	}, []codegen.QueryFile{file})
+	require.ErrorContains(t, err, `column type override "settings" must have format <table>.<column> or <query>.<name>`)
+}
+
+func TestGenerate_TypeExpressions(t *testing.T) {
+	dir := t.TempDir()
+	hstore := pg.BaseType{ID: 16385, Name: "hstore"}
+	textArray := pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevice",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT device_id, labels, expires_at, aliases FROM device WHERE device_id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "device_id", PgType: pg.UUID},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "device_id", PgType: pg.UUID},
+					{PgName: "labels", PgType: hstore, Nullable: true},
+					{PgName: "expires_at", PgType: pg.Timestamptz, Nullable: true, TableName: "device", TableColumn: "expires_at"},
+					{PgName: "aliases", PgType: textArray, Nullable: true},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:     "device",
+		OutputDir: dir,
+		Acronyms:  map[string]string{"id": "ID"},
+		TypeOverrides: map[string]string{
+			"uuid":   "[16]byte",
+			"hstore": "map[string]*string",
+		},
+		ColumnTypeOverrides: map[string]string{
+			"device.expires_at":  "github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Option[time.Time]",
+			"FindDevice.aliases": "[]github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Option[string]",
+		},
+		InlineParamCount: 2,
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_type_expressions.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_ArrayElemOverrides(t *testing.T) {
+	dir := t.TempDir()
+	tag := pg.DomainType{ID: 16390, Name: "tag", BaseType: pg.Text}
+	tagArray := pg.ArrayType{ID: 16391, Name: "_tag", Elem: tag}
+	label := pg.DomainType{ID: 16392, Name: "label", BaseType: pg.Text}
+	labelArray := pg.ArrayType{ID: 16393, Name: "_label", Elem: label}
+	item := pg.CompositeType{
+		ID:          16394,
+		Name:        "item",
+		ColumnNames: []string{"id", "tags"},
+		ColumnTypes: []pg.Type{pg.Int8, tagArray},
+	}
+	itemArray := pg.ArrayType{ID: 16395, Name: "_item", Elem: item}
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindItems",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT ids, tags, labels, items, counts FROM find_items($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "ids", PgType: pg.Int8Array},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "ids", PgType: pg.Int8Array},
+					{PgName: "tags", PgType: tagArray},
+					{PgName: "labels", PgType: labelArray},
+					{PgName: "items", PgType: itemArray},
+					{PgName: "counts", PgType: pg.Int4Array},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:     "item",
+		OutputDir: dir,
+		Acronyms:  map[string]string{"id": "ID", "ids": "IDs"},
+		TypeOverrides: map[string]string{
+			"int8":   "github.com/jschaf/pggen/internal/codegen/golang/test_resolve.ID",
+			"tag":    "github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Option[string]",
+			"label":  "github.com/jschaf/pggen/internal/codegen/golang/test_resolve.Option[string]",
+			"_label": "[]string",
+			"int4":   "int",
+		},
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_array_elem_overrides.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_TypePresets(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindPayments",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, account_id, amount, created_at, ttl, network, tags FROM payment WHERE created_at > $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "after", PgType: pg.Timestamptz},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.UUID},
+					{PgName: "account_id", PgType: pg.UUID, Nullable: true},
+					{PgName: "amount", PgType: pg.Numeric, Nullable: true},
+					{PgName: "created_at", PgType: pg.Timestamptz},
+					{PgName: "ttl", PgType: pg.Interval, Nullable: true},
+					{PgName: "network", PgType: pg.Inet, Nullable: true},
+					{PgName: "tags", PgType: pg.TextArray},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		GoPkg:            "payment",
+		OutputDir:        dir,
+		Acronyms:         map[string]string{"id": "ID"},
+		TypePresets:      []string{gotype.PresetStdlib, gotype.PresetGoogleUUID, gotype.PresetShopspringDecimal},
+		TypeOverrides:    map[string]string{"interval?": "*int64"},
+		InlineParamCount: 2,
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	golden := "testdata/generate_type_presets.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_TypePresetErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		preset     string
+		pgxVersion int
+		driver     Driver
+		wantErr    string
+	}{
+		{"unknown", "joda-time", 4, DriverPgx, `unknown type preset "joda-time"; must be one of google-uuid, pgtype, shopspring-decimal, stdlib`},
+		{"pgtype pgx5", gotype.PresetPgtype, 5, DriverPgx, `type preset "pgtype" is only supported for pgx v4`},
+		{"pgtype database/sql", gotype.PresetPgtype, 0, DriverDatabaseSQL, `type preset "pgtype" is only supported for pgx v4`},
+		{"stdlib database/sql", gotype.PresetStdlib, 0, DriverDatabaseSQL, `type preset "stdlib" is only supported for pgx`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Generate(GenerateOptions{
+				OutputDir:   t.TempDir(),
+				PgxVersion:  tt.pgxVersion,
+				Driver:      tt.driver,
+				TypePresets: []string{tt.preset},
+			}, nil)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestApplyTypePresets(t *testing.T) {
+	got, err := applyTypePresets(
+		[]string{gotype.PresetPgtype, gotype.PresetStdlib},
+		map[string]string{"bigint": "int64", "text?": "example.com/opt.Option[string]"},
+		DriverPgx, 4)
+	require.NoError(t, err)
+	// Later presets take precedence.
+	assert.Equal(t, "time.Time", got["date"])
+	assert.Equal(t, "*time.Time", got["date?"])
+	// Types only in earlier presets remain.
+	assert.Equal(t, "github.com/jackc/pgtype.UUID", got["uuid"])
+	// An override replaces the non-nullable and nullable preset types.
+	assert.Equal(t, "int64", got["bigint"])
+	assert.NotContains(t, got, "int8")
+	assert.NotContains(t, got, "int8?")
+	// A nullable override only replaces the nullable preset type.
+	assert.Equal(t, "string", got["text"])
+	assert.Equal(t, "example.com/opt.Option[string]", got["text?"])
+}
+
+func TestGenerate_NonNullableOverride(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindAuthorAges",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, age FROM author;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "age", PgType: pg.Int8, Nullable: true},
+				},
+			},
+		},
+	}
+	err := Generate(GenerateOptions{
+		GoPkg:         "author",
+		OutputDir:     dir,
+		TypeOverrides: map[string]string{"int8": "int"},
+	}, []codegen.QueryFile{file})
+	require.ErrorContains(t, err, "query FindAuthorAges column age: nullable type int8 uses non-nullable Go type int")
+
+	err = Generate(GenerateOptions{
+		GoPkg:               "author",
+		OutputDir:           dir,
+		ColumnTypeOverrides: map[string]string{"FindAuthorAges.age": "int"},
+	}, []codegen.QueryFile{file})
+	require.ErrorContains(t, err, "query FindAuthorAges column age: nullable column uses non-nullable Go type int from a column override")
+
+	err = Generate(GenerateOptions{
+		GoPkg:               "author",
+		OutputDir:           dir,
+		ColumnTypeOverrides: map[string]string{"FindAuthorAges.age": "*int"},
+	}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+}
+
+func TestGenerate_UnknownDriver(t *testing.T) {
+	err := Generate(GenerateOptions{OutputDir: t.TempDir(), Driver: "lib/pq"}, nil)
+	require.ErrorContains(t, err, `unknown driver "lib/pq"`)
+}
+
+func TestGenerate_UnsupportedPgxVersion(t *testing.T) {
+	err := Generate(GenerateOptions{OutputDir: t.TempDir(), PgxVersion: 3}, nil)
+	require.ErrorContains(t, err, "unsupported pgx version 3")
+}
+
+// goldenDriver is a driver configuration for a golden test. The name is the
+// golden file suffix, like pgx4 in generate_iter_pgx4.golden.
+type goldenDriver struct {
+	name          string
+	pgxVersion    int
+	driver        Driver
+	nullableStyle NullableStyle
+}
+
+var (
+	pgxDrivers = []goldenDriver{
+		{name: "pgx4", pgxVersion: 4, driver: DriverPgx},
+		{name: "pgx5", pgxVersion: 5, driver: DriverPgx},
+	}
+	allDrivers = append(pgxDrivers[:len(pgxDrivers):len(pgxDrivers)],
+		goldenDriver{name: "database_sql", driver: DriverDatabaseSQL})
+)
+
+// runGenerateGolden generates files for each driver and compares query.sql.go
+// against testdata/generate_<name>_<driver>.golden. The source path of each
+// file is relative to the output directory. Returns the output directory of
+// each driver, keyed by driver name, for checks beyond the golden file.
+func runGenerateGolden(t *testing.T, name string, drivers []goldenDriver, opts GenerateOptions, files ...codegen.QueryFile) map[string]string {
+	t.Helper()
+	root := t.TempDir()
+	dirs := make(map[string]string, len(drivers))
+	for _, d := range drivers {
+		dir := filepath.Join(root, d.name)
+		dirs[d.name] = dir
+		t.Run(d.name, func(t *testing.T) {
+			require.NoError(t, os.MkdirAll(dir, 0o755))
+			driverFiles := make([]codegen.QueryFile, len(files))
+			for i, file := range files {
+				file.SourcePath = filepath.Join(dir, file.SourcePath)
+				driverFiles[i] = file
+			}
+			driverOpts := opts
+			driverOpts.OutputDir = dir
+			driverOpts.PgxVersion = d.pgxVersion
+			driverOpts.Driver = d.driver
+			driverOpts.NullableStyle = d.nullableStyle
+			err := Generate(driverOpts, driverFiles)
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+			require.NoError(t, err)
+			golden := "testdata/generate_" + name + "_" + d.name + ".golden"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got, 0o644))
+				return
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+	return dirs
+}
+
+// deviceOpts are the options shared by the device golden tests.
+var deviceOpts = GenerateOptions{
+	GoPkg:            "device",
+	Acronyms:         map[string]string{"id": "ID", "ids": "IDs"},
+	InlineParamCount: 2,
+}
+
+// deviceOwnerType is the composite type of the owner column in the device
+// golden tests.
+var deviceOwnerType = pg.CompositeType{
+	ID:             16410,
+	Name:           "user",
+	ColumnNames:    []string{"id", "name"},
+	ColumnTypes:    []pg.Type{pg.Int8, pg.Text},
+	ColumnNotNulls: []bool{true, false},
+}
+
+func TestGenerate_CopyFrom(t *testing.T) {
+	deviceType := pg.EnumType{ID: 16400, Name: "device_type", Labels: []string{"phone", "laptop"}}
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "InsertDevices",
+				ResultKind:  ast.ResultKindCopyFrom,
+				PreparedSQL: "INSERT INTO device (name, type, owner, tags) VALUES ($1, $2, $3, $4);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "name", PgType: pg.Text},
+					{PgName: "type", PgType: deviceType},
+					{PgName: "owner", PgType: deviceOwnerType},
+					{PgName: "tags", PgType: pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}},
+				},
+				CopyFrom: ast.CopyFromTarget{
+					Table:   []string{"public", "device"},
+					Columns: []string{"name", "type", "owner", "tags"},
+				},
+			},
+			{
+				Name:        "CountDevices",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT count(*) FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "count", PgType: pg.Int8},
+				},
+			},
+		},
+	}
+	opts := deviceOpts
+	opts.Acronyms = map[string]string{"id": "ID"}
+	runGenerateGolden(t, "copyfrom", pgxDrivers, opts, file)
+}
+
+func TestGenerate_CopyFromDatabaseSQL(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "InsertAuthors",
+				ResultKind:  ast.ResultKindCopyFrom,
+				PreparedSQL: "INSERT INTO author (first_name) VALUES ($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "first_name", PgType: pg.Text},
+				},
+				CopyFrom: ast.CopyFromTarget{Table: []string{"author"}, Columns: []string{"first_name"}},
+			},
+		},
+	}
+	err := Generate(GenerateOptions{
+		GoPkg:     "author",
+		OutputDir: dir,
+		Driver:    DriverDatabaseSQL,
+	}, []codegen.QueryFile{file})
+	require.ErrorContains(t, err, "query InsertAuthors: :copyfrom requires pgx; database/sql doesn't support the COPY protocol")
+}
+
+func TestGenerate_Iter(t *testing.T) {
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "StreamDevices",
+				ResultKind:  ast.ResultKindIter,
+				Doc:         []string{"StreamDevices streams all devices."},
+				PreparedSQL: "SELECT id, name, owner FROM device WHERE id > $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "after", PgType: pg.Int8},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "owner", PgType: deviceOwnerType, Nullable: true},
+				},
+			},
+			{
+				Name:        "StreamDeviceIDs",
+				ResultKind:  ast.ResultKindIter,
+				PreparedSQL: "SELECT id FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+				},
+			},
+		},
+	}
+	runGenerateGolden(t, "iter", allDrivers, deviceOpts, file)
+}
+
+func TestGenerate_Map(t *testing.T) {
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevicesByID",
+				ResultKind:  ast.ResultKindMap,
+				Doc:         []string{"FindDevicesByID finds devices keyed by ID."},
+				PreparedSQL: "SELECT id, name, owner FROM device WHERE id = ANY($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "ids", PgType: pg.Int8Array},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "owner", PgType: deviceOwnerType, Nullable: true},
+				},
+				MapKey: "id",
+			},
+			{
+				// Postgres can't prove join columns are not null, so
+				// every output column is nullable.
+				Name:        "FindDeviceNamesByOwner",
+				ResultKind:  ast.ResultKindMap,
+				Doc:         []string{"FindDeviceNamesByOwner finds device names keyed by owner ID."},
+				PreparedSQL: "SELECT d.owner_id, d.name FROM device d JOIN \"user\" u ON u.id = d.owner_id;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "owner_id", PgType: pg.Int8, Nullable: true},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+				},
+				MapKey: "owner_id",
+			},
+			{
+				Name:        "ListUserDevices",
+				ResultKind:  ast.ResultKindMany,
+				Doc:         []string{"ListUserDevices lists users with their devices."},
+				PreparedSQL: "SELECT u.id, u.name, d.id AS \"devices.id\", d.name AS \"devices.name\", COALESCE(d.kind, 'phone') AS \"devices.kind\" FROM \"user\" u LEFT JOIN device d ON d.owner_id = u.id;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8, Nullable: true},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "devices.id", PgType: pg.Int8, Nullable: true},
+					{PgName: "devices.name", PgType: pg.Text, Nullable: true},
+					// A non-nullable child column doesn't tell if the child exists.
+					{PgName: "devices.kind", PgType: pg.Text},
+				},
+				GroupBy: "id",
+			},
+		},
+	}
+	drivers := append(allDrivers[:len(allDrivers):len(allDrivers)],
+		goldenDriver{name: "pgx5_generic", pgxVersion: 5, driver: DriverPgx, nullableStyle: NullableStyleGeneric},
+		goldenDriver{name: "database_sql_sqlnull", driver: DriverDatabaseSQL, nullableStyle: NullableStyleSQLNull},
+	)
+	runGenerateGolden(t, "map", drivers, deviceOpts, file)
+}
+
+func TestGenerate_MapErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   pginfer.TypedQuery
+		wantErr string
+	}{
+		{
+			"array key",
+			pginfer.TypedQuery{
+				Name:        "FindDevicesByTags",
+				ResultKind:  ast.ResultKindMap,
+				PreparedSQL: "SELECT id, tags FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "tags", PgType: pg.TextArray, Nullable: true},
+				},
+				MapKey: "tags",
+			},
+			"query FindDevicesByTags key pragma: column tags has Go type []string that isn't comparable",
+		},
+		{
+			"composite key with array",
+			pginfer.TypedQuery{
+				Name:        "FindDevicesByLabel",
+				ResultKind:  ast.ResultKindMap,
+				PreparedSQL: "SELECT id, label FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "label", PgType: pg.CompositeType{
+						ID:          16420,
+						Name:        "label",
+						ColumnNames: []string{"name", "tags"},
+						ColumnTypes: []pg.Type{pg.Text, pg.TextArray},
+					}},
+				},
+				MapKey: "label",
+			},
+			"query FindDevicesByLabel key pragma: column label has Go type Label that isn't comparable",
+		},
+		{
+			"nullable opaque key",
+			pginfer.TypedQuery{
+				Name:        "FindDevicesByUUID",
+				ResultKind:  ast.ResultKindMap,
+				PreparedSQL: "SELECT uuid, id FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "uuid", PgType: pg.UUID, Nullable: true},
+					{PgName: "id", PgType: pg.Int8},
+				},
+				MapKey: "uuid",
+			},
+			"query FindDevicesByUUID key pragma: column uuid is nullable with Go type pgtype.UUID that can't tell if the key is NULL",
+		},
+		{
+			"no child columns",
+			pginfer.TypedQuery{
+				Name:        "ListUsers",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, name FROM \"user\";",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text},
+				},
+				GroupBy: "id",
+			},
+			"query ListUsers group-by pragma: no child columns",
+		},
+		{
+			"different child prefixes",
+			pginfer.TypedQuery{
+				Name:        "ListUsers",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT u.id, d.id AS \"devices.id\", p.id AS \"phones.id\" FROM \"user\" u, device d, phone p;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "devices.id", PgType: pg.Int8},
+					{PgName: "phones.id", PgType: pg.Int8},
+				},
+				GroupBy: "id",
+			},
+			"query ListUsers group-by pragma: columns must have the same child prefix; got devices and phones",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := codegen.QueryFile{
+				SourcePath: filepath.Join(dir, "query.sql"),
+				Queries:    []pginfer.TypedQuery{tt.query},
+			}
+			err := Generate(GenerateOptions{
+				GoPkg:     "device",
+				OutputDir: dir,
+				Acronyms:  map[string]string{"id": "ID"},
+			}, []codegen.QueryFile{file})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerate_Opt(t *testing.T) {
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevice",
+				ResultKind:  ast.ResultKindOpt,
+				Doc:         []string{"FindDevice finds a device by ID."},
+				PreparedSQL: "SELECT id, name, owner FROM device WHERE id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "id", PgType: pg.Int8},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "owner", PgType: deviceOwnerType, Nullable: true},
+				},
+			},
+			{
+				Name:        "FindDeviceName",
+				ResultKind:  ast.ResultKindOpt,
+				PreparedSQL: "SELECT name FROM device WHERE id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "id", PgType: pg.Int8},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+				},
+			},
+		},
+	}
+	runGenerateGolden(t, "opt", allDrivers, deviceOpts, file)
+}
+
+func TestGenerate_Cursor(t *testing.T) {
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "ListDevices",
+				ResultKind:  ast.ResultKindMany,
+				Doc:         []string{"ListDevices lists all devices."},
+				PreparedSQL: "SELECT id, name, owner FROM device WHERE id > $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "after", PgType: pg.Int8},
+				},
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "owner", PgType: deviceOwnerType, Nullable: true},
+				},
+				CursorSize: 500,
+			},
+			{
+				Name:        "StreamDeviceIDs",
+				ResultKind:  ast.ResultKindIter,
+				PreparedSQL: "SELECT id FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+				},
+				CursorSize: 1000,
+			},
+		},
+	}
+	runGenerateGolden(t, "cursor", allDrivers, deviceOpts, file)
+}
+
+func TestGenerate_ExecRows(t *testing.T) {
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "DeleteDevices",
+				ResultKind:  ast.ResultKindExecRows,
+				Doc:         []string{"DeleteDevices deletes devices owned by the user."},
+				PreparedSQL: "DELETE FROM device WHERE owner_id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "owner_id", PgType: pg.Int8},
+				},
+			},
+			{
+				Name:        "UpdateDeviceName",
+				ResultKind:  ast.ResultKindExec,
+				PreparedSQL: "UPDATE device SET name = $1, version = version + 1 WHERE id = $2 AND version = $3;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "name", PgType: pg.Text},
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "version", PgType: pg.Int4},
+				},
+				AffectedRows: ptrs.Int(1),
+			},
+			{
+				Name:        "DeleteDevice",
+				ResultKind:  ast.ResultKindExecRows,
+				PreparedSQL: "DELETE FROM device WHERE id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "id", PgType: pg.Int8},
+				},
+				AffectedRows: ptrs.Int(1),
+			},
+		},
+	}
+	runGenerateGolden(t, "execrows", allDrivers, deviceOpts, file)
+}
+
+func TestGenerate_AffectedZero(t *testing.T) {
+	dir := t.TempDir()
+	file := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "query.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:         "InsertDeviceIfAbsent",
+				ResultKind:   ast.ResultKindExecRows,
+				PreparedSQL:  "INSERT INTO device (id) VALUES ($1) ON CONFLICT DO NOTHING;",
+				Inputs:       []pginfer.InputParam{{PgName: "id", PgType: pg.Int8}},
+				AffectedRows: ptrs.Int(0),
+			},
+		},
+	}
+	err := Generate(GenerateOptions{GoPkg: "device", OutputDir: dir}, []codegen.QueryFile{file})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "if n != 0 {")
+	assert.Contains(t, string(got), "var ErrUnexpectedRowsAffected")
+}
+
+func TestGenerate_RowType(t *testing.T) {
+	outputs := []pginfer.OutputColumn{
+		{PgName: "id", PgType: pg.Int8},
+		{PgName: "name", PgType: pg.Text, Nullable: true},
+	}
+	file := codegen.QueryFile{
+		SourcePath: "query.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevice",
+				ResultKind:  ast.ResultKindOne,
+				Doc:         []string{"FindDevice finds a device by ID."},
+				PreparedSQL: "SELECT id, name FROM device WHERE id = $1;",
+				Inputs: []pginfer.InputParam{
+					{PgName: "id", PgType: pg.Int8},
+				},
+				Outputs: outputs,
+				RowType: "Device",
+			},
+			{
+				Name:        "ListDevices",
+				ResultKind:  ast.ResultKindMany,
+				Doc:         []string{"ListDevices lists all devices."},
+				PreparedSQL: "SELECT id, name FROM device;",
+				Outputs:     outputs,
+				RowType:     "Device",
+			},
+		},
+	}
+	otherFile := codegen.QueryFile{
+		SourcePath: "query_other.sql",
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:        "FindDevicesByID",
+				ResultKind:  ast.ResultKindMap,
+				Doc:         []string{"FindDevicesByID finds devices keyed by ID."},
+				PreparedSQL: "SELECT id, name FROM device WHERE id = ANY($1);",
+				Inputs: []pginfer.InputParam{
+					{PgName: "ids", PgType: pg.Int8Array},
+				},
+				Outputs: outputs,
+				MapKey:  "id",
+				RowType: "Device",
+			},
+		},
+	}
+	dirs := runGenerateGolden(t, "row_type", allDrivers, deviceOpts, file, otherFile)
+	for name, dir := range dirs {
+		other, err := os.ReadFile(filepath.Join(dir, "query_other.sql.go"))
+		require.NoError(t, err, name)
+		assert.Contains(t, string(other), "map[int]Device", name)
+		assert.NotContains(t, string(other), "type Device struct", name)
+	}
+}
+
+func TestGenerate_RowTypeErrors(t *testing.T) {
+	findDevice := pginfer.TypedQuery{
+		Name:        "FindDevice",
+		ResultKind:  ast.ResultKindOne,
+		PreparedSQL: "SELECT id, name FROM device LIMIT 1;",
+		Outputs: []pginfer.OutputColumn{
+			{PgName: "id", PgType: pg.Int8},
+			{PgName: "name", PgType: pg.Text},
+		},
+		RowType: "Device",
+	}
+	tests := []struct {
+		name    string
+		query   pginfer.TypedQuery
+		wantErr string
+	}{
+		{
+			"one output column",
+			pginfer.TypedQuery{
+				Name:        "ListDeviceIDs",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id FROM device;",
+				Outputs:     []pginfer.OutputColumn{{PgName: "id", PgType: pg.Int8}},
+				RowType:     "Device",
+			},
+			"query ListDeviceIDs row-type pragma: requires more than one output column",
+		},
+		{
+			"different column count",
+			pginfer.TypedQuery{
+				Name:        "ListDevices",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, name, owner FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text},
+					{PgName: "owner", PgType: pg.Text},
+				},
+				RowType: "Device",
+			},
+			"row-type Device: query FindDevice has 2 output columns but query ListDevices has 3",
+		},
+		{
+			"different column name",
+			pginfer.TypedQuery{
+				Name:        "ListDevices",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, owner FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "owner", PgType: pg.Text},
+				},
+				RowType: "Device",
+			},
+			"row-type Device: query FindDevice has column name but query ListDevices has column owner at the same position",
+		},
+		{
+			"different column type",
+			pginfer.TypedQuery{
+				Name:        "ListDevices",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id::int4, name FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int4},
+					{PgName: "name", PgType: pg.Text},
+				},
+				RowType: "Device",
+			},
+			"row-type Device: column id has type int in query FindDevice but type int32 in query ListDevices",
+		},
+		{
+			"different nullability",
+			pginfer.TypedQuery{
+				Name:        "ListDevices",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT id, name FROM device;",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+				},
+				RowType: "Device",
+			},
+			"row-type Device: column name has type string in query FindDevice but type *string in query ListDevices",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// Put the queries in separate files to check row types across files.
+			files := []codegen.QueryFile{
+				{SourcePath: filepath.Join(dir, "query.sql"), Queries: []pginfer.TypedQuery{findDevice}},
+				{SourcePath: filepath.Join(dir, "query_other.sql"), Queries: []pginfer.TypedQuery{tt.query}},
+			}
+			err := Generate(GenerateOptions{
+				GoPkg:     "device",
+				OutputDir: dir,
+				Acronyms:  map[string]string{"id": "ID"},
+			}, files)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerate_RowTypeNameCollisions(t *testing.T) {
+	userType := pg.CompositeType{
+		ID:             16410,
+		Name:           "user",
+		ColumnNames:    []string{"id", "name"},
+		ColumnTypes:    []pg.Type{pg.Int8, pg.Text},
+		ColumnNotNulls: []bool{true, false},
+	}
+	findDevice := func(rowType string) pginfer.TypedQuery {
+		return pginfer.TypedQuery{
+			Name:        "FindDevice",
+			ResultKind:  ast.ResultKindOne,
+			PreparedSQL: "SELECT id, owner FROM device LIMIT 1;",
+			Outputs: []pginfer.OutputColumn{
+				{PgName: "id", PgType: pg.Int8},
+				{PgName: "owner", PgType: userType, Nullable: true},
+			},
+			RowType: rowType,
+		}
+	}
+	listDevices := pginfer.TypedQuery{
+		Name:        "ListDevices",
+		ResultKind:  ast.ResultKindMany,
+		PreparedSQL: "SELECT id, name FROM device;",
+		Outputs: []pginfer.OutputColumn{
+			{PgName: "id", PgType: pg.Int8},
+			{PgName: "name", PgType: pg.Text},
+		},
+	}
+	tests := []struct {
+		name    string
+		queries []pginfer.TypedQuery
+		wantErr string
+	}{
+		{
+			"composite type",
+			[]pginfer.TypedQuery{findDevice("User")},
+			"row-type User: name collides with the Go type for composite type user",
+		},
+		{
+			"query row struct",
+			[]pginfer.TypedQuery{findDevice("ListDevicesRow"), listDevices},
+			"row-type ListDevicesRow: name collides with the row struct of query ListDevices",
+		},
+		{
+			"querier",
+			[]pginfer.TypedQuery{findDevice("Querier")},
+			"row-type Querier: name collides with the Querier interface",
+		},
+		{
+			"db querier",
+			[]pginfer.TypedQuery{findDevice("DBQuerier")},
+			"row-type DBQuerier: name collides with the DBQuerier struct",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := codegen.QueryFile{
+				SourcePath: filepath.Join(dir, "query.sql"),
+				Queries:    tt.queries,
+			}
+			err := Generate(GenerateOptions{
+				GoPkg:     "device",
+				OutputDir: dir,
+				Acronyms:  map[string]string{"id": "ID"},
+			}, []codegen.QueryFile{file})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
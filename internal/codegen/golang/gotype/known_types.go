@@ -44,6 +44,26 @@ func FindKnownTypeNonNullable(oid pgtype.OID) (Type, bool) {
 	return typ.pgNative, true
 }
 
+// FindKnownTypeRangeBound returns the type for the bounds of a range type, like
+// time.Time for tstzrange, if known, for the OID of the range subtype. Range
+// bounds are never null because Range represents a missing bound with the
+// infinite flags. Falls back to the non-nullable type.
+func FindKnownTypeRangeBound(oid pgtype.OID) (Type, bool) {
+	if typ, ok := knownRangeBoundsByOID[oid]; ok {
+		return typ, true
+	}
+	return FindKnownTypeNonNullable(oid)
+}
+
+// FindKnownTypeNative returns the native Go type that can't represent NULL,
+// like string for varchar or time.Time for timestamptz, if known, for a
+// Postgres OID. pgx v4, pgx v5, and database/sql all scan into the native
+// types, so the native types don't depend on the generated code.
+func FindKnownTypeNative(oid pgtype.OID) (Type, bool) {
+	typ, ok := knownNativeTypesByOID[oid]
+	return typ, ok
+}
+
 // Native go types are not prefixed.
 //
 //nolint:gochecknoglobals
@@ -87,6 +107,7 @@ var (
 	Float64Slice  = MustParseKnownType("[]float64", pg.Float8Array)
 	Float64pSlice = MustParseKnownType("[]*float64", pg.Float8Array)
 	ByteSlice     = MustParseKnownType("[]byte", pg.Bytea)
+	Time          = MustParseKnownType("time.Time", pg.Timestamptz)
 )
 
 // pgtype types prefixed with "pg".
@@ -160,6 +181,9 @@ var (
 	PgTstzrange        = MustParseKnownType("github.com/jackc/pgtype.Tstzrange", pg.Tstzrange)
 	PgDaterange        = MustParseKnownType("github.com/jackc/pgtype.Daterange", pg.Daterange)
 	PgInt8range        = MustParseKnownType("github.com/jackc/pgtype.Int8range", pg.Int8range)
+	PgInt4multirange   = MustParseKnownType("github.com/jackc/pgtype.Int4multirange", pg.Int4multirange)
+	PgNummultirange    = MustParseKnownType("github.com/jackc/pgtype.Nummultirange", pg.Nummultirange)
+	PgInt8multirange   = MustParseKnownType("github.com/jackc/pgtype.Int8multirange", pg.Int8multirange)
 )
 
 // knownGoType is the native pgtype type, the nullable and non-nullable types
@@ -249,4 +273,44 @@ var knownTypesByOID = map[pgtype.OID]knownGoType{
 	pgtype.TstzrangeOID:        {PgTstzrange, nil, nil},
 	pgtype.DaterangeOID:        {PgDaterange, nil, nil},
 	pgtype.Int8rangeOID:        {PgInt8range, nil, nil},
+	pgtype.Int4multirangeOID:   {PgInt4multirange, nil, nil},
+	pgtype.NummultirangeOID:    {PgNummultirange, nil, nil},
+	pgtype.Int8multirangeOID:   {PgInt8multirange, nil, nil},
+}
+
+// knownRangeBoundsByOID maps the OID of a range subtype to the Go type for the
+// range bounds if the type differs from the non-nullable type.
+//
+//nolint:gochecknoglobals
+var knownRangeBoundsByOID = map[pgtype.OID]Type{
+	pgtype.Float4OID:      Float32,
+	pgtype.Float8OID:      Float64,
+	pgtype.DateOID:        Time,
+	pgtype.TimestampOID:   Time,
+	pgtype.TimestamptzOID: Time,
+}
+
+// knownNativeTypesByOID maps the OID of a Postgres type to the native Go type
+// that can't represent NULL. Only lists Postgres types with a lossless native
+// Go type, so not numeric or interval.
+//
+//nolint:gochecknoglobals
+var knownNativeTypesByOID = map[pgtype.OID]Type{
+	pgtype.BoolOID:        Bool,
+	pgtype.NameOID:        String,
+	pgtype.Int8OID:        Int,
+	pgtype.Int2OID:        Int16,
+	pgtype.Int4OID:        Int32,
+	pgtype.TextOID:        String,
+	pgtype.ByteaOID:       ByteSlice,
+	pgtype.JSONOID:        ByteSlice,
+	pgtype.Float4OID:      Float32,
+	pgtype.Float8OID:      Float64,
+	pgtype.BPCharOID:      String,
+	pgtype.VarcharOID:     String,
+	pgtype.DateOID:        Time,
+	pgtype.TimestampOID:   Time,
+	pgtype.TimestamptzOID: Time,
+	pgtype.UUIDOID:        String,
+	pgtype.JSONBOID:       ByteSlice,
 }
@@ -0,0 +1,119 @@
+package gotype
+
+import (
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pg/pgoid"
+)
+
+// FindKnownTypePgx5 returns the Go type, like string or *string, if known, for
+// a Postgres OID when generating code for pgx v5. Follows the same fallback
+// rules as FindKnownTypeNullable and FindKnownTypeNonNullable. If there is no
+// known type for the OID, returns nil.
+func FindKnownTypePgx5(oid pgtype.OID, nullable bool) (Type, bool) {
+	typ, ok := knownTypesPgx5ByOID[oid]
+	if !ok {
+		return nil, false
+	}
+	if !nullable && typ.nonNullable != nil {
+		return typ.nonNullable, true
+	}
+	if typ.nullable != nil {
+		return typ.nullable, true
+	}
+	return typ.pgNative, true
+}
+
+// FindKnownTypePgx5RangeBound returns the type for the bounds of a
+// pgtype.Range, like time.Time for tstzrange, if known, for the OID of the
+// range subtype when generating code for pgx v5.
+func FindKnownTypePgx5RangeBound(oid pgtype.OID) (Type, bool) {
+	if typ, ok := knownRangeBoundsByOID[oid]; ok {
+		return typ, true
+	}
+	return FindKnownTypePgx5(oid, false)
+}
+
+// Native Go types only used for pgx v5.
+//
+//nolint:gochecknoglobals
+var (
+	Byte         = MustParseKnownType("byte", pg.QChar)
+	Bytep        = MustParseKnownType("*byte", pg.QChar)
+	Uint32p      = MustParseKnownType("*uint32", pg.OID)
+	Any          = MustParseKnownType("any", pg.Record)
+	NetipPrefix  = MustParseKnownType("net/netip.Prefix", pg.Inet)
+	NetipPrefixp = MustParseKnownType("*net/netip.Prefix", pg.Inet)
+	HardwareAddr = MustParseKnownType("net.HardwareAddr", pg.Macaddr)
+)
+
+// pgx v5 pgtype types prefixed with "Pgx5".
+//
+//nolint:gochecknoglobals
+var (
+	Pgx5Date        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Date", pg.Date)
+	Pgx5Time        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Time", pg.Time)
+	Pgx5Timestamp   = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Timestamp", pg.Timestamp)
+	Pgx5Timestamptz = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Timestamptz", pg.Timestamptz)
+	Pgx5Interval    = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Interval", pg.Interval)
+	Pgx5Numeric     = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Numeric", pg.Numeric)
+	Pgx5UUID        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.UUID", pg.UUID)
+	Pgx5Bits        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Bits", pg.Bit)
+	Pgx5TID         = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.TID", pg.TID)
+	Pgx5Point       = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Point", pg.Point)
+	Pgx5Lseg        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Lseg", pg.Lseg)
+	Pgx5Path        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Path", pg.Path)
+	Pgx5Box         = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Box", pg.Box)
+	Pgx5Polygon     = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Polygon", pg.Polygon)
+	Pgx5Line        = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Line", pg.Line)
+	Pgx5Circle      = MustParseKnownType("github.com/jackc/pgx/v5/pgtype.Circle", pg.Circle)
+)
+
+// knownTypesPgx5ByOID is like knownTypesByOID for pgx v5. Arrays aren't listed
+// because pgx v5 scans arrays into Go slices of the element type. Range and
+// multirange types use the generic pgtype.Range and pgtype.Multirange types.
+//
+//nolint:gochecknoglobals
+var knownTypesPgx5ByOID = map[pgtype.OID]knownGoType{
+	pgtype.BoolOID:        {nil, Boolp, Bool},
+	pgtype.QCharOID:       {nil, Bytep, Byte},
+	pgtype.NameOID:        {nil, Stringp, String},
+	pgtype.Int8OID:        {nil, Intp, Int},
+	pgtype.Int2OID:        {nil, Int16p, Int16},
+	pgtype.Int4OID:        {nil, Int32p, Int32},
+	pgtype.TextOID:        {nil, Stringp, String},
+	pgtype.ByteaOID:       {nil, ByteSlice, ByteSlice},
+	pgtype.OIDOID:         {nil, Uint32p, Uint32},
+	pgtype.TIDOID:         {Pgx5TID, nil, nil},
+	pgtype.XIDOID:         {nil, Uint32p, Uint32},
+	pgtype.CIDOID:         {nil, Uint32p, Uint32},
+	pgtype.JSONOID:        {nil, ByteSlice, ByteSlice},
+	pgtype.PointOID:       {Pgx5Point, nil, nil},
+	pgtype.LsegOID:        {Pgx5Lseg, nil, nil},
+	pgtype.PathOID:        {Pgx5Path, nil, nil},
+	pgtype.BoxOID:         {Pgx5Box, nil, nil},
+	pgtype.PolygonOID:     {Pgx5Polygon, nil, nil},
+	pgtype.LineOID:        {Pgx5Line, nil, nil},
+	pgtype.CIDROID:        {nil, NetipPrefixp, NetipPrefix},
+	pgtype.Float4OID:      {nil, Float32p, Float32},
+	pgtype.Float8OID:      {nil, Float64p, Float64},
+	pgtype.UnknownOID:     {nil, Stringp, String},
+	pgtype.CircleOID:      {Pgx5Circle, nil, nil},
+	pgtype.MacaddrOID:     {nil, HardwareAddr, HardwareAddr},
+	pgtype.InetOID:        {nil, NetipPrefixp, NetipPrefix},
+	pgtype.ACLItemOID:     {nil, Stringp, String},
+	pgtype.BPCharOID:      {nil, Stringp, String},
+	pgtype.VarcharOID:     {nil, Stringp, String},
+	pgtype.DateOID:        {Pgx5Date, nil, nil},
+	pgtype.TimeOID:        {Pgx5Time, nil, nil},
+	pgtype.TimestampOID:   {Pgx5Timestamp, nil, nil},
+	pgtype.TimestamptzOID: {Pgx5Timestamptz, nil, nil},
+	pgtype.IntervalOID:    {Pgx5Interval, nil, nil},
+	pgtype.BitOID:         {Pgx5Bits, nil, nil},
+	pgtype.VarbitOID:      {Pgx5Bits, nil, nil},
+	pgoid.Void:            {PgVoid, nil, nil},
+	pgtype.NumericOID:     {Pgx5Numeric, nil, nil},
+	pgtype.RecordOID:      {Any, nil, nil},
+	pgtype.UUIDOID:        {Pgx5UUID, nil, nil},
+	pgtype.JSONBOID:       {nil, ByteSlice, ByteSlice},
+}
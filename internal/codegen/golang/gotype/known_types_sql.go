@@ -0,0 +1,84 @@
+package gotype
+
+import (
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pg/pgoid"
+)
+
+// FindKnownTypeDatabaseSQL returns the Go type, like string or *string, if
+// known, for a Postgres OID when generating code for database/sql. Follows the
+// same fallback rules as FindKnownTypeNullable and FindKnownTypeNonNullable.
+// If there is no known type for the OID, returns nil.
+func FindKnownTypeDatabaseSQL(oid pgtype.OID, nullable bool) (Type, bool) {
+	typ, ok := knownTypesDatabaseSQLByOID[oid]
+	if !ok {
+		return nil, false
+	}
+	if !nullable && typ.nonNullable != nil {
+		return typ.nonNullable, true
+	}
+	if typ.nullable != nil {
+		return typ.nullable, true
+	}
+	return typ.pgNative, true
+}
+
+// Native Go types only used for database/sql.
+//
+//nolint:gochecknoglobals
+var (
+	Timep = MustParseKnownType("*time.Time", pg.Timestamptz)
+)
+
+// knownTypesDatabaseSQLByOID is like knownTypesByOID for database/sql. Only
+// uses Go types that database/sql scans from the values returned by any
+// Postgres driver, like the pgx stdlib driver or lib/pq. Postgres types
+// without a natural Go type, like numeric and uuid, use the Postgres text
+// format as a string. Arrays aren't listed because pggen wraps Go slices of
+// the element type to scan the Postgres text format of arrays.
+//
+//nolint:gochecknoglobals
+var knownTypesDatabaseSQLByOID = map[pgtype.OID]knownGoType{
+	pgtype.BoolOID:        {nil, Boolp, Bool},
+	pgtype.QCharOID:       {nil, Stringp, String},
+	pgtype.NameOID:        {nil, Stringp, String},
+	pgtype.Int8OID:        {nil, Intp, Int},
+	pgtype.Int2OID:        {nil, Int16p, Int16},
+	pgtype.Int4OID:        {nil, Int32p, Int32},
+	pgtype.TextOID:        {nil, Stringp, String},
+	pgtype.ByteaOID:       {nil, ByteSlice, ByteSlice},
+	pgtype.OIDOID:         {nil, Uint32p, Uint32},
+	pgtype.TIDOID:         {nil, Stringp, String},
+	pgtype.XIDOID:         {nil, Uint32p, Uint32},
+	pgtype.CIDOID:         {nil, Uint32p, Uint32},
+	pgtype.JSONOID:        {nil, ByteSlice, ByteSlice},
+	pgtype.PointOID:       {nil, Stringp, String},
+	pgtype.LsegOID:        {nil, Stringp, String},
+	pgtype.PathOID:        {nil, Stringp, String},
+	pgtype.BoxOID:         {nil, Stringp, String},
+	pgtype.PolygonOID:     {nil, Stringp, String},
+	pgtype.LineOID:        {nil, Stringp, String},
+	pgtype.CIDROID:        {nil, Stringp, String},
+	pgtype.Float4OID:      {nil, Float32p, Float32},
+	pgtype.Float8OID:      {nil, Float64p, Float64},
+	pgtype.UnknownOID:     {nil, Stringp, String},
+	pgtype.CircleOID:      {nil, Stringp, String},
+	pgtype.MacaddrOID:     {nil, Stringp, String},
+	pgtype.InetOID:        {nil, Stringp, String},
+	pgtype.ACLItemOID:     {nil, Stringp, String},
+	pgtype.BPCharOID:      {nil, Stringp, String},
+	pgtype.VarcharOID:     {nil, Stringp, String},
+	pgtype.DateOID:        {nil, Timep, Time},
+	pgtype.TimeOID:        {nil, Stringp, String},
+	pgtype.TimestampOID:   {nil, Timep, Time},
+	pgtype.TimestamptzOID: {nil, Timep, Time},
+	pgtype.IntervalOID:    {nil, Stringp, String},
+	pgtype.BitOID:         {nil, Stringp, String},
+	pgtype.VarbitOID:      {nil, Stringp, String},
+	pgoid.Void:            {PgVoid, nil, nil},
+	pgtype.NumericOID:     {nil, Stringp, String},
+	pgtype.RecordOID:      {Any, nil, nil},
+	pgtype.UUIDOID:        {nil, Stringp, String},
+	pgtype.JSONBOID:       {nil, ByteSlice, ByteSlice},
+}
@@ -1,5 +1,10 @@
 package gotype
 
+import (
+	"go/token"
+	"go/types"
+)
+
 // HasCompositeType returns true if t or any of t's descendants (for array and
 // composite types) is a composite type.
 func HasCompositeType(t Type) bool {
@@ -30,3 +35,135 @@ func HasArrayType(t Type) bool {
 		return false
 	}
 }
+
+// HasOpaqueElem returns true if t is a slice derived from a type override for
+// the element type where pgx doesn't support the Go type natively as a slice
+// element, like []example.com/ids.ID or []map[string]string. Returns false for
+// a slice type from a type override for the array type itself.
+func HasOpaqueElem(t *ArrayType) bool {
+	if !t.ElemOverride || IsPgxSupportedArray(t) || IsByteSlice(t) {
+		return false
+	}
+	switch UnwrapNestedType(t.Elem).(type) {
+	case *OpaqueType, *MapType, *FixedArrayType:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsByteSlice returns true if t is a []byte, which represents Postgres types
+// like bytea and jsonb instead of a Postgres array.
+func IsByteSlice(t Type) bool {
+	arr, ok := t.(*ArrayType)
+	if !ok {
+		return false
+	}
+	elem, ok := arr.Elem.(*OpaqueType)
+	return ok && (elem.Name == "byte" || elem.Name == "uint8")
+}
+
+// IsNonNullable returns true if t is a Go type that can't represent a Postgres
+// NULL, like int, [16]byte, or time.Time. Returns false for types that might
+// represent NULL, like pointers, slices, maps, and named types that implement
+// sql.Scanner.
+func IsNonNullable(t Type) bool {
+	switch t := t.(type) {
+	case *FixedArrayType:
+		return true
+	case *OpaqueType:
+		switch t.Name {
+		case "bool", "string", "byte", "rune",
+			"int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64", "complex64", "complex128":
+			return true
+		}
+		return false
+	case *ImportType:
+		opaque, ok := t.Type.(*OpaqueType)
+		return ok && t.PkgPath == "time" && opaque.Name == "Time"
+	default:
+		return false
+	}
+}
+
+// IsRawJSON returns true if t represents the raw JSON text of a Postgres json
+// or jsonb value, like []byte, string, json.RawMessage, or pgtype.JSONB,
+// instead of a Go type that pggen decodes from JSON.
+func IsRawJSON(t Type) bool {
+	if ptr, ok := t.(*PointerType); ok {
+		t = ptr.Elem
+	}
+	switch t := t.(type) {
+	case *ArrayType:
+		return IsByteSlice(t)
+	case *OpaqueType:
+		return t.Name == "string"
+	case *ImportType:
+		opaque, ok := t.Type.(*OpaqueType)
+		if !ok {
+			return false
+		}
+		switch t.PkgPath {
+		case "encoding/json":
+			return opaque.Name == "RawMessage"
+		case "github.com/jackc/pgtype", "github.com/jackc/pgx/v5/pgtype":
+			return true
+		}
+	}
+	return false
+}
+
+// IsComparable returns true if t is a comparable Go type that can be a map
+// key, as checked by go/types. Assumes opaque named types, like a type from a
+// type override, are comparable since the definition isn't known.
+func IsComparable(t Type) bool {
+	return types.Comparable(toGoTypesType(t))
+}
+
+// toGoTypesType converts t into a go/types type with the same structure for
+// the comparable check. Named types become the underlying type if known, like
+// string for an enum, or an invalid type otherwise.
+func toGoTypesType(t Type) types.Type {
+	switch t := t.(type) {
+	case *PointerType:
+		return types.NewPointer(toGoTypesType(t.Elem))
+	case *ArrayType:
+		return types.NewSlice(toGoTypesType(t.Elem))
+	case *FixedArrayType:
+		return types.NewArray(toGoTypesType(t.Elem), int64(t.Len))
+	case *MapType:
+		return types.NewMap(toGoTypesType(t.Key), toGoTypesType(t.Elem))
+	case *ImportType:
+		return toGoTypesType(t.Type)
+	case *EnumType:
+		return types.Typ[types.String]
+	case *CompositeType:
+		fields := make([]*types.Var, len(t.FieldTypes))
+		for i, typ := range t.FieldTypes {
+			fields[i] = types.NewField(token.NoPos, nil, t.FieldNames[i], toGoTypesType(typ), false)
+		}
+		return types.NewStruct(fields, nil)
+	case *NullType:
+		return types.NewStruct([]*types.Var{
+			types.NewField(token.NoPos, nil, "V", toGoTypesType(t.Elem), false),
+			types.NewField(token.NoPos, nil, "Valid", types.Typ[types.Bool], false),
+		}, nil)
+	case *RangeType:
+		elem := toGoTypesType(t.Elem)
+		return types.NewStruct([]*types.Var{
+			types.NewField(token.NoPos, nil, "Lower", elem, false),
+			types.NewField(token.NoPos, nil, "Upper", elem, false),
+		}, nil)
+	case *MultirangeType:
+		return types.NewSlice(toGoTypesType(t.Range))
+	case *OpaqueType:
+		if obj, ok := types.Universe.Lookup(t.Name).(*types.TypeName); ok {
+			return obj.Type()
+		}
+		return types.Typ[types.Invalid]
+	default:
+		return types.Typ[types.Invalid]
+	}
+}
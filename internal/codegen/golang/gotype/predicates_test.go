@@ -48,3 +48,131 @@ func TestHasArrayType(t *testing.T) {
 		})
 	}
 }
+
+func TestHasOpaqueElem(t *testing.T) {
+	tests := []struct {
+		name         string
+		typ          string
+		elemOverride bool
+		want         bool
+	}{
+		{"custom", "[]example.com/ids.ID", true, true},
+		{"custom pointer", "[]*example.com/ids.ID", true, true},
+		{"generic", "[]example.com/opt.Option[int]", true, true},
+		{"map", "[]map[string]string", true, true},
+		{"string", "[]string", true, false},
+		{"time", "[]time.Time", true, false},
+		{"byte slice", "[]byte", true, false},
+		{"nested slice", "[][]example.com/ids.ID", true, false},
+		{"array override", "[]example.com/ids.ID", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := MustParseOpaqueType(tt.typ).(*ArrayType)
+			typ.ElemOverride = tt.elemOverride
+			if got := HasOpaqueElem(typ); got != tt.want {
+				t.Errorf("HasOpaqueElem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsByteSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  Type
+		want bool
+	}{
+		{"byte slice", ByteSlice, true},
+		{"uint8 slice", MustParseOpaqueType("[]uint8"), true},
+		{"string slice", &ArrayType{Elem: String}, false},
+		{"opaque", &OpaqueType{Name: "byte"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsByteSlice(tt.typ); got != tt.want {
+				t.Errorf("IsByteSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNonNullable(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  Type
+		want bool
+	}{
+		{"int", MustParseOpaqueType("int"), true},
+		{"string", MustParseOpaqueType("string"), true},
+		{"time", MustParseOpaqueType("time.Time"), true},
+		{"int pointer", MustParseOpaqueType("*int"), false},
+		{"int slice", MustParseOpaqueType("[]int"), false},
+		{"byte array", MustParseOpaqueType("[16]byte"), true},
+		{"map", MustParseOpaqueType("map[string]string"), false},
+		{"pgtype", MustParseOpaqueType("github.com/jackc/pgtype.Int8"), false},
+		{"custom", MustParseOpaqueType("example.com/foo.Int"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNonNullable(tt.typ); got != tt.want {
+				t.Errorf("IsNonNullable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRawJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  Type
+		want bool
+	}{
+		{"byte slice", ByteSlice, true},
+		{"string", MustParseOpaqueType("string"), true},
+		{"string pointer", MustParseOpaqueType("*string"), true},
+		{"raw message", MustParseOpaqueType("encoding/json.RawMessage"), true},
+		{"pgtype", MustParseOpaqueType("github.com/jackc/pgtype.JSONB"), true},
+		{"struct", MustParseOpaqueType("example.com/user.Settings"), false},
+		{"struct pointer", MustParseOpaqueType("*example.com/user.Settings"), false},
+		{"map", MustParseOpaqueType("map[string]any"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRawJSON(tt.typ); got != tt.want {
+				t.Errorf("IsRawJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsComparable(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  Type
+		want bool
+	}{
+		{"int", MustParseOpaqueType("int"), true},
+		{"int pointer", MustParseOpaqueType("*int"), true},
+		{"time", MustParseOpaqueType("time.Time"), true},
+		{"byte array", MustParseOpaqueType("[16]byte"), true},
+		{"custom", MustParseOpaqueType("example.com/foo.ID"), true},
+		{"enum", &EnumType{}, true},
+		{"null", &NullType{Elem: MustParseOpaqueType("int64")}, true},
+		{"int slice", MustParseOpaqueType("[]int"), false},
+		{"map", MustParseOpaqueType("map[string]string"), false},
+		{"composite", &CompositeType{FieldNames: []string{"ID"}, FieldTypes: []Type{MustParseOpaqueType("int")}}, true},
+		{"composite with slice", &CompositeType{
+			FieldNames: []string{"ID", "Tags"},
+			FieldTypes: []Type{MustParseOpaqueType("int"), MustParseOpaqueType("[]string")},
+		}, false},
+		{"range of slice", &RangeType{Elem: MustParseOpaqueType("[]byte")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsComparable(tt.typ); got != tt.want {
+				t.Errorf("IsComparable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
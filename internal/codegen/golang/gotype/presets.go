@@ -0,0 +1,127 @@
+package gotype
+
+import (
+	"sort"
+)
+
+// Names of the type presets, named sets of Go types for builtin Postgres
+// types for common Go ecosystems.
+const (
+	// PresetStdlib uses standard library types instead of pgtype types, like
+	// time.Time for date, time.Duration for interval, and netip.Prefix for
+	// inet. Only supported for pgx.
+	PresetStdlib = "stdlib"
+	// PresetGoogleUUID uses github.com/google/uuid for uuid.
+	PresetGoogleUUID = "google-uuid"
+	// PresetShopspringDecimal uses github.com/shopspring/decimal for numeric.
+	PresetShopspringDecimal = "shopspring-decimal"
+	// PresetPgtype uses the github.com/jackc/pgtype type for all builtin
+	// Postgres types, like pgtype.Text for text. Only supported for pgx v4.
+	PresetPgtype = "pgtype"
+)
+
+// FindPreset returns the Go types for a named preset, like PresetStdlib. The
+// keys are Postgres type names in the same format as type overrides. A key
+// with a "?" suffix, like "date?", only applies to nullable values. Each
+// preset has a nullable key for every Postgres type so that nullable values
+// don't fail the non-nullable override check. Returns a copy that the caller
+// may modify.
+func FindPreset(name string) (map[string]string, bool) {
+	preset, ok := presets[name]
+	if !ok {
+		return nil, false
+	}
+	types := make(map[string]string, len(preset))
+	for pgName, goType := range preset {
+		types[pgName] = goType
+	}
+	return types, true
+}
+
+// ListPresetNames returns the names of all presets in sorted order.
+func ListPresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//nolint:gochecknoglobals
+var presets = map[string]map[string]string{
+	PresetStdlib: {
+		"bool":         "bool",
+		"bool?":        "*bool",
+		"int2":         "int16",
+		"int2?":        "*int16",
+		"int4":         "int32",
+		"int4?":        "*int32",
+		"int8":         "int",
+		"int8?":        "*int",
+		"float4":       "float32",
+		"float4?":      "*float32",
+		"float8":       "float64",
+		"float8?":      "*float64",
+		"oid":          "uint32",
+		"oid?":         "*uint32",
+		"xid":          "uint32",
+		"xid?":         "*uint32",
+		"cid":          "uint32",
+		"cid?":         "*uint32",
+		"name":         "string",
+		"name?":        "*string",
+		"text":         "string",
+		"text?":        "*string",
+		"bpchar":       "string",
+		"bpchar?":      "*string",
+		"varchar":      "string",
+		"varchar?":     "*string",
+		"bytea":        "[]byte",
+		"bytea?":       "[]byte",
+		"json":         "[]byte",
+		"json?":        "[]byte",
+		"jsonb":        "[]byte",
+		"jsonb?":       "[]byte",
+		"date":         "time.Time",
+		"date?":        "*time.Time",
+		"timestamp":    "time.Time",
+		"timestamp?":   "*time.Time",
+		"timestamptz":  "time.Time",
+		"timestamptz?": "*time.Time",
+		"interval":     "time.Duration",
+		"interval?":    "*time.Duration",
+		"inet":         "net/netip.Prefix",
+		"inet?":        "*net/netip.Prefix",
+		"cidr":         "net/netip.Prefix",
+		"cidr?":        "*net/netip.Prefix",
+		"macaddr":      "net.HardwareAddr",
+		"macaddr?":     "net.HardwareAddr",
+	},
+	PresetGoogleUUID: {
+		"uuid":  "github.com/google/uuid.UUID",
+		"uuid?": "github.com/google/uuid.NullUUID",
+	},
+	PresetShopspringDecimal: {
+		"numeric":  "github.com/shopspring/decimal.Decimal",
+		"numeric?": "github.com/shopspring/decimal.NullDecimal",
+	},
+	PresetPgtype: newPgtypePreset(),
+}
+
+// newPgtypePreset maps every Postgres type with a pgtype type in
+// knownTypesByOID to the pgtype type, like "text" => "github.com/jackc/pgtype.Text".
+func newPgtypePreset() map[string]string {
+	types := make(map[string]string, 2*len(knownTypesByOID))
+	for _, known := range knownTypesByOID {
+		imp, ok := known.pgNative.(*ImportType)
+		if !ok || imp.PkgPath != "github.com/jackc/pgtype" {
+			continue
+		}
+		opaque := imp.Type.(*OpaqueType)
+		goType := imp.PkgPath + "." + opaque.Name
+		types[opaque.PgType.String()] = goType
+		types[opaque.PgType.String()+"?"] = goType
+	}
+	return types
+}
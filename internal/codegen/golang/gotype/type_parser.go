@@ -0,0 +1,140 @@
+package gotype
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// typeParser parses a Go type expression where named types use the fully
+// qualified package path instead of the package name, like
+// "map[string]github.com/jschaf/opt.Option[time.Time]".
+type typeParser struct {
+	src string // the type expression
+	pos int    // byte offset of the next unread character in src
+}
+
+// parseType parses a single type:
+//
+//	Type      = "*" Type | "[" "]" Type | "[" Len "]" Type | "map" "[" Type "]" Type | TypeName [ TypeArgs ] .
+//	TypeName  = [ PkgPath "." ] identifier .
+//	TypeArgs  = "[" Type { "," Type } "]" .
+func (p *typeParser) parseType() (Type, error) {
+	p.skipSpace()
+	switch {
+	case p.consume("*"):
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		return &PointerType{Elem: elem}, nil
+
+	case p.consume("[]"):
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		return &ArrayType{Elem: elem}, nil
+
+	case p.consume("["):
+		start := p.pos
+		for p.pos < len(p.src) && '0' <= p.src[p.pos] && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		n, err := strconv.Atoi(p.src[start:p.pos])
+		if err != nil {
+			return nil, p.errorf("expected array length")
+		}
+		if !p.consume("]") {
+			return nil, p.errorf("expected ']' after array length")
+		}
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		return &FixedArrayType{Len: n, Elem: elem}, nil
+
+	case p.consume("map["):
+		key, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if p.skipSpace(); !p.consume("]") {
+			return nil, p.errorf("expected ']' after map key type")
+		}
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		return &MapType{Key: key, Elem: elem}, nil
+
+	default:
+		return p.parseTypeName()
+	}
+}
+
+// parseTypeName parses a possibly qualified and possibly generic type name,
+// like "int", "time.Time", or "github.com/jschaf/opt.Option[int]".
+func (p *typeParser) parseTypeName() (Type, error) {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune("[], *", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	qualName := p.src[start:p.pos]
+	if qualName == "" {
+		return nil, p.errorf("expected type name")
+	}
+	pkgPath, name := "", qualName
+	if idx := strings.LastIndexByte(qualName, '.'); idx != -1 {
+		pkgPath, name = qualName[:idx], qualName[idx+1:]
+		if pkgPath == "" {
+			return nil, p.errorf("empty package path for type %q", name)
+		}
+	}
+	if !token.IsIdentifier(name) {
+		return nil, p.errorf("invalid type name %q", name)
+	}
+
+	opaque := &OpaqueType{Name: name}
+	if p.consume("[") {
+		for {
+			arg, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			opaque.TypeArgs = append(opaque.TypeArgs, arg)
+			p.skipSpace()
+			if p.consume("]") {
+				break
+			}
+			if !p.consume(",") {
+				return nil, p.errorf("expected ',' or ']' after type argument")
+			}
+		}
+	}
+	if pkgPath == "" {
+		return opaque, nil
+	}
+	return &ImportType{PkgPath: pkgPath, Type: opaque}, nil
+}
+
+// consume advances past prefix and returns true if the unread input starts
+// with prefix.
+func (p *typeParser) consume(prefix string) bool {
+	if !strings.HasPrefix(p.src[p.pos:], prefix) {
+		return false
+	}
+	p.pos += len(prefix)
+	return true
+}
+
+func (p *typeParser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *typeParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("malformed custom type %q at offset %d: %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
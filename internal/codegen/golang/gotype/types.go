@@ -23,10 +23,15 @@ type Type interface {
 }
 
 type (
-	// ArrayType is a Go slice type.
+	// ArrayType is a Go slice type. A multi-dimensional Postgres array is a
+	// nested ArrayType, like [][]int for a two-dimensional int8 array.
 	ArrayType struct {
 		PgArray pg.ArrayType // original Postgres array type
 		Elem    Type         // element type of the slice, like int for []int
+		// ElemOverride is true if the Elem Go type comes from a type override
+		// for the Postgres element type, like []ids.ID for my_id[] with the
+		// override my_id=example.com/ids.ID.
+		ElemOverride bool
 	}
 
 	// CompositeType is a struct type that represents a Postgres composite type.
@@ -56,11 +61,27 @@ type (
 		Type    Type   // type to import
 	}
 
+	// FixedArrayType is a Go array type with a constant length, like [16]byte
+	// for uuid.
+	FixedArrayType struct {
+		Len  int  // length of the array, like 16 for [16]byte
+		Elem Type // element type of the array, like byte for [16]byte
+	}
+
+	// MapType is a Go map type, like map[string]string for hstore.
+	MapType struct {
+		Key  Type // key type of the map, like string for map[string]int
+		Elem Type // element type of the map, like int for map[string]int
+	}
+
 	// OpaqueType is a type where only the name is known, as with a user-provided
 	// custom type.
 	OpaqueType struct {
 		PgType pg.Type // original Postgres type
 		Name   string  // name of the unqualified Go type
+		// Type arguments of a generic type, like int for Option[int]. Nil for
+		// non-generic types.
+		TypeArgs []Type
 	}
 
 	// PointerType is a pointer to another Go type.
@@ -68,6 +89,37 @@ type (
 		Elem Type // the pointed-to type
 	}
 
+	// RangeType is the generic Range struct that represents a Postgres range
+	// type, like Range[time.Time] for tstzrange.
+	RangeType struct {
+		PgRange pg.RangeType // original Postgres range type
+		Name    string       // Go-style name of the Postgres range type, like Tstzrange
+		Elem    Type         // type of the range bounds, like time.Time
+		// Package of the generic Range type, like
+		// "github.com/jackc/pgx/v5/pgtype". Empty if pggen declares the Range
+		// type.
+		PkgPath string
+	}
+
+	// MultirangeType is the generic Multirange slice that represents a Postgres
+	// multirange type, like Multirange[time.Time] for tstzmultirange. If the
+	// range type has a PkgPath, the type argument is the range type instead,
+	// like pgtype.Multirange[pgtype.Range[time.Time]].
+	MultirangeType struct {
+		PgMultirange pg.MultirangeType // original Postgres multirange type
+		Name         string            // Go-style name of the Postgres multirange type, like Tstzmultirange
+		Range        *RangeType        // the range type of each element
+	}
+
+	// NullType is a generic struct that represents a nullable Go type, like
+	// sql.Null[string] or the Null[string] type declared by pggen.
+	NullType struct {
+		Elem Type // the non-nullable type, like string
+		// Package of the generic Null type, like "database/sql". Empty if pggen
+		// declares the Null type.
+		PkgPath string
+	}
+
 	// VoidType is a placeholder type that should never appear in output. We need
 	// a placeholder to scan pgx rows, but we ultimately ignore the results in the
 	// return values.
@@ -86,12 +138,38 @@ func (e *EnumType) BaseName() string { return e.Name }
 func (e *ImportType) Import() string   { return e.PkgPath }
 func (e *ImportType) BaseName() string { return e.Type.BaseName() }
 
-func (o *OpaqueType) Import() string   { return "" }
-func (o *OpaqueType) BaseName() string { return o.Name }
+func (f *FixedArrayType) Import() string { return f.Elem.Import() }
+func (f *FixedArrayType) BaseName() string {
+	return "[" + strconv.Itoa(f.Len) + "]" + f.Elem.BaseName()
+}
+
+func (m *MapType) Import() string   { return "" }
+func (m *MapType) BaseName() string { return "map[" + m.Key.BaseName() + "]" + m.Elem.BaseName() }
+
+func (o *OpaqueType) Import() string { return "" }
+func (o *OpaqueType) BaseName() string {
+	if len(o.TypeArgs) == 0 {
+		return o.Name
+	}
+	args := make([]string, len(o.TypeArgs))
+	for i, arg := range o.TypeArgs {
+		args[i] = arg.BaseName()
+	}
+	return o.Name + "[" + strings.Join(args, ", ") + "]"
+}
 
 func (o *PointerType) Import() string   { return "" }
 func (o *PointerType) BaseName() string { return "*" + o.Elem.BaseName() }
 
+func (r *RangeType) Import() string   { return r.PkgPath }
+func (r *RangeType) BaseName() string { return "Range[" + r.Elem.BaseName() + "]" }
+
+func (m *MultirangeType) Import() string   { return m.Range.PkgPath }
+func (m *MultirangeType) BaseName() string { return "Multirange[" + m.Range.Elem.BaseName() + "]" }
+
+func (n *NullType) Import() string   { return n.PkgPath }
+func (n *NullType) BaseName() string { return "Null[" + n.Elem.BaseName() + "]" }
+
 func (e *VoidType) Import() string   { return "" }
 func (e *VoidType) BaseName() string { return "" }
 
@@ -103,12 +181,22 @@ func getTypePackage(typ Type) string {
 		return ""
 	case *EnumType:
 		return ""
+	case *FixedArrayType:
+		return getTypePackage(typ.Elem)
 	case *ImportType:
 		return typ.PkgPath
+	case *MapType:
+		return ""
 	case *OpaqueType:
 		return ""
 	case *PointerType:
 		return getTypePackage(typ.Elem)
+	case *RangeType:
+		return typ.PkgPath
+	case *MultirangeType:
+		return typ.Range.PkgPath
+	case *NullType:
+		return typ.PkgPath
 	case *VoidType:
 		return ""
 	default:
@@ -117,36 +205,70 @@ func getTypePackage(typ Type) string {
 }
 
 func QualifyType(typ Type, otherPkgPath string) string {
-	sb := &strings.Builder{}
-	arrType, isArr := typ.(*ArrayType)
-	if isArr {
-		sb.WriteString("[]")
-		typ = arrType.Elem
-	}
-	ptrType, isPtr := typ.(*PointerType)
-	if isPtr {
-		sb.WriteString("*")
-		typ = ptrType.Elem
+	switch typ := typ.(type) {
+	case *ArrayType:
+		return "[]" + QualifyType(typ.Elem, otherPkgPath)
+	case *FixedArrayType:
+		return "[" + strconv.Itoa(typ.Len) + "]" + QualifyType(typ.Elem, otherPkgPath)
+	case *MapType:
+		return "map[" + QualifyType(typ.Key, otherPkgPath) + "]" + QualifyType(typ.Elem, otherPkgPath)
+	case *PointerType:
+		return "*" + QualifyType(typ.Elem, otherPkgPath)
+
+	// Generic types qualify the type argument.
+	case *RangeType:
+		return qualifyRange(typ, otherPkgPath)
+	case *MultirangeType:
+		if typ.Range.PkgPath != "" {
+			return ExtractShortPackage([]byte(typ.Range.PkgPath)) + ".Multirange[" + qualifyRange(typ.Range, otherPkgPath) + "]"
+		}
+		return "Multirange[" + QualifyType(typ.Range.Elem, otherPkgPath) + "]"
+	case *NullType:
+		prefix := ""
+		if typ.PkgPath != "" && typ.PkgPath != otherPkgPath {
+			prefix = ExtractShortPackage([]byte(typ.PkgPath)) + "."
+		}
+		return prefix + "Null[" + QualifyType(typ.Elem, otherPkgPath) + "]"
 	}
 
+	name := qualifyTypeArgs(typ, otherPkgPath)
 	pkg := getTypePackage(typ)
 	if typ.Import() == otherPkgPath || typ.Import() == "" || pkg == "" {
-		sb.WriteString(typ.BaseName())
-		return sb.String()
+		return name
 	}
 	if !strings.ContainsRune(otherPkgPath, '.') && pkg == otherPkgPath {
 		// If the otherPkgPath is unqualified and matches the package path, assume
 		// the same package.
+		return name
+	}
+	return ExtractShortPackage([]byte(pkg)) + "." + name
+}
+
+// qualifyTypeArgs returns the base name of a possibly generic type with
+// qualified type arguments, like Option[time.Time].
+func qualifyTypeArgs(typ Type, otherPkgPath string) string {
+	opaque, ok := typ.(*OpaqueType)
+	if imp, isImp := typ.(*ImportType); isImp {
+		opaque, ok = imp.Type.(*OpaqueType)
+	}
+	if !ok || len(opaque.TypeArgs) == 0 {
 		return typ.BaseName()
 	}
-	sb.Grow(len(typ.BaseName()))
-	if typ.Import() != "" {
-		shortPkg := ExtractShortPackage([]byte(pkg))
-		sb.WriteString(shortPkg)
-		sb.WriteRune('.')
+	args := make([]string, len(opaque.TypeArgs))
+	for i, arg := range opaque.TypeArgs {
+		args[i] = QualifyType(arg, otherPkgPath)
 	}
-	sb.WriteString(typ.BaseName())
-	return sb.String()
+	return opaque.Name + "[" + strings.Join(args, ", ") + "]"
+}
+
+// qualifyRange returns the generic Range type with the qualified bounds type,
+// like Range[time.Time] or pgtype.Range[time.Time].
+func qualifyRange(typ *RangeType, otherPkgPath string) string {
+	prefix := ""
+	if typ.PkgPath != "" {
+		prefix = ExtractShortPackage([]byte(typ.PkgPath)) + "."
+	}
+	return prefix + "Range[" + QualifyType(typ.Elem, otherPkgPath) + "]"
 }
 
 func NewArrayType(pgArray pg.ArrayType, elemType Type) Type {
@@ -156,8 +278,39 @@ func NewArrayType(pgArray pg.ArrayType, elemType Type) Type {
 	}
 }
 
+func NewRangeType(pgRange pg.RangeType, elemType Type, caser casing.Caser) *RangeType {
+	name := caser.ToUpperGoIdent(pgRange.Name)
+	if name == "" {
+		name = ChooseFallbackName(pgRange.Name, "UnnamedRange")
+	}
+	return &RangeType{
+		PgRange: pgRange,
+		Name:    name,
+		Elem:    elemType,
+	}
+}
+
+func NewMultirangeType(pgMulti pg.MultirangeType, rangeType *RangeType, caser casing.Caser) *MultirangeType {
+	name := caser.ToUpperGoIdent(pgMulti.Name)
+	if name == "" {
+		name = ChooseFallbackName(pgMulti.Name, "UnnamedMultirange")
+	}
+	return &MultirangeType{
+		PgMultirange: pgMulti,
+		Name:         name,
+		Range:        rangeType,
+	}
+}
+
 func NewEnumType(pkgPath string, pgEnum pg.EnumType, caser casing.Caser) Type {
-	name := caser.ToUpperGoIdent(pgEnum.Name)
+	return NewNamedEnumType(pkgPath, pgEnum, caser.ToUpperGoIdent(pgEnum.Name), caser)
+}
+
+// NewNamedEnumType creates an enum type with the Go type name instead of a
+// name derived from the Postgres enum name. Useful to disambiguate enums with
+// the same name in different schemas, like BillingStatus for billing.status.
+// Chooses a fallback name if name is empty.
+func NewNamedEnumType(pkgPath string, pgEnum pg.EnumType, name string, caser casing.Caser) Type {
 	if name == "" {
 		name = ChooseFallbackName(pgEnum.Name, "UnnamedEnum")
 	}
@@ -186,57 +339,43 @@ func NewEnumType(pkgPath string, pgEnum pg.EnumType, caser casing.Caser) Type {
 	return typ
 }
 
-// ParseOpaqueType creates a Type by parsing a fully qualified Go type like
-// "github.com/jschaf/custom.Int4" with the backing pg.Type.
+// ParseOpaqueType creates a Type by parsing a Go type expression with fully
+// qualified package paths, like "github.com/jschaf/custom.Int4", with the
+// backing pg.Type. Supports pointers, slices, fixed arrays, maps, and generic
+// types with type arguments:
 //
 //   - []int
 //   - []*int
 //   - *example.com/foo.Qux
 //   - []*example.com/foo.Qux
+//   - [16]byte
+//   - map[string]*example.com/foo.Qux
+//   - example.com/opt.Option[example.com/foo.Qux]
 func ParseOpaqueType(qualType string, pgType pg.Type) (Type, error) {
-	bs := []byte(qualType)
-	isArr := bs[0] == '['
-	if isArr {
-		if bs[1] != ']' {
-			return nil, fmt.Errorf("malformed custom type %q; must have closing bracket", qualType)
-		}
-		bs = bs[2:]
-	}
-	isPtr := bs[0] == '*'
-	if isPtr {
-		bs = bs[1:]
-	}
-	idx := bytes.LastIndexByte(bs, '.')
-	name := string(bs[idx+1:])
-	var typ Type = &OpaqueType{Name: name}
-	// On array types, the PgType goes on the Array. In all other cases, it
-	// goes on the OpaqueType.
-	if t, ok := typ.(*OpaqueType); ok && !isArr {
-		t.PgType = pgType
-	}
-
-	if isQualifiedType := idx != -1; isQualifiedType {
-		pkgPath := bs[:idx]
-		typ = &ImportType{
-			PkgPath: string(pkgPath),
-			Type:    typ,
-		}
+	p := &typeParser{src: qualType}
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, err
 	}
-
-	if isPtr {
-		typ = &PointerType{Elem: typ}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
 	}
 
-	if isArr {
+	// On array types, the PgType goes on the Array. In all other cases, it
+	// goes on the OpaqueType, if any.
+	if arr, ok := typ.(*ArrayType); ok {
 		pgArr, ok := pgType.(pg.ArrayType)
 		// Ensure that if we have a Go slice type that the Postgres type is also
 		// an array. []byte is special since it maps to the Postgres bytea type.
-		if !ok && pgType != nil && qualType != "[]byte" {
+		if !ok && pgType != nil && !IsByteSlice(arr) {
 			return nil, fmt.Errorf("opaque pg type %T{%+v} for go type %q is not a pg.ArrayType", pgType, pgType, qualType)
 		}
-		typ = &ArrayType{PgArray: pgArr, Elem: typ}
+		arr.PgArray = pgArr
+		return arr, nil
+	}
+	if opaque, ok := UnwrapNestedType(typ).(*OpaqueType); ok {
+		opaque.PgType = pgType
 	}
-
 	return typ, nil
 }
 
@@ -298,6 +437,20 @@ func UnwrapNestedType(typ Type) Type {
 	}
 }
 
+// ArrayDimensions returns the innermost element type of a possibly nested
+// array type and the number of dimensions, like int and 2 for [][]int.
+func ArrayDimensions(typ *ArrayType) (Type, int) {
+	elem, dims := typ.Elem, 1
+	for {
+		arr, ok := elem.(*ArrayType)
+		if !ok {
+			return elem, dims
+		}
+		elem = arr.Elem
+		dims++
+	}
+}
+
 // IsPgxSupportedArray returns true if pgx can handle the translation from the
 // Go array type into the Postgres type.
 func IsPgxSupportedArray(typ *ArrayType) bool {
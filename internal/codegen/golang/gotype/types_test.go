@@ -55,6 +55,47 @@ func TestMustParseKnownType(t *testing.T) {
 				Elem: &ImportType{PkgPath: "util/custom/times", Type: &OpaqueType{Name: "Interval"}},
 			},
 		},
+		{
+			qualType: "[16]byte",
+			want:     &FixedArrayType{Len: 16, Elem: &OpaqueType{Name: "byte"}},
+		},
+		{
+			qualType: "map[string]string",
+			want:     &MapType{Key: &OpaqueType{Name: "string"}, Elem: &OpaqueType{Name: "string"}},
+		},
+		{
+			qualType: "map[string][]*example.com/foo.Qux",
+			want: &MapType{
+				Key: &OpaqueType{Name: "string"},
+				Elem: &ArrayType{
+					Elem: &PointerType{
+						Elem: &ImportType{PkgPath: "example.com/foo", Type: &OpaqueType{Name: "Qux"}},
+					},
+				},
+			},
+		},
+		{
+			qualType: "github.com/x/opt.Option[int]",
+			want: &ImportType{
+				PkgPath: "github.com/x/opt",
+				Type:    &OpaqueType{Name: "Option", TypeArgs: []Type{&OpaqueType{Name: "int"}}},
+			},
+		},
+		{
+			qualType: "*example.com/pair.Pair[time.Time, map[string]example.com/foo.Qux]",
+			want: &PointerType{
+				Elem: &ImportType{
+					PkgPath: "example.com/pair",
+					Type: &OpaqueType{Name: "Pair", TypeArgs: []Type{
+						&ImportType{PkgPath: "time", Type: &OpaqueType{Name: "Time"}},
+						&MapType{
+							Key:  &OpaqueType{Name: "string"},
+							Elem: &ImportType{PkgPath: "example.com/foo", Type: &OpaqueType{Name: "Qux"}},
+						},
+					}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.qualType, func(t *testing.T) {
@@ -66,6 +107,29 @@ func TestMustParseKnownType(t *testing.T) {
 	}
 }
 
+func TestParseOpaqueType_Error(t *testing.T) {
+	tests := []struct {
+		qualType string
+		wantErr  string
+	}{
+		{"", "expected type name"},
+		{"[", "expected array length"},
+		{"[16byte", "expected ']' after array length"},
+		{"map[string", "expected ']' after map key type"},
+		{"example.com/opt.Option[int", "expected ',' or ']' after type argument"},
+		{"example.com/opt.Option[]", "expected type name"},
+		{".Foo", "empty package path"},
+		{"example.com/foo.1Foo", `invalid type name "1Foo"`},
+		{"int]", `unexpected "]"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.qualType, func(t *testing.T) {
+			_, err := ParseOpaqueType(tt.qualType, nil)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestQualifyType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -115,6 +179,48 @@ func TestQualifyType(t *testing.T) {
 			otherPkg: "example.com/foo",
 			want:     "[]Bar",
 		},
+		{
+			name:     "[][]foo.com/qux.Bar - example.com/foo",
+			typ:      &ArrayType{Elem: &ArrayType{Elem: &ImportType{PkgPath: "foo.com/qux", Type: &OpaqueType{Name: "Bar"}}}},
+			otherPkg: "example.com/foo",
+			want:     "[][]qux.Bar",
+		},
+		{
+			name:     "[][]*string",
+			typ:      &ArrayType{Elem: &ArrayType{Elem: &PointerType{Elem: &OpaqueType{Name: "string"}}}},
+			otherPkg: "example.com/foo",
+			want:     "[][]*string",
+		},
+		{
+			name:     "[16]byte",
+			typ:      MustParseOpaqueType("[16]byte"),
+			otherPkg: "example.com/foo",
+			want:     "[16]byte",
+		},
+		{
+			name:     "map[string]*foo.com/qux.Bar - example.com/foo",
+			typ:      MustParseOpaqueType("map[string]*foo.com/qux.Bar"),
+			otherPkg: "example.com/foo",
+			want:     "map[string]*qux.Bar",
+		},
+		{
+			name:     "*[]foo.com/qux.Bar - example.com/foo",
+			typ:      MustParseOpaqueType("*[]foo.com/qux.Bar"),
+			otherPkg: "example.com/foo",
+			want:     "*[]qux.Bar",
+		},
+		{
+			name:     "foo.com/opt.Option[time.Time] - example.com/foo",
+			typ:      MustParseOpaqueType("foo.com/opt.Option[time.Time]"),
+			otherPkg: "example.com/foo",
+			want:     "opt.Option[time.Time]",
+		},
+		{
+			name:     "example.com/foo.Pair[example.com/foo.Bar, foo.com/qux.Baz] - example.com/foo",
+			typ:      MustParseOpaqueType("example.com/foo.Pair[example.com/foo.Bar, foo.com/qux.Baz]"),
+			otherPkg: "example.com/foo",
+			want:     "Pair[Bar, qux.Baz]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -25,12 +25,38 @@ func (s *ImportSet) AddPackage(p string) {
 // types.
 func (s *ImportSet) AddType(typ gotype.Type) {
 	s.AddPackage(typ.Import())
-	comp, ok := typ.(*gotype.CompositeType)
-	if !ok {
-		return
-	}
-	for _, childType := range comp.FieldTypes {
-		s.AddType(childType)
+	switch typ := typ.(type) {
+	case *gotype.ArrayType:
+		// A composite type imports the packages of its fields where the composite
+		// type is declared, not where the array is used.
+		if _, ok := gotype.UnwrapNestedType(typ.Elem).(*gotype.CompositeType); !ok {
+			s.AddType(typ.Elem)
+		}
+	case *gotype.FixedArrayType:
+		s.AddType(typ.Elem)
+	case *gotype.MapType:
+		s.AddType(typ.Key)
+		s.AddType(typ.Elem)
+	case *gotype.ImportType:
+		if opaque, ok := typ.Type.(*gotype.OpaqueType); ok {
+			s.AddType(opaque)
+		}
+	case *gotype.OpaqueType:
+		for _, arg := range typ.TypeArgs {
+			s.AddType(arg)
+		}
+	case *gotype.CompositeType:
+		for _, childType := range typ.FieldTypes {
+			s.AddType(childType)
+		}
+	case *gotype.RangeType:
+		s.AddType(typ.Elem)
+	case *gotype.MultirangeType:
+		s.AddType(typ.Range.Elem)
+	case *gotype.NullType:
+		s.AddType(typ.Elem)
+	case *gotype.PointerType:
+		s.AddType(typ.Elem)
 	}
 }
 
@@ -0,0 +1,562 @@
+package golang
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
+	"golang.org/x/tools/go/packages"
+)
+
+// goTypeOverride is a user-provided Go type for a Postgres type, query param,
+// or output column, like from --go-type.
+type goTypeOverride struct {
+	// Where the user provided the override for error messages, like
+	// `type override "uuid=example.com/ids.ID"`.
+	source string
+	goType string // Go type expression, like "*example.com/ids.ID"
+	// The Postgres type of the override, like int8, or nil if unknown, like
+	// for a type that no query uses.
+	pgType pg.Type
+	// If true, the generated code encodes params or decodes output columns
+	// with the Go type. A type override sets both.
+	isParam  bool
+	isResult bool
+	// If true, the generated code encodes and decodes the Go type with
+	// encoding/json, so the Go type doesn't need to implement any interface.
+	isJSON bool
+}
+
+// listGoTypeOverrides lists the Go type overrides from the type overrides,
+// column type overrides, and go-type pragmas, sorted by source. Doesn't list
+// types from type presets.
+func listGoTypeOverrides(opts GenerateOptions, files []codegen.QueryFile) []goTypeOverride {
+	// Find the Postgres type and usage of each param and column that a column
+	// type override or go-type pragma might refer to.
+	targets := make(map[string]goTypeOverride)
+	addTarget := func(key string, pgType pg.Type, isParam bool) {
+		target := targets[key]
+		target.pgType = pgType
+		target.isParam = target.isParam || isParam
+		target.isResult = target.isResult || !isParam
+		target.isJSON = target.isJSON || isJSONPgType(pgType)
+		targets[key] = target
+	}
+	for _, file := range files {
+		for _, query := range file.Queries {
+			for _, input := range query.Inputs {
+				addTarget(query.Name+"."+input.PgName, input.PgType, true)
+			}
+			for _, out := range query.Outputs {
+				addTarget(query.Name+"."+out.PgName, out.PgType, false)
+				if out.TableName != "" {
+					addTarget(out.TableName+"."+out.TableColumn, out.PgType, false)
+				}
+			}
+		}
+	}
+
+	overrides := make([]goTypeOverride, 0, len(opts.TypeOverrides)+len(opts.ColumnTypeOverrides))
+	for pgName, goType := range opts.TypeOverrides {
+		overrides = append(overrides, goTypeOverride{
+			source:   fmt.Sprintf("type override %q", pgName+"="+goType),
+			goType:   goType,
+			pgType:   findPgTypeByName(strings.TrimSuffix(pgName, "?"), files),
+			isParam:  true,
+			isResult: true,
+		})
+	}
+	for key, goType := range opts.ColumnTypeOverrides {
+		override := targets[key]
+		override.source = fmt.Sprintf("column type override %q", key+"="+goType)
+		override.goType = goType
+		overrides = append(overrides, override)
+	}
+	for _, file := range files {
+		for _, query := range file.Queries {
+			for name, goType := range query.GoTypes {
+				override := targets[query.Name+"."+name]
+				override.source = fmt.Sprintf("go-type pragma %q in query %s", name+":"+goType, query.Name)
+				override.goType = goType
+				overrides = append(overrides, override)
+			}
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].source < overrides[j].source })
+	return overrides
+}
+
+// findPgTypeByName finds the Postgres type named name, like "int8" or
+// "billing.status", from the params and output columns of the queries or the
+// known Postgres types. Returns nil if no query uses the type and the type
+// isn't a known type.
+func findPgTypeByName(name string, files []codegen.QueryFile) pg.Type {
+	matches := func(pgt pg.Type) bool {
+		return pgt != nil && (pgt.String() == name || pg.QualifiedName(pgt) == name)
+	}
+	for _, file := range files {
+		for _, query := range file.Queries {
+			for _, input := range query.Inputs {
+				if matches(input.PgType) {
+					return input.PgType
+				}
+			}
+			for _, out := range query.Outputs {
+				if matches(out.PgType) {
+					return out.PgType
+				}
+			}
+		}
+	}
+	for _, pgt := range pg.ListKnownTypes() {
+		if matches(pgt) {
+			return pgt
+		}
+	}
+	return nil
+}
+
+// loadTypesMode loads packages with go/packages to look up Go types. Type
+// checks from source instead of export data so that loading doesn't depend on
+// the export data format of the installed Go version.
+const loadTypesMode = packages.NeedName | packages.NeedTypes | packages.NeedImports |
+	packages.NeedDeps | packages.NeedSyntax
+
+// Packages that declare the interfaces the drivers use to encode and decode a
+// type override.
+const (
+	sqlPkgPath       = "database/sql"
+	sqlDriverPkgPath = "database/sql/driver"
+	pgtypePkgPath    = "github.com/jackc/pgtype"
+	pgtype5PkgPath   = "github.com/jackc/pgx/v5/pgtype"
+)
+
+// checkGoTypeOverrides loads the package of each Go type override with
+// go/packages from dir and checks that each named type exists, is exported,
+// and has the right number of type arguments. Also checks that the driver can
+// encode params from and decode columns into the type for the driver and pgx
+// version. Returns an error naming the source of the first invalid override.
+func checkGoTypeOverrides(dir string, overrides []goTypeOverride, driver Driver, pgxVersion int) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	goTypes := make([]gotype.Type, len(overrides))
+	pkgPaths := make(map[string]struct{})
+	for i, override := range overrides {
+		typ, err := gotype.ParseOpaqueType(override.goType, nil)
+		if err != nil {
+			return fmt.Errorf("%s: %w", override.source, err)
+		}
+		goTypes[i] = typ
+		listPkgPaths(typ, pkgPaths)
+	}
+	checker, err := newGoTypeChecker(dir, pkgPaths, driver, pgxVersion)
+	if err != nil {
+		return err
+	}
+	for i, override := range overrides {
+		if err := checker.check(goTypes[i], override); err != nil {
+			return fmt.Errorf("%s: %w", override.source, err)
+		}
+	}
+	return nil
+}
+
+// listPkgPaths adds the package paths of all named types in typ to pkgPaths.
+func listPkgPaths(typ gotype.Type, pkgPaths map[string]struct{}) {
+	switch typ := typ.(type) {
+	case *gotype.PointerType:
+		listPkgPaths(typ.Elem, pkgPaths)
+	case *gotype.ArrayType:
+		listPkgPaths(typ.Elem, pkgPaths)
+	case *gotype.FixedArrayType:
+		listPkgPaths(typ.Elem, pkgPaths)
+	case *gotype.MapType:
+		listPkgPaths(typ.Key, pkgPaths)
+		listPkgPaths(typ.Elem, pkgPaths)
+	case *gotype.ImportType:
+		pkgPaths[typ.PkgPath] = struct{}{}
+		listPkgPaths(typ.Type, pkgPaths)
+	case *gotype.OpaqueType:
+		for _, arg := range typ.TypeArgs {
+			listPkgPaths(arg, pkgPaths)
+		}
+	}
+}
+
+// goTypeChecker converts a parsed Go type override into a go/types type using
+// the loaded packages.
+type goTypeChecker struct {
+	pkgs       map[string]*packages.Package // loaded packages by package path
+	driver     Driver
+	pgxVersion int
+}
+
+// newGoTypeChecker loads pkgPaths with go/packages from dir, along with the
+// packages that declare the interfaces the driver uses to encode and decode a
+// named type for the driver and pgx version.
+func newGoTypeChecker(dir string, pkgPaths map[string]struct{}, driver Driver, pgxVersion int) (goTypeChecker, error) {
+	checker := goTypeChecker{
+		pkgs:       make(map[string]*packages.Package, len(pkgPaths)),
+		driver:     driver,
+		pgxVersion: pgxVersion,
+	}
+	if len(pkgPaths) == 0 {
+		return checker, nil
+	}
+	// Only a named type from a package can implement an interface, so only
+	// load the interface packages if there are packages to load. Load all
+	// packages at once so the types share the same package objects.
+	paths := make([]string, 0, len(pkgPaths)+3)
+	for pkgPath := range pkgPaths {
+		paths = append(paths, pkgPath)
+	}
+	paths = append(paths, sqlPkgPath, sqlDriverPkgPath)
+	switch {
+	case driver == DriverDatabaseSQL:
+	case pgxVersion == 5:
+		paths = append(paths, pgtype5PkgPath)
+	default:
+		paths = append(paths, pgtypePkgPath)
+	}
+	sort.Strings(paths)
+	cfg := &packages.Config{Mode: loadTypesMode, Dir: dir}
+	pkgs, err := packages.Load(cfg, paths...)
+	if err != nil {
+		return goTypeChecker{}, fmt.Errorf("load packages for go type overrides: %w", err)
+	}
+	for _, pkg := range pkgs {
+		checker.pkgs[pkg.PkgPath] = pkg
+	}
+	return checker, nil
+}
+
+// check checks that typ exists and, unless the generated code encodes and
+// decodes typ with encoding/json, that the driver can encode and decode typ.
+func (c goTypeChecker) check(typ gotype.Type, override goTypeOverride) error {
+	goType, err := c.convert(typ)
+	if err != nil {
+		return err
+	}
+	if override.isJSON {
+		return nil
+	}
+	return c.checkCodec(goType, override.pgType, override.isParam, override.isResult)
+}
+
+// convert converts typ into a go/types type. Returns an error if a named type
+// doesn't exist, isn't exported, or has the wrong number of type arguments.
+func (c goTypeChecker) convert(typ gotype.Type) (types.Type, error) {
+	switch typ := typ.(type) {
+	case *gotype.PointerType:
+		elem, err := c.convert(typ.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+	case *gotype.ArrayType:
+		elem, err := c.convert(typ.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSlice(elem), nil
+	case *gotype.FixedArrayType:
+		elem, err := c.convert(typ.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewArray(elem, int64(typ.Len)), nil
+	case *gotype.MapType:
+		key, err := c.convert(typ.Key)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := c.convert(typ.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMap(key, elem), nil
+	case *gotype.ImportType:
+		opaque, ok := typ.Type.(*gotype.OpaqueType)
+		if !ok {
+			return nil, fmt.Errorf("unsupported imported type %T", typ.Type)
+		}
+		pkg, ok := c.pkgs[typ.PkgPath]
+		if !ok {
+			return nil, fmt.Errorf("package %s not loaded", typ.PkgPath)
+		}
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("load package %s: %w", typ.PkgPath, pkg.Errors[0])
+		}
+		obj := pkg.Types.Scope().Lookup(opaque.Name)
+		if _, ok := obj.(*types.TypeName); !ok {
+			return nil, fmt.Errorf("type %s not found in package %s", opaque.Name, typ.PkgPath)
+		}
+		if !obj.Exported() {
+			return nil, fmt.Errorf("type %s in package %s is not exported", opaque.Name, typ.PkgPath)
+		}
+		return c.instantiate(obj.Type(), opaque)
+	case *gotype.OpaqueType:
+		obj, ok := types.Universe.Lookup(typ.Name).(*types.TypeName)
+		if !ok {
+			// An unqualified type declared in the package of the generated code.
+			// The package might not compile until pggen generates the code.
+			return types.Typ[types.Invalid], nil
+		}
+		return c.instantiate(obj.Type(), typ)
+	default:
+		return nil, fmt.Errorf("unsupported go type override %T", typ)
+	}
+}
+
+// instantiate instantiates a generic named type with the type arguments of
+// opaque. Returns an error if the number of type arguments doesn't match the
+// number of type parameters.
+func (c goTypeChecker) instantiate(typ types.Type, opaque *gotype.OpaqueType) (types.Type, error) {
+	numParams := 0
+	if named, ok := typ.(*types.Named); ok {
+		numParams = named.TypeParams().Len()
+	}
+	if len(opaque.TypeArgs) != numParams {
+		return nil, fmt.Errorf("type %s has %d type parameters but got %d type arguments",
+			opaque.Name, numParams, len(opaque.TypeArgs))
+	}
+	if numParams == 0 {
+		return typ, nil
+	}
+	args := make([]types.Type, len(opaque.TypeArgs))
+	validate := true // only validate type constraints if all type arguments are known
+	for i, arg := range opaque.TypeArgs {
+		argType, err := c.convert(arg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = argType
+		validate = validate && argType != types.Typ[types.Invalid]
+	}
+	inst, err := types.Instantiate(nil, typ, args, validate)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate type %s: %w", opaque.Name, err)
+	}
+	return inst, nil
+}
+
+// codecInterfaces are the interfaces the driver uses to encode a param from
+// a Go type or decode a column into a Go type. The driver encodes and decodes
+// other types, like int or a named string type, with reflection.
+type codecInterfaces struct {
+	encoders, decoders []*types.Interface
+	// Interfaces of types that encode and decode a Postgres type, like
+	// pgtype.Value for types registered in pgtype.ConnInfo.
+	values []*types.Interface
+	// Describes the interfaces for error messages, like "sql.Scanner".
+	encodersDesc, decodersDesc string
+}
+
+// codecInterfaces returns the interfaces the driver uses to encode and decode
+// a Go type. pgx v4 uses the pgtype encoders and decoders, database/sql
+// interfaces, and pgtype.Value types registered in pgtype.ConnInfo. pgx v5
+// uses the database/sql interfaces and the pgtype scanner and valuer
+// interfaces, like pgtype.TextScanner.
+func (c goTypeChecker) codecInterfaces() (codecInterfaces, error) {
+	var names [][2]string // pairs of package path and interface name
+	var ifaces codecInterfaces
+	switch {
+	case c.driver == DriverDatabaseSQL:
+		names = [][2]string{{sqlDriverPkgPath, "Valuer"}, {sqlPkgPath, "Scanner"}}
+		ifaces.encodersDesc = "driver.Valuer"
+		ifaces.decodersDesc = "sql.Scanner"
+	case c.pgxVersion == 5:
+		names = [][2]string{{sqlDriverPkgPath, "Valuer"}, {sqlPkgPath, "Scanner"}}
+		pkg, ok := c.pkgs[pgtype5PkgPath]
+		if !ok {
+			return codecInterfaces{}, fmt.Errorf("package %s not loaded", pgtype5PkgPath)
+		}
+		if len(pkg.Errors) > 0 {
+			return codecInterfaces{}, fmt.Errorf("load package %s: %w", pgtype5PkgPath, pkg.Errors[0])
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			if strings.HasSuffix(name, "Scanner") || strings.HasSuffix(name, "Valuer") {
+				if _, ok := scope.Lookup(name).Type().Underlying().(*types.Interface); ok {
+					names = append(names, [2]string{pgtype5PkgPath, name})
+				}
+			}
+		}
+		ifaces.encodersDesc = "driver.Valuer or a pgtype valuer interface, like pgtype.TextValuer"
+		ifaces.decodersDesc = "sql.Scanner or a pgtype scanner interface, like pgtype.TextScanner"
+	default:
+		names = [][2]string{
+			{pgtypePkgPath, "BinaryEncoder"}, {pgtypePkgPath, "TextEncoder"}, {sqlDriverPkgPath, "Valuer"},
+			{pgtypePkgPath, "BinaryDecoder"}, {pgtypePkgPath, "TextDecoder"}, {sqlPkgPath, "Scanner"},
+			{pgtypePkgPath, "Value"},
+		}
+		ifaces.encodersDesc = "pgtype.BinaryEncoder, pgtype.TextEncoder, driver.Valuer, or pgtype.Value"
+		ifaces.decodersDesc = "pgtype.BinaryDecoder, pgtype.TextDecoder, sql.Scanner, or pgtype.Value"
+	}
+	for _, name := range names {
+		iface, err := c.lookupInterface(name[0], name[1])
+		if err != nil {
+			return codecInterfaces{}, err
+		}
+		switch {
+		case name[1] == "Value":
+			ifaces.values = append(ifaces.values, iface)
+		case strings.HasSuffix(name[1], "Encoder"), strings.HasSuffix(name[1], "Valuer"):
+			ifaces.encoders = append(ifaces.encoders, iface)
+		default:
+			ifaces.decoders = append(ifaces.decoders, iface)
+		}
+	}
+	return ifaces, nil
+}
+
+// checkCodec checks that the driver can encode a param from typ if isParam
+// and decode a column into typ if isResult. A named type must implement one
+// of the driver's encoder and decoder interfaces unless the driver encodes
+// and decodes the type with reflection. The drivers use reflection for types
+// whose underlying type is a basic type that can represent pgType, like a
+// named string type for text, and for slices, maps, and time.Time. pgx v5 also
+// uses reflection for a struct type for a composite type. pgType is nil if
+// unknown.
+func (c goTypeChecker) checkCodec(typ types.Type, pgType pg.Type, isParam, isResult bool) error {
+	base := typ
+	for {
+		ptr, ok := base.(*types.Pointer)
+		if !ok {
+			break
+		}
+		base = ptr.Elem()
+	}
+	if base == types.Typ[types.Invalid] {
+		return nil // declared in the package of the generated code
+	}
+	for {
+		domain, ok := pgType.(pg.DomainType)
+		if !ok {
+			break
+		}
+		pgType = domain.BaseType
+	}
+
+	if named, ok := base.(*types.Named); ok && named.Obj().Pkg() != nil {
+		if obj := named.Obj(); obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return nil
+		}
+		ifaces, err := c.codecInterfaces()
+		if err != nil {
+			return err
+		}
+		// The generated code passes a param by value and scans a column into a
+		// pointer to the named type.
+		ptr := types.NewPointer(named)
+		isValue := implementsAny(ptr, ifaces.values)
+		encodes := !isParam || isValue || implementsAny(typ, ifaces.encoders)
+		decodes := !isResult || isValue || implementsAny(ptr, ifaces.decoders)
+		if encodes && decodes {
+			return nil
+		}
+		if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+			// pgx v5 encodes and decodes a composite type with the struct fields.
+			if _, isComposite := pgType.(pg.CompositeType); (isComposite || pgType == nil) && c.pgxVersion == 5 && c.driver == DriverPgx {
+				return nil
+			}
+			if !encodes {
+				return fmt.Errorf("type %s does not implement %s", types.TypeString(typ, nil), ifaces.encodersDesc)
+			}
+			return fmt.Errorf("type %s does not implement %s", types.TypeString(ptr, nil), ifaces.decodersDesc)
+		}
+	}
+
+	switch underlying := base.Underlying().(type) {
+	case *types.Basic:
+		return checkBasicKind(base, underlying, pgType)
+	case *types.Slice:
+		if arr, ok := pgType.(pg.ArrayType); ok && arr.Elem != nil {
+			if err := c.checkCodec(underlying.Elem(), arr.Elem, isParam, isResult); err != nil {
+				return fmt.Errorf("array element: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// implementsAny returns true if typ implements any of the interfaces.
+func implementsAny(typ types.Type, ifaces []*types.Interface) bool {
+	for _, iface := range ifaces {
+		if types.Implements(typ, iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBasicKind checks that the drivers can convert between the Postgres
+// type and typ, with the underlying basic type basic, using reflection. The
+// basic type must have the same kind, either bool, numeric, or string, as the
+// native Go type for the Postgres type, like a named string type for text.
+// Doesn't check Postgres types without a native Go type.
+func checkBasicKind(typ types.Type, basic *types.Basic, pgType pg.Type) error {
+	if pgType == nil {
+		return nil
+	}
+	native, ok := gotype.FindKnownTypeNative(pgType.OID())
+	if !ok {
+		return nil
+	}
+	opaque, ok := native.(*gotype.OpaqueType)
+	if !ok {
+		return nil
+	}
+	obj, ok := types.Universe.Lookup(opaque.Name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	nativeBasic, ok := obj.Type().(*types.Basic)
+	if !ok || basicKind(basic) == basicKind(nativeBasic) {
+		return nil
+	}
+	return fmt.Errorf("type %s has underlying type %s that can't represent Postgres type %s; want a %s type, like %s",
+		types.TypeString(typ, nil), basic.Name(), pgType, basicKind(nativeBasic), nativeBasic.Name())
+}
+
+// basicKind returns the kind of a basic type that the drivers convert with
+// reflection, like "numeric" for int32 or float64.
+func basicKind(basic *types.Basic) string {
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return "bool"
+	case info&types.IsNumeric != 0:
+		return "numeric"
+	case info&types.IsString != 0:
+		return "string"
+	default:
+		return basic.Name()
+	}
+}
+
+// lookupInterface returns the interface type named name in the loaded package
+// pkgPath, like Scanner in database/sql.
+func (c goTypeChecker) lookupInterface(pkgPath, name string) (*types.Interface, error) {
+	pkg, ok := c.pkgs[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package %s not loaded", pkgPath)
+	}
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("load package %s: %w", pkgPath, pkg.Errors[0])
+	}
+	obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in package %s", name, pkgPath)
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("type %s in package %s is not an interface", name, pkgPath)
+	}
+	return iface, nil
+}
@@ -0,0 +1,139 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pginfer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGoTypeOverrides(t *testing.T) {
+	opts := GenerateOptions{
+		TypeOverrides:       map[string]string{"uuid": "example.com/ids.ID", "int8?": "*int"},
+		ColumnTypeOverrides: map[string]string{"author.meta": "example.com/meta.Meta", "author.id": "int64"},
+	}
+	files := []codegen.QueryFile{{
+		Queries: []pginfer.TypedQuery{{
+			Name:   "FindAuthor",
+			Inputs: []pginfer.InputParam{{PgName: "filter", PgType: pg.JSONB}},
+			Outputs: []pginfer.OutputColumn{
+				{PgName: "meta", PgType: pg.JSONB, TableName: "author", TableColumn: "meta"},
+				{PgName: "id", PgType: pg.Int8, TableName: "author", TableColumn: "id"},
+			},
+			GoTypes: map[string]string{"filter": "example.com/meta.Filter"},
+		}},
+	}}
+	got := listGoTypeOverrides(opts, files)
+	want := []goTypeOverride{
+		{source: `column type override "author.id=int64"`, goType: "int64", pgType: pg.Int8, isResult: true},
+		{source: `column type override "author.meta=example.com/meta.Meta"`, goType: "example.com/meta.Meta", pgType: pg.JSONB, isResult: true, isJSON: true},
+		{source: `go-type pragma "filter:example.com/meta.Filter" in query FindAuthor`, goType: "example.com/meta.Filter", pgType: pg.JSONB, isParam: true, isJSON: true},
+		{source: `type override "int8?=*int"`, goType: "*int", pgType: pg.Int8, isParam: true, isResult: true},
+		{source: `type override "uuid=example.com/ids.ID"`, goType: "example.com/ids.ID", pgType: pg.UUID, isParam: true, isResult: true},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCheckGoTypeOverrides(t *testing.T) {
+	point := pg.CompositeType{ID: 16400, Name: "point", ColumnNames: []string{"x"}, ColumnTypes: []pg.Type{pg.Int8}}
+	tests := []struct {
+		name       string
+		goType     string
+		pgType     pg.Type
+		resultOnly bool
+		isJSON     bool
+		pgxVersion int
+		driver     Driver
+		wantErr    string
+	}{
+		{"builtin", "int", pg.Int8, false, false, 4, DriverPgx, ""},
+		{"time", "*time.Time", pg.Timestamptz, false, false, 4, DriverPgx, ""},
+		{"fixed array", "[16]byte", pg.UUID, false, false, 4, DriverPgx, ""},
+		{"map", "map[string]*string", nil, false, false, 4, DriverPgx, ""},
+		{"pgtype", "github.com/jackc/pgtype.Text", pg.Text, false, false, 4, DriverPgx, ""},
+		{"pgtype pgx5", "github.com/jackc/pgx/v5/pgtype.Text", pg.Text, false, false, 5, DriverPgx, ""},
+		{"scanner", "[]database/sql.NullString", pg.TextArray, false, false, 4, DriverPgx, ""},
+		{"scanner pgx5", "database/sql.NullString", pg.Text, false, false, 5, DriverPgx, ""},
+		{"generic", "database/sql.Null[int64]", pg.Int8, false, false, 0, DriverDatabaseSQL, ""},
+		{"same package", "DeviceType", nil, false, false, 4, DriverPgx, ""},
+		{"named int", "time.Month", pg.Int4, false, false, 4, DriverPgx, ""},
+		{"named string", "encoding/json.Number", pg.Text, false, false, 4, DriverPgx, ""},
+		{"named string unknown pg type", "encoding/json.Number", nil, false, false, 0, DriverDatabaseSQL, ""},
+		{"struct json", "sync.Mutex", pg.JSONB, false, true, 4, DriverPgx, ""},
+		{"struct pgx5 composite", "sync.Mutex", point, false, false, 5, DriverPgx, ""},
+		{"missing type", "time.Tim", nil, false, false, 4, DriverPgx, "type Tim not found in package time"},
+		{"unexported", "time.zone", nil, false, false, 4, DriverPgx, "type zone in package time is not exported"},
+		{"missing package", "example.com/nope.Type", nil, false, false, 4, DriverPgx, "load package example.com/nope"},
+		{"missing type args", "database/sql.Null", nil, false, false, 4, DriverPgx, "type Null has 1 type parameters but got 0 type arguments"},
+		{"extra type args", "time.Time[int]", nil, false, false, 4, DriverPgx, "type Time has 0 type parameters but got 1 type arguments"},
+		{"struct pgx4", "*sync.Mutex", pg.Int8, false, false, 4, DriverPgx, "type *sync.Mutex does not implement pgtype.BinaryEncoder, pgtype.TextEncoder, driver.Valuer, or pgtype.Value"},
+		{"struct pgx4 result", "*sync.Mutex", pg.Int8, true, false, 4, DriverPgx, "type *sync.Mutex does not implement pgtype.BinaryDecoder, pgtype.TextDecoder, sql.Scanner, or pgtype.Value"},
+		{"struct pgx5", "sync.Mutex", pg.Int8, false, false, 5, DriverPgx, "type sync.Mutex does not implement driver.Valuer or a pgtype valuer interface"},
+		{"struct pgx5 result", "sync.Mutex", pg.Int8, true, false, 5, DriverPgx, "type *sync.Mutex does not implement sql.Scanner or a pgtype scanner interface"},
+		{"struct database/sql", "sync.Mutex", pg.Int8, false, false, 0, DriverDatabaseSQL, "type sync.Mutex does not implement driver.Valuer"},
+		{"struct database/sql result", "sync.Mutex", pg.Int8, true, false, 0, DriverDatabaseSQL, "type *sync.Mutex does not implement sql.Scanner"},
+		{"scanner database/sql", "database/sql.NullString", pg.Text, false, false, 0, DriverDatabaseSQL, ""},
+		{"wrong scan signature pgx4", "text/scanner.Scanner", pg.Text, true, false, 4, DriverPgx, "type *text/scanner.Scanner does not implement pgtype.BinaryDecoder, pgtype.TextDecoder, sql.Scanner, or pgtype.Value"},
+		{"wrong scan signature database/sql", "text/scanner.Scanner", pg.Text, true, false, 0, DriverDatabaseSQL, "type *text/scanner.Scanner does not implement sql.Scanner"},
+		{"named string for int8", "encoding/json.Number", pg.Int8, false, false, 4, DriverPgx, "type encoding/json.Number has underlying type string that can't represent Postgres type int8; want a numeric type, like int"},
+		{"named string for int8 pgx5", "*encoding/json.Number", pg.Int8, false, false, 5, DriverPgx, "type encoding/json.Number has underlying type string that can't represent Postgres type int8"},
+		{"named int for text database/sql", "time.Month", pg.Text, false, false, 0, DriverDatabaseSQL, "type time.Month has underlying type int that can't represent Postgres type text; want a string type, like string"},
+		{"builtin for wrong type", "bool", pg.Int8, false, false, 4, DriverPgx, "type bool has underlying type bool that can't represent Postgres type int8"},
+		{"array elem", "[]encoding/json.Number", pg.Int8Array, false, false, 4, DriverPgx, "array element: type encoding/json.Number has underlying type string"},
+	}
+
+	// Load the packages once per driver and pgx version because loading type
+	// checks the packages from source.
+	type config struct {
+		driver     Driver
+		pgxVersion int
+	}
+	goTypes := make([]gotype.Type, len(tests))
+	pkgPaths := make(map[config]map[string]struct{})
+	for i, tt := range tests {
+		typ, err := gotype.ParseOpaqueType(tt.goType, nil)
+		require.NoError(t, err)
+		goTypes[i] = typ
+		cfg := config{driver: tt.driver, pgxVersion: tt.pgxVersion}
+		if pkgPaths[cfg] == nil {
+			pkgPaths[cfg] = make(map[string]struct{})
+		}
+		listPkgPaths(typ, pkgPaths[cfg])
+	}
+	checkers := make(map[config]goTypeChecker, len(pkgPaths))
+	for cfg, paths := range pkgPaths {
+		checker, err := newGoTypeChecker(".", paths, cfg.driver, cfg.pgxVersion)
+		require.NoError(t, err)
+		checkers[cfg] = checker
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := checkers[config{driver: tt.driver, pgxVersion: tt.pgxVersion}]
+			err := checker.check(goTypes[i], goTypeOverride{
+				pgType:   tt.pgType,
+				isParam:  !tt.resultOnly,
+				isResult: true,
+				isJSON:   tt.isJSON,
+			})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("source", func(t *testing.T) {
+		overrides := []goTypeOverride{
+			{source: `type override "foo=time.Time"`, goType: "time.Time"},
+			{source: `type override "bar=time.Tim"`, goType: "time.Tim"},
+		}
+		err := checkGoTypeOverrides(".", overrides, DriverPgx, 4)
+		require.ErrorContains(t, err, `type override "bar=time.Tim": type Tim not found in package time`)
+	})
+}
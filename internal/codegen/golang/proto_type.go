@@ -0,0 +1,271 @@
+package golang
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pginfer"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	timestamppbPkg = "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspbPkg  = "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// protoMessage is the Go struct generated by protoc-gen-go for a protobuf
+// message named by a proto-type pragma.
+type protoMessage struct {
+	protoName string                // full protobuf name, like "foo.bar.User"
+	pkgPath   string                // Go package path of the generated struct
+	goName    string                // name of the Go struct, like "User" or "User_Profile"
+	fields    map[string]protoField // keyed by protobuf field name, like "first_name"
+}
+
+// protoField is a field of a protoMessage.
+type protoField struct {
+	goName string     // name of the Go struct field, like "FirstName"
+	typ    types.Type // Go type of the struct field
+}
+
+// ProtoField describes how to scan an output column into a field of the Go
+// struct for a protobuf message.
+type ProtoField struct {
+	GoName string // name of the Go struct field, like "CreatedAt"
+	// Go type of a temporary variable to scan the column into before
+	// assigning the field, like "*time.Time". Empty if the column scans
+	// directly into the field.
+	ScanType string
+	// Format string to convert the temporary variable into the field value,
+	// like "timestamppb.New(*%s)". Only set if ScanType is set. The generated
+	// code only assigns the field if the temporary variable is not nil.
+	Convert string
+	// If true, the field is a repeated scalar field, like []string.
+	IsRepeated bool
+}
+
+// listProtoTypes lists the protobuf message names from the proto-type pragma
+// of all queries in files, sorted.
+func listProtoTypes(files []codegen.QueryFile) []string {
+	names := make(map[string]struct{})
+	for _, file := range files {
+		for _, query := range file.Queries {
+			if query.ProtobufType != "" {
+				names[query.ProtobufType] = struct{}{}
+			}
+		}
+	}
+	protoNames := make([]string, 0, len(names))
+	for name := range names {
+		protoNames = append(protoNames, name)
+	}
+	sort.Strings(protoNames)
+	return protoNames
+}
+
+// findProtoGoType finds the Go package path and Go struct name for a full
+// protobuf message name, like "foo.bar.User", using protoPkgs, a map from a
+// protobuf package, like "foo.bar", to the Go package path of the code
+// generated by protoc-gen-go. Prefers the longest matching protobuf package.
+// protoc-gen-go names the struct of a nested message by joining the message
+// names with an underscore, like "User_Profile" for "foo.bar.User.Profile".
+func findProtoGoType(protoName string, protoPkgs map[string]string) (pkgPath, goName string, err error) {
+	bestPkg := ""
+	for protoPkg, goPkg := range protoPkgs {
+		if strings.HasPrefix(protoName, protoPkg+".") && len(protoPkg) > len(bestPkg) {
+			bestPkg = protoPkg
+			pkgPath = goPkg
+		}
+	}
+	if bestPkg == "" {
+		return "", "", fmt.Errorf("no Go package for proto-type %s; add a proto Go package for the protobuf package", protoName)
+	}
+	msgName := strings.TrimPrefix(protoName, bestPkg+".")
+	return pkgPath, strings.ReplaceAll(msgName, ".", "_"), nil
+}
+
+// loadProtoMessages loads the Go structs for the protobuf messages named by
+// the proto-type pragma of any query in files. Loads the Go packages with
+// go/packages from dir. Returns the messages keyed by full protobuf name.
+func loadProtoMessages(dir string, protoPkgs map[string]string, files []codegen.QueryFile) (map[string]protoMessage, error) {
+	protoNames := listProtoTypes(files)
+	if len(protoNames) == 0 {
+		return nil, nil
+	}
+	type goTypeName struct{ pkgPath, goName string }
+	goTypes := make(map[string]goTypeName, len(protoNames))
+	pkgPaths := make(map[string]struct{})
+	for _, protoName := range protoNames {
+		pkgPath, goName, err := findProtoGoType(protoName, protoPkgs)
+		if err != nil {
+			return nil, err
+		}
+		goTypes[protoName] = goTypeName{pkgPath: pkgPath, goName: goName}
+		pkgPaths[pkgPath] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(pkgPaths))
+	for pkgPath := range pkgPaths {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+	cfg := &packages.Config{Mode: loadTypesMode, Dir: dir}
+	pkgs, err := packages.Load(cfg, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages for proto types: %w", err)
+	}
+	pkgsByPath := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		pkgsByPath[pkg.PkgPath] = pkg
+	}
+
+	msgs := make(map[string]protoMessage, len(protoNames))
+	for _, protoName := range protoNames {
+		goType := goTypes[protoName]
+		pkg, ok := pkgsByPath[goType.pkgPath]
+		if !ok {
+			return nil, fmt.Errorf("proto-type %s: package %s not loaded", protoName, goType.pkgPath)
+		}
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("proto-type %s: load package %s: %w", protoName, goType.pkgPath, pkg.Errors[0])
+		}
+		// The generated code refers to the package by the last element of the
+		// package path.
+		if shortPkg := gotype.ExtractShortPackage([]byte(pkg.PkgPath)); pkg.Name != shortPkg {
+			return nil, fmt.Errorf("proto-type %s: package %s has name %s but pggen requires the name %s",
+				protoName, pkg.PkgPath, pkg.Name, shortPkg)
+		}
+		obj, ok := pkg.Types.Scope().Lookup(goType.goName).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("proto-type %s: type %s not found in package %s", protoName, goType.goName, goType.pkgPath)
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("proto-type %s: type %s in package %s is not a struct", protoName, goType.goName, goType.pkgPath)
+		}
+		msgs[protoName] = newProtoMessage(protoName, goType.pkgPath, goType.goName, st)
+	}
+	return msgs, nil
+}
+
+// newProtoMessage creates a protoMessage from the Go struct generated by
+// protoc-gen-go. Finds the protobuf name of each field from the name option
+// of the protobuf struct tag, like `protobuf:"bytes,1,opt,name=first_name"`.
+// Skips fields without a protobuf struct tag, like oneof fields and the
+// internal state fields.
+func newProtoMessage(protoName, pkgPath, goName string, st *types.Struct) protoMessage {
+	fields := make(map[string]protoField, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, ok := reflect.StructTag(st.Tag(i)).Lookup("protobuf")
+		if !ok || !field.Exported() {
+			continue
+		}
+		for _, opt := range strings.Split(tag, ",") {
+			if name, ok := strings.CutPrefix(opt, "name="); ok {
+				fields[name] = protoField{goName: field.Name(), typ: field.Type()}
+				break
+			}
+		}
+	}
+	return protoMessage{protoName: protoName, pkgPath: pkgPath, goName: goName, fields: fields}
+}
+
+// wrapperTypes maps the name of each protobuf wrapper type in the wrapperspb
+// package to the Go type of the wrapped value and the wrapperspb constructor.
+//
+//nolint:gochecknoglobals
+var wrapperTypes = map[string]struct{ goType, constructor string }{
+	"BoolValue":   {"bool", "Bool"},
+	"Int32Value":  {"int32", "Int32"},
+	"Int64Value":  {"int64", "Int64"},
+	"UInt32Value": {"uint32", "UInt32"},
+	"UInt64Value": {"uint64", "UInt64"},
+	"FloatValue":  {"float32", "Float"},
+	"DoubleValue": {"float64", "Double"},
+	"StringValue": {"string", "String"},
+	"BytesValue":  {"[]byte", "Bytes"},
+}
+
+// templateProtoField finds the field of msg for the output column and
+// describes how to scan the column into the field. Returns the Go packages
+// the generated code needs to convert the column into the field.
+func templateProtoField(msg protoMessage, out pginfer.OutputColumn) (ProtoField, []string, error) {
+	field, ok := msg.fields[out.PgName]
+	if !ok {
+		return ProtoField{}, nil, fmt.Errorf("no field in message %s for column %s", msg.protoName, out.PgName)
+	}
+	unsupported := func() error {
+		return fmt.Errorf("field %s of message %s has unsupported type %s for column %s",
+			field.goName, msg.protoName, types.TypeString(field.typ, nil), out.PgName)
+	}
+	switch typ := field.typ.(type) {
+	case *types.Basic:
+		if !out.Nullable {
+			return ProtoField{GoName: field.goName}, nil, nil
+		}
+		// Scan a nullable column into a pointer so that NULL becomes the zero
+		// value of the field.
+		return ProtoField{GoName: field.goName, ScanType: "*" + typ.Name(), Convert: "*%s"}, nil, nil
+	case *types.Slice:
+		elem, ok := typ.Elem().(*types.Basic)
+		if !ok {
+			return ProtoField{}, nil, unsupported()
+		}
+		// A NULL bytea scans into a nil byte slice.
+		return ProtoField{GoName: field.goName, IsRepeated: elem.Kind() != types.Byte}, nil, nil
+	case *types.Pointer:
+		switch elem := typ.Elem().(type) {
+		case *types.Basic:
+			// An optional scalar field.
+			return ProtoField{GoName: field.goName}, nil, nil
+		case *types.Named:
+			obj := elem.Obj()
+			if obj.Pkg() == nil {
+				return ProtoField{}, nil, unsupported()
+			}
+			switch {
+			case obj.Pkg().Path() == timestamppbPkg && obj.Name() == "Timestamp":
+				if !isTimestampPgType(out.PgType) {
+					return ProtoField{}, nil, fmt.Errorf("field %s of message %s has type *timestamppb.Timestamp but column %s has Postgres type %s",
+						field.goName, msg.protoName, out.PgName, out.PgType.String())
+				}
+				return ProtoField{GoName: field.goName, ScanType: "*time.Time", Convert: "timestamppb.New(*%s)"},
+					[]string{"time", timestamppbPkg}, nil
+			case obj.Pkg().Path() == wrapperspbPkg:
+				wrapper, ok := wrapperTypes[obj.Name()]
+				if !ok {
+					return ProtoField{}, nil, unsupported()
+				}
+				if wrapper.goType == "[]byte" {
+					return ProtoField{GoName: field.goName, ScanType: "[]byte", Convert: "wrapperspb.Bytes(%s)"},
+						[]string{wrapperspbPkg}, nil
+				}
+				return ProtoField{GoName: field.goName, ScanType: "*" + wrapper.goType, Convert: "wrapperspb." + wrapper.constructor + "(*%s)"},
+					[]string{wrapperspbPkg}, nil
+			}
+		}
+	}
+	return ProtoField{}, nil, unsupported()
+}
+
+// isTimestampPgType returns true if pgt is a Postgres type that decodes into
+// a time.Time, or a domain over the type.
+func isTimestampPgType(pgt pg.Type) bool {
+	if domain, ok := pgt.(pg.DomainType); ok {
+		return isTimestampPgType(domain.BaseType)
+	}
+	switch pgt.OID() {
+	case pgtype.DateOID, pgtype.TimestampOID, pgtype.TimestamptzOID:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,195 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/ast"
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pginfer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const protoTestPkg = "github.com/jschaf/pggen/internal/codegen/golang/prototest"
+
+// newProtoTestDir creates a temp dir in the module so that go/packages can
+// load the prototest package from the dir.
+func newProtoTestDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("testdata", "proto_type")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	absDir, err := filepath.Abs(dir)
+	require.NoError(t, err)
+	return absDir
+}
+
+func TestGenerate_ProtoType(t *testing.T) {
+	tests := []struct {
+		name       string
+		pgxVersion int
+		driver     Driver
+		golden     string
+	}{
+		{"pgx4", 4, DriverPgx, "testdata/generate_proto_type.golden"},
+		{"pgx5", 5, DriverPgx, "testdata/generate_proto_type_pgx5.golden"},
+		{"database/sql", 0, DriverDatabaseSQL, "testdata/generate_proto_type_sql.golden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newProtoTestDir(t)
+			file := codegen.QueryFile{
+				SourcePath: filepath.Join(dir, "query.sql"),
+				Queries: []pginfer.TypedQuery{
+					{
+						Name:         "FindUser",
+						ResultKind:   ast.ResultKindOne,
+						PreparedSQL:  "SELECT id, name, email, created_at, age, tags, avatar FROM users WHERE id = $1;",
+						ProtobufType: "pggen.test.User",
+						Inputs: []pginfer.InputParam{
+							{PgName: "id", PgType: pg.Int8},
+						},
+						Outputs: []pginfer.OutputColumn{
+							{PgName: "id", PgType: pg.Int8},
+							{PgName: "name", PgType: pg.Text, Nullable: true},
+							{PgName: "email", PgType: pg.Text, Nullable: true},
+							{PgName: "created_at", PgType: pg.Timestamptz, Nullable: true},
+							{PgName: "age", PgType: pg.Int4, Nullable: true},
+							{PgName: "tags", PgType: pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}},
+							{PgName: "avatar", PgType: pg.Bytea, Nullable: true},
+						},
+					},
+					{
+						Name:         "ListProfiles",
+						ResultKind:   ast.ResultKindMany,
+						PreparedSQL:  "SELECT bio, karma, pg_sleep(0) FROM profile;",
+						ProtobufType: "pggen.test.User.Profile",
+						Outputs: []pginfer.OutputColumn{
+							{PgName: "bio", PgType: pg.Text},
+							{PgName: "karma", PgType: pg.Int8, Nullable: true},
+							{PgName: "pg_sleep", PgType: pg.Void},
+						},
+					},
+				},
+			}
+
+			err := Generate(GenerateOptions{
+				GoPkg:            "users",
+				OutputDir:        dir,
+				PgxVersion:       tt.pgxVersion,
+				Driver:           tt.driver,
+				InlineParamCount: 2,
+				ProtoGoPackages:  map[string]string{"pggen.test": protoTestPkg},
+			}, []codegen.QueryFile{file})
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join(dir, "query.sql.go"))
+			require.NoError(t, err)
+			if *update {
+				require.NoError(t, os.WriteFile(tt.golden, got, 0o644))
+				return
+			}
+			want, err := os.ReadFile(tt.golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func TestGenerate_ProtoTypeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		protoType string
+		kind      ast.ResultKind
+		outputs   []pginfer.OutputColumn
+		wantErr   string
+	}{
+		{
+			name:      "missing field",
+			protoType: "pggen.test.User",
+			kind:      ast.ResultKindOne,
+			outputs:   []pginfer.OutputColumn{{PgName: "nickname", PgType: pg.Text}},
+			wantErr:   "query FindUser proto-type pggen.test.User: no field in message pggen.test.User for column nickname",
+		},
+		{
+			name:      "unsupported field type",
+			protoType: "pggen.test.User",
+			kind:      ast.ResultKindOne,
+			outputs:   []pginfer.OutputColumn{{PgName: "profile", PgType: pg.Text}},
+			wantErr:   "field Profile of message pggen.test.User has unsupported type *" + protoTestPkg + ".User_Profile for column profile",
+		},
+		{
+			name:      "timestamp from text",
+			protoType: "pggen.test.User",
+			kind:      ast.ResultKindOne,
+			outputs:   []pginfer.OutputColumn{{PgName: "created_at", PgType: pg.Text}},
+			wantErr:   "field CreatedAt of message pggen.test.User has type *timestamppb.Timestamp but column created_at has Postgres type text",
+		},
+		{
+			name:      "exec query",
+			protoType: "pggen.test.User",
+			kind:      ast.ResultKindExec,
+			wantErr:   "query FindUser: proto-type requires a :one, :opt, :many, or :iter query",
+		},
+		{
+			name:      "unknown proto package",
+			protoType: "other.User",
+			kind:      ast.ResultKindOne,
+			wantErr:   "load proto types: no Go package for proto-type other.User",
+		},
+		{
+			name:      "unknown message",
+			protoType: "pggen.test.Author",
+			kind:      ast.ResultKindOne,
+			wantErr:   "load proto types: proto-type pggen.test.Author: type Author not found in package " + protoTestPkg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newProtoTestDir(t)
+			file := codegen.QueryFile{
+				SourcePath: filepath.Join(dir, "query.sql"),
+				Queries: []pginfer.TypedQuery{{
+					Name:         "FindUser",
+					ResultKind:   tt.kind,
+					PreparedSQL:  "SELECT 1;",
+					ProtobufType: tt.protoType,
+					Outputs:      tt.outputs,
+				}},
+			}
+			err := Generate(GenerateOptions{
+				OutputDir:       dir,
+				ProtoGoPackages: map[string]string{"pggen.test": protoTestPkg},
+			}, []codegen.QueryFile{file})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestFindProtoGoType(t *testing.T) {
+	protoPkgs := map[string]string{
+		"foo":     "example.com/foopb",
+		"foo.bar": "example.com/foobarpb",
+	}
+	tests := []struct {
+		protoName  string
+		wantPkg    string
+		wantGoName string
+	}{
+		{"foo.User", "example.com/foopb", "User"},
+		{"foo.bar.User", "example.com/foobarpb", "User"},
+		{"foo.bar.User.Profile", "example.com/foobarpb", "User_Profile"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.protoName, func(t *testing.T) {
+			pkgPath, goName, err := findProtoGoType(tt.protoName, protoPkgs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPkg, pkgPath)
+			assert.Equal(t, tt.wantGoName, goName)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package prototest mirrors the Go structs protoc-gen-go generates for
+// protobuf messages to test the proto-type pragma without a protoc
+// dependency. Only the struct fields and protobuf struct tags matter to pggen.
+package prototest
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// User mirrors the protoc-gen-go struct for:
+//
+//	message User {
+//	  int64 id = 1;
+//	  string name = 2;
+//	  google.protobuf.StringValue email = 3;
+//	  google.protobuf.Timestamp created_at = 4;
+//	  optional int32 age = 5;
+//	  repeated string tags = 6;
+//	  bytes avatar = 7;
+//	  Profile profile = 8;
+//	}
+type User struct {
+	Id        int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email     *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Age       *int32                  `protobuf:"varint,5,opt,name=age,proto3,oneof" json:"age,omitempty"`
+	Tags      []string                `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Avatar    []byte                  `protobuf:"bytes,7,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Profile   *User_Profile           `protobuf:"bytes,8,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+// User_Profile mirrors the protoc-gen-go struct for the nested message:
+//
+//	message Profile {
+//	  string bio = 1;
+//	  google.protobuf.Int64Value karma = 2;
+//	}
+type User_Profile struct { //nolint:revive,stylecheck
+	Bio   string                 `protobuf:"bytes,1,opt,name=bio,proto3" json:"bio,omitempty"`
+	Karma *wrapperspb.Int64Value `protobuf:"bytes,2,opt,name=karma,proto3" json:"karma,omitempty"`
+}
@@ -16,6 +16,19 @@ type TemplatedPackage struct {
 	Files []TemplatedFile // sorted lexicographically by path
 }
 
+// HasCopyFromQuery returns true if any query in the package is a :copyfrom
+// query. If so, genericConn must support CopyFrom.
+func (tp TemplatedPackage) HasCopyFromQuery() bool {
+	for _, file := range tp.Files {
+		for _, query := range file.Queries {
+			if query.ResultKind == ast.ResultKindCopyFrom {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // TemplatedFile is the Go version of a SQL query file with all information
 // needed to execute the codegen template.
 type TemplatedFile struct {
@@ -37,12 +50,42 @@ type TemplatedFile struct {
 type TemplatedQuery struct {
 	Name             string            // name of the query, from the comment preceding the query
 	SQLVarName       string            // name of the string variable containing the SQL
-	ResultKind       ast.ResultKind    // kind of result: :one, :many, or :exec
+	ResultKind       ast.ResultKind    // kind of result, like :one, :many, or :exec
 	Doc              string            // doc from the source query file, formatted for Go
 	PreparedSQL      string            // SQL query, ready to run with PREPARE statement
 	Inputs           []TemplatedParam  // input parameters to the query
 	Outputs          []TemplatedColumn // output columns of the query
 	InlineParamCount int               // inclusive count of params that will be inlined
+	PgxVersion       int               // major version of pgx for the generated code
+	Driver           Driver            // Go database API for the generated code
+	// Package-qualified Go type of the protobuf message to scan output rows
+	// into, like "userpb.User". Empty unless the query has a proto-type pragma.
+	ProtoType string
+	// The table and columns to insert into for a :copyfrom query.
+	CopyFrom ast.CopyFromTarget
+	// The number of rows to fetch at a time with a server-side cursor. If
+	// positive, the query has a cursor method that yields chunks of rows.
+	CursorSize int
+	// The number of rows an :exec or :execrows query must affect. If set, the
+	// query returns ErrUnexpectedRowsAffected for a different count.
+	AffectedRows *int
+	// The output column to key rows by for a :map query, like "author_id".
+	MapKey string
+	// The output column to group rows by for a :many query with a group-by
+	// pragma, like "author_id". Output columns named with ChildPrefix belong
+	// to a slice of child rows in the ChildField of each group.
+	GroupBy     string
+	ChildPrefix string // prefix of the child columns, like "books" for "books.title"
+	ChildField  string // name of the Go field for the child rows, like "Books"
+	// The Go type of the MapKey or GroupBy column without the pointer or
+	// Null[T] of a nullable column, like "int64" for "*int64".
+	KeyType string
+	// The name of the row struct shared with other queries, like "Author".
+	// Empty if the query declares its own row struct, like "FindAuthorRow".
+	RowType string
+	// Extra levels of indentation for code emitted inside nested funcs, like
+	// the fetch func of a cursor method.
+	indentDepth int
 }
 
 type TemplatedParam struct {
@@ -51,6 +94,8 @@ type TemplatedParam struct {
 	QualType  string // package-qualified Go type to use for this param
 	Type      gotype.Type
 	RawName   pginfer.InputParam
+	// If true, encode the Go type as JSON for a Postgres json or jsonb param.
+	IsJSON bool
 }
 
 type TemplatedColumn struct {
@@ -59,16 +104,26 @@ type TemplatedColumn struct {
 	LowerName string // name in Go-style (lowerCamelCase)
 	Type      gotype.Type
 	QualType  string // package qualified Go type to use for the column, like "pgtype.Text"
+	// If true, decode a Postgres json or jsonb column into the Go type as JSON.
+	IsJSON bool
+	// The field of the protobuf message for the column. Only set if the query
+	// has a proto-type pragma.
+	ProtoField ProtoField
+	// If true, the column belongs to the child rows of a group-by query.
+	IsChild bool
+	// If true, the Postgres column might be null.
+	Nullable bool
 }
 
 func (tf TemplatedFile) needsPgconnImport() bool {
 	if tf.IsLeader {
-		// Leader files define genericConn.Exec which returns pgconn.CommandTag.
+		// Leader files define genericConn.Exec which returns pgconn.CommandTag,
+		// or sql.Result for database/sql.
 		return true
 	}
 	for _, query := range tf.Queries {
 		if query.ResultKind == ast.ResultKindExec {
-			return true // :exec queries return pgconn.CommandTag
+			return true // :exec queries return pgconn.CommandTag or sql.Result
 		}
 	}
 	return false
@@ -86,6 +141,9 @@ func (tq TemplatedQuery) EmitPreparedSQL() string {
 // a name and type based on the number of params. For use in a method
 // definition.
 func (tq TemplatedQuery) EmitParams() string {
+	if tq.ResultKind == ast.ResultKindCopyFrom {
+		return ", params []" + tq.Name + "Params"
+	}
 	if !tq.isInlineParams() {
 		return ", params " + tq.Name + "Params"
 	}
@@ -152,52 +210,137 @@ func (tq TemplatedQuery) EmitParamStruct() string {
 // EmitParamNames emits the TemplatedQuery.Inputs into comma separated names
 // for use in a method invocation.
 func (tq TemplatedQuery) EmitParamNames() string {
-	appendParam := func(sb *strings.Builder, typ gotype.Type, name string) {
-		switch typ := gotype.UnwrapNestedType(typ).(type) {
-		case *gotype.CompositeType:
-			sb.WriteString("q.types.")
-			sb.WriteString(NameCompositeInitFunc(typ))
-			sb.WriteString("(")
-			sb.WriteString(name)
-			sb.WriteString(")")
-		case *gotype.ArrayType:
-			if gotype.IsPgxSupportedArray(typ) {
-				sb.WriteString(name)
-				break
-			}
-			switch gotype.UnwrapNestedType(typ.Elem).(type) {
-			case *gotype.CompositeType, *gotype.EnumType:
-				sb.WriteString("q.types.")
-				sb.WriteString(NameArrayInitFunc(typ))
-				sb.WriteString("(")
-				sb.WriteString(name)
-				sb.WriteString(")")
-			default:
-				sb.WriteString(name)
-			}
-		default:
-			sb.WriteString(name)
-		}
-	}
 	switch {
 	case tq.isInlineParams():
 		sb := &strings.Builder{}
 		for _, input := range tq.Inputs {
 			sb.WriteString(", ")
-			appendParam(sb, input.Type, input.LowerName)
+			tq.appendParamValue(sb, input, input.LowerName)
 		}
 		return sb.String()
 	default:
 		sb := &strings.Builder{}
 		for _, input := range tq.Inputs {
 			sb.WriteString(", ")
-			appendParam(sb, input.Type, "params."+input.UpperName)
+			tq.appendParamValue(sb, input, "params."+input.UpperName)
 		}
 		return sb.String()
 	}
 }
 
+// appendParamValue writes the expression to encode the input param with the
+// Go expression name, like "params.FirstName".
+func (tq TemplatedQuery) appendParamValue(sb *strings.Builder, input TemplatedParam, name string) {
+	typ := input.Type
+	switch {
+	case input.IsJSON:
+		sb.WriteString(emitJSONValue("param "+input.RawName.PgName, name))
+		return
+	case tq.Driver == DriverDatabaseSQL:
+		// Enums and composite types implement driver.Valuer. Wrap slices
+		// to encode the Postgres text format of arrays.
+		if arr, ok := gotype.UnwrapNestedType(typ).(*gotype.ArrayType); ok && !gotype.IsByteSlice(arr) {
+			sb.WriteString("newTextArray(")
+			sb.WriteString(name)
+			sb.WriteString(")")
+			return
+		}
+		sb.WriteString(name)
+		return
+	case tq.PgxVersion == 5:
+		// pgx v5 encodes Go types directly with the registered types.
+		sb.WriteString(name)
+		return
+	}
+	switch typ := gotype.UnwrapNestedType(typ).(type) {
+	case *gotype.CompositeType:
+		sb.WriteString("q.types.")
+		sb.WriteString(NameCompositeInitFunc(typ))
+		sb.WriteString("(")
+		sb.WriteString(name)
+		sb.WriteString(")")
+	case *gotype.RangeType:
+		sb.WriteString("q.types.")
+		sb.WriteString(NameRangeInitFunc(typ))
+		sb.WriteString("(")
+		sb.WriteString(name)
+		sb.WriteString(")")
+	case *gotype.MultirangeType:
+		sb.WriteString("q.types.")
+		sb.WriteString(NameMultirangeInitFunc(typ))
+		sb.WriteString("(")
+		sb.WriteString(name)
+		sb.WriteString(")")
+	case *gotype.ArrayType:
+		if gotype.IsPgxSupportedArray(typ) {
+			sb.WriteString(name)
+			break
+		}
+		if hasArrayTranscoder(typ) {
+			sb.WriteString("q.types.")
+			sb.WriteString(NameArrayInitFunc(typ))
+			sb.WriteString("(")
+			sb.WriteString(name)
+			sb.WriteString(")")
+		} else {
+			sb.WriteString(name)
+		}
+	default:
+		sb.WriteString(name)
+	}
+}
+
+// EmitCopyFromTable emits the pgx.Identifier of the table for a :copyfrom
+// query.
+func (tq TemplatedQuery) EmitCopyFromTable() string {
+	return "pgx.Identifier{" + joinQuoted(tq.CopyFrom.Table) + "}"
+}
+
+// EmitCopyFromColumns emits the column names for a :copyfrom query.
+func (tq TemplatedQuery) EmitCopyFromColumns() string {
+	return "[]string{" + joinQuoted(tq.CopyFrom.Columns) + "}"
+}
+
+// EmitCopyFromRow emits the comma separated values of a single row for a
+// :copyfrom query from the params struct with the Go expression name, like
+// "params[i]". Encodes values the same way as EmitParamNames.
+func (tq TemplatedQuery) EmitCopyFromRow(name string) string {
+	sb := &strings.Builder{}
+	for i, input := range tq.Inputs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		tq.appendParamValue(sb, input, name+"."+input.UpperName)
+	}
+	return sb.String()
+}
+
+// joinQuoted joins the Go string literal of each string in ss with commas.
+func joinQuoted(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = strconv.Quote(s)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// hasArrayTranscoder returns true if pgx v4 encodes and decodes the array
+// type with the transcoder declared by pggen, like for arrays of enums,
+// composite types, or user-provided Go types, instead of the array type
+// registered in pgtype.ConnInfo.
+func hasArrayTranscoder(typ *gotype.ArrayType) bool {
+	switch gotype.UnwrapNestedType(typ.Elem).(type) {
+	case *gotype.EnumType, *gotype.CompositeType, *gotype.ArrayType:
+		return true
+	default:
+		return gotype.HasOpaqueElem(typ)
+	}
+}
+
 func (tq TemplatedQuery) isInlineParams() bool {
+	if tq.ResultKind == ast.ResultKindCopyFrom {
+		return false // :copyfrom queries accept a slice of param structs
+	}
 	return len(tq.Inputs) <= tq.InlineParamCount
 }
 
@@ -207,42 +350,51 @@ func (tq TemplatedQuery) EmitRowScanArgs() (string, error) {
 	switch tq.ResultKind {
 	case ast.ResultKindExec:
 		return "", fmt.Errorf("cannot EmitRowScanArgs for :exec query %s", tq.Name)
-	case ast.ResultKindMany, ast.ResultKindOne:
+	case ast.ResultKindMany, ast.ResultKindOne, ast.ResultKindOpt, ast.ResultKindMap, ast.ResultKindIter:
 		break // okay
 	default:
 		return "", fmt.Errorf("unhandled EmitRowScanArgs type: %s", tq.ResultKind)
 	}
 
+	switch {
+	case tq.ProtoType != "":
+		return tq.emitRowScanArgsProto(), nil
+	case tq.Driver == DriverDatabaseSQL:
+		return tq.emitRowScanArgsDatabaseSQL(), nil
+	case tq.PgxVersion == 5:
+		return tq.emitRowScanArgsPgx5(), nil
+	}
+
 	hasOnlyOneNonVoid := len(removeVoidColumns(tq.Outputs)) == 1
 	sb := strings.Builder{}
 	sb.Grow(15 * len(tq.Outputs))
 	for i, out := range tq.Outputs {
+		if out.IsJSON {
+			sb.WriteString(emitJSONValue("column "+out.PgName, emitFieldDest(out, hasOnlyOneNonVoid)))
+			if i < len(tq.Outputs)-1 {
+				sb.WriteString(", ")
+			}
+			continue
+		}
 		switch typ := gotype.UnwrapNestedType(out.Type).(type) {
 		case *gotype.ArrayType:
-			switch gotype.UnwrapNestedType(typ.Elem).(type) {
-			case *gotype.EnumType, *gotype.CompositeType:
+			if hasArrayTranscoder(typ) {
 				sb.WriteString(out.LowerName)
 				sb.WriteString("Array")
-			default:
-				if hasOnlyOneNonVoid {
-					sb.WriteString("&item")
-				} else {
-					sb.WriteString("&item.")
-					sb.WriteString(out.UpperName)
-				}
+			} else {
+				sb.WriteString(emitFieldDest(out, hasOnlyOneNonVoid))
 			}
 
 		case *gotype.CompositeType:
 			sb.WriteString(out.LowerName)
 			sb.WriteString("Row")
 
-		case *gotype.EnumType, *gotype.OpaqueType:
-			if hasOnlyOneNonVoid {
-				sb.WriteString("&item")
-			} else {
-				sb.WriteString("&item.")
-				sb.WriteString(out.UpperName)
-			}
+		case *gotype.RangeType, *gotype.MultirangeType:
+			sb.WriteString(out.LowerName)
+			sb.WriteString("Range")
+
+		case *gotype.EnumType, *gotype.OpaqueType, *gotype.NullType, *gotype.MapType, *gotype.FixedArrayType:
+			sb.WriteString(emitFieldDest(out, hasOnlyOneNonVoid))
 
 		case *gotype.VoidType:
 			sb.WriteString("nil")
@@ -257,31 +409,404 @@ func (tq TemplatedQuery) EmitRowScanArgs() (string, error) {
 	return sb.String(), nil
 }
 
+// emitFieldDest emits the address of the Go value to scan the output column
+// into, like "&item.FirstName". A query with a single non-void column scans
+// directly into the item. The child columns of a group-by query scan into the
+// child row, like "&child.Title".
+func emitFieldDest(out TemplatedColumn, hasOnlyOneNonVoid bool) string {
+	switch {
+	case hasOnlyOneNonVoid:
+		return "&item"
+	case out.IsChild:
+		return "&child." + out.UpperName
+	default:
+		return "&item." + out.UpperName
+	}
+}
+
+// emitRowScanArgsPgx5 emits the args to scan a single row for pgx v5. pgx v5
+// decodes enums, composite types, and arrays directly into the Go types using
+// the types loaded by RegisterTypes.
+func (tq TemplatedQuery) emitRowScanArgsPgx5() string {
+	hasOnlyOneNonVoid := len(removeVoidColumns(tq.Outputs)) == 1
+	sb := strings.Builder{}
+	sb.Grow(15 * len(tq.Outputs))
+	for i, out := range tq.Outputs {
+		dest := emitFieldDest(out, hasOnlyOneNonVoid)
+		_, isVoid := out.Type.(*gotype.VoidType)
+		switch {
+		case isVoid:
+			sb.WriteString("nil")
+		case out.IsJSON:
+			sb.WriteString(emitJSONValue("column "+out.PgName, dest))
+		default:
+			sb.WriteString(dest)
+		}
+		if i < len(tq.Outputs)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	return sb.String()
+}
+
+// emitRowScanArgsDatabaseSQL emits the args to scan a single row for
+// database/sql. Enums and composite types implement sql.Scanner. Slices are
+// wrapped to scan the Postgres text format of arrays. database/sql requires a
+// destination for every column, so void columns scan into a throwaway value.
+func (tq TemplatedQuery) emitRowScanArgsDatabaseSQL() string {
+	hasOnlyOneNonVoid := len(removeVoidColumns(tq.Outputs)) == 1
+	sb := strings.Builder{}
+	sb.Grow(15 * len(tq.Outputs))
+	for i, out := range tq.Outputs {
+		dest := emitFieldDest(out, hasOnlyOneNonVoid)
+		if out.IsJSON {
+			sb.WriteString(emitJSONValue("column "+out.PgName, dest))
+			if i < len(tq.Outputs)-1 {
+				sb.WriteString(", ")
+			}
+			continue
+		}
+		switch typ := gotype.UnwrapNestedType(out.Type).(type) {
+		case *gotype.VoidType:
+			sb.WriteString("new(any)")
+		case *gotype.ArrayType:
+			if gotype.IsByteSlice(typ) {
+				sb.WriteString(dest)
+				break
+			}
+			sb.WriteString("scanTextArray(")
+			sb.WriteString(dest)
+			sb.WriteString(")")
+		default:
+			sb.WriteString(dest)
+		}
+		if i < len(tq.Outputs)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	return sb.String()
+}
+
+// emitRowScanArgsProto emits the args to scan a single row into the Go struct
+// for a protobuf message. Columns that need a conversion, like a timestamp
+// into a *timestamppb.Timestamp, scan into a temporary variable declared by
+// EmitResultDecoders.
+func (tq TemplatedQuery) emitRowScanArgsProto() string {
+	sb := strings.Builder{}
+	sb.Grow(15 * len(tq.Outputs))
+	for i, out := range tq.Outputs {
+		_, isVoid := out.Type.(*gotype.VoidType)
+		switch {
+		case isVoid && tq.Driver == DriverDatabaseSQL:
+			sb.WriteString("new(any)")
+		case isVoid:
+			sb.WriteString("nil")
+		case out.ProtoField.ScanType != "":
+			sb.WriteString("&")
+			sb.WriteString(out.LowerName)
+			sb.WriteString("Value")
+		case out.ProtoField.IsRepeated && tq.Driver == DriverDatabaseSQL:
+			sb.WriteString("scanTextArray(&item.")
+			sb.WriteString(out.ProtoField.GoName)
+			sb.WriteString(")")
+		default:
+			sb.WriteString("&item.")
+			sb.WriteString(out.ProtoField.GoName)
+		}
+		if i < len(tq.Outputs)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	return sb.String()
+}
+
+// emitJSONValue emits a jsonValue that encodes or decodes the Go value expr
+// as JSON. The name identifies the param or column in error messages.
+func emitJSONValue(name, expr string) string {
+	return "jsonValue{name: " + strconv.Quote(name) + ", v: " + expr + "}"
+}
+
+// EmitResults returns the results of the query method, like
+// "(FindAuthorsRow, error)". An :iter query returns an iterator that yields
+// each row with an error, like "iter.Seq2[FindAuthorsRow, error]".
+func (tq TemplatedQuery) EmitResults() (string, error) {
+	result, err := tq.EmitResultType()
+	if err != nil {
+		return "", err
+	}
+	if tq.ResultKind == ast.ResultKindIter {
+		return result, nil
+	}
+	return "(" + result + ", error)", nil
+}
+
+// EmitIterElem returns the type of each row yielded by an :iter query. Same as
+// the element type of the slice returned by the equivalent :many query.
+func (tq TemplatedQuery) EmitIterElem() (string, error) {
+	many := tq
+	many.ResultKind = ast.ResultKindMany
+	result, err := many.EmitResultType()
+	if err != nil {
+		return "", fmt.Errorf("create result type for EmitIterElem: %w", err)
+	}
+	return strings.TrimPrefix(result, "[]"), nil
+}
+
+// asMany returns a copy of the query with the :many result kind. Useful
+// because the values of a :map query are the elements of a :many query.
+func (tq TemplatedQuery) asMany() TemplatedQuery {
+	many := tq
+	many.ResultKind = ast.ResultKindMany
+	return many
+}
+
+// findOutput finds the output column with the Postgres name.
+func (tq TemplatedQuery) findOutput(pgName string) (TemplatedColumn, bool) {
+	for _, out := range tq.Outputs {
+		if out.PgName == pgName {
+			return out, true
+		}
+	}
+	return TemplatedColumn{}, false
+}
+
+// EmitChildDecl declares the child row scanned by a group-by query, like
+// "var child ListAuthorsBooksRow". Empty unless the query has a group-by
+// pragma.
+func (tq TemplatedQuery) EmitChildDecl() string {
+	if tq.GroupBy == "" {
+		return ""
+	}
+	return "\n\t\t" + strings.Repeat("\t", tq.indentDepth) + "var child " + tq.childRowName()
+}
+
+// rowName returns the name of the struct for output rows, like
+// "FindAuthorRow" or the row type "Author".
+func (tq TemplatedQuery) rowName() string {
+	if tq.RowType != "" {
+		return tq.RowType
+	}
+	return tq.Name + "Row"
+}
+
+// childRowName returns the name of the struct for the child rows of a
+// group-by query, like "ListAuthorsBooksRow".
+func (tq TemplatedQuery) childRowName() string {
+	return tq.Name + tq.ChildField + "Row"
+}
+
+// EmitResultAppend emits the statements to add the scanned item to items in
+// the for loop of a :many or :map query.
+//
+// A :map query adds the item by key and errors on a duplicate key. A group-by
+// query adds the item if it starts a new group, then appends the child row to
+// the group. Both error if the key of a nullable column is NULL. A LEFT JOIN
+// without a matching child returns NULL for every child column, so skip the
+// child if every nullable child column is NULL.
+func (tq TemplatedQuery) EmitResultAppend() (string, error) {
+	indent := "\n\t\t" + strings.Repeat("\t", tq.indentDepth)
+	expr, err := tq.EmitResultExpr("item")
+	if err != nil {
+		return "", fmt.Errorf("create result expr for EmitResultAppend: %w", err)
+	}
+	hasOnlyOneNonVoid := len(removeVoidColumns(tq.Outputs)) == 1
+	sb := &strings.Builder{}
+	switch {
+	case tq.ResultKind == ast.ResultKindMap:
+		out, ok := tq.findOutput(tq.MapKey)
+		if !ok {
+			return "", fmt.Errorf("no output column for key %q in query %s", tq.MapKey, tq.Name)
+		}
+		key := tq.emitKeyValue(sb, out, "key", hasOnlyOneNonVoid)
+		sb.WriteString(indent + "if _, ok := items[" + key + "]; ok {")
+		sb.WriteString(indent + "\treturn nil, fmt.Errorf(\"query " + tq.Name + ": %w: %v\", ErrDuplicateKey, " + key + ")")
+		sb.WriteString(indent + "}")
+		sb.WriteString(indent + "items[" + key + "] = " + expr)
+
+	case tq.GroupBy != "":
+		out, ok := tq.findOutput(tq.GroupBy)
+		if !ok {
+			return "", fmt.Errorf("no output column for group-by %q in query %s", tq.GroupBy, tq.Name)
+		}
+		key := tq.emitKeyValue(sb, out, "group-by", hasOnlyOneNonVoid)
+		field := "items[i]." + tq.ChildField
+		sb.WriteString(indent + "i, ok := index[" + key + "]")
+		sb.WriteString(indent + "if !ok {")
+		sb.WriteString(indent + "\ti = len(items)")
+		sb.WriteString(indent + "\tindex[" + key + "] = i")
+		sb.WriteString(indent + "\titem." + tq.ChildField + " = []" + tq.childRowName() + "{}")
+		sb.WriteString(indent + "\titems = append(items, " + expr + ")")
+		sb.WriteString(indent + "}")
+		// An outer join without a matching child row sets every child column
+		// to NULL, so a child exists if any nullable child column is not NULL.
+		// Non-nullable child columns, like a COALESCE, don't tell if the child
+		// exists.
+		var nonNils []string
+		for _, out := range removeVoidColumns(tq.Outputs) {
+			if !out.IsChild || !out.Nullable {
+				continue
+			}
+			if notNull := emitNotNullCheck("child."+out.UpperName, out.Type); notNull != "" {
+				nonNils = append(nonNils, notNull)
+			}
+		}
+		if len(nonNils) == 0 {
+			sb.WriteString(indent + field + " = append(" + field + ", child)")
+			break
+		}
+		sb.WriteString(indent + "if " + strings.Join(nonNils, " || ") + " {")
+		sb.WriteString(indent + "\t" + field + " = append(" + field + ", child)")
+		sb.WriteString(indent + "}")
+
+	default:
+		sb.WriteString(indent + "items = append(items, " + expr + ")")
+	}
+	return sb.String(), nil
+}
+
+// emitKeyValue returns the expression for the key of the scanned item for a
+// :map or group-by query. If the key column is nullable, emits a check that
+// errors if the key is NULL and returns the non-NULL value, like "*item.ID".
+func (tq TemplatedQuery) emitKeyValue(sb *strings.Builder, out TemplatedColumn, pragma string, hasOnlyOneNonVoid bool) string {
+	indent := "\n\t\t" + strings.Repeat("\t", tq.indentDepth)
+	key := strings.TrimPrefix(emitFieldDest(out, hasOnlyOneNonVoid), "&")
+	isNull, value := emitNullCheck(key, out.Type)
+	if isNull == "" {
+		return key
+	}
+	sb.WriteString(indent + "if " + isNull + " {")
+	sb.WriteString(indent + "\treturn nil, fmt.Errorf(\"query " + tq.Name + ": " + pragma + " column " + out.PgName + " is NULL\")")
+	sb.WriteString(indent + "}")
+	return value
+}
+
+// emitNullCheck returns the condition that the value of expr with Go type typ
+// is NULL and the expression for the non-NULL value. Returns an empty
+// condition if typ is not a pointer or Null[T].
+func emitNullCheck(expr string, typ gotype.Type) (isNull string, value string) {
+	switch typ.(type) {
+	case *gotype.PointerType:
+		return expr + " == nil", "*" + expr
+	case *gotype.NullType:
+		return "!" + expr + ".Valid", expr + ".V"
+	default:
+		return "", expr
+	}
+}
+
+// emitNotNullCheck returns the condition that the value of expr with Go type
+// typ is not NULL. Returns an empty string if typ can't represent NULL, like an
+// opaque type with its own validity field.
+func emitNotNullCheck(expr string, typ gotype.Type) string {
+	switch typ.(type) {
+	case *gotype.PointerType, *gotype.ArrayType:
+		return expr + " != nil"
+	case *gotype.NullType:
+		return expr + ".Valid"
+	default:
+		return ""
+	}
+}
+
+// HasBatchMethods returns true if the query has Queue and Scan methods to send
+// the query in a pgx.Batch. :copyfrom queries use the COPY protocol and :iter
+// queries stream rows, so neither works with a batch.
+func (tq TemplatedQuery) HasBatchMethods() bool {
+	return tq.ResultKind != ast.ResultKindCopyFrom && tq.ResultKind != ast.ResultKindIter
+}
+
+// CursorChunk returns the query to fetch a single chunk of rows from the
+// server-side cursor. Each chunk is like the result of a :many query scanned
+// in the fetch func of the cursor method.
+func (tq TemplatedQuery) CursorChunk() TemplatedQuery {
+	chunk := tq
+	chunk.ResultKind = ast.ResultKindMany
+	chunk.indentDepth = 2 // the fetch func is in the func returned by the cursor method
+	return chunk
+}
+
+// EmitCursorResults returns the results of the cursor method, an iterator
+// that yields chunks of rows, like "iter.Seq2[[]FindAuthorsRow, error]".
+func (tq TemplatedQuery) EmitCursorResults() (string, error) {
+	chunk, err := tq.CursorChunk().EmitResultType()
+	if err != nil {
+		return "", fmt.Errorf("create result type for EmitCursorResults: %w", err)
+	}
+	return "iter.Seq2[" + chunk + ", error]", nil
+}
+
+// EmitCursorName returns the prefix of the server-side cursor name declared by
+// the cursor method, like "pggen_findauthors". The cursor method appends a
+// sequence number so each call declares a unique cursor. Unquoted, so Postgres
+// folds the name to lowercase.
+func (tq TemplatedQuery) EmitCursorName() string {
+	return "pggen_" + strings.ToLower(tq.Name)
+}
+
 // EmitResultType returns the string representing the overall query result type,
 // meaning the return result.
 func (tq TemplatedQuery) EmitResultType() (string, error) {
 	outs := removeVoidColumns(tq.Outputs)
+	cmdTagType := "pgconn.CommandTag"
+	if tq.Driver == DriverDatabaseSQL {
+		cmdTagType = "sql.Result"
+	}
 	switch tq.ResultKind {
 	case ast.ResultKindExec:
-		return "pgconn.CommandTag", nil
+		return cmdTagType, nil
+	case ast.ResultKindExecRows:
+		return "int64", nil // number of affected rows
+	case ast.ResultKindCopyFrom:
+		return "int64", nil // number of copied rows
+	case ast.ResultKindIter:
+		elem, err := tq.EmitIterElem()
+		if err != nil {
+			return "", err
+		}
+		return "iter.Seq2[" + elem + ", error]", nil
 	case ast.ResultKindMany:
+		if tq.ProtoType != "" {
+			return "[]*" + tq.ProtoType, nil
+		}
 		switch len(outs) {
 		case 0:
-			return "pgconn.CommandTag", nil
+			return cmdTagType, nil
 		case 1:
 			return "[]" + outs[0].QualType, nil
 		default:
-			return "[]" + tq.Name + "Row", nil
+			return "[]" + tq.rowName(), nil
 		}
 	case ast.ResultKindOne:
+		if tq.ProtoType != "" {
+			return "*" + tq.ProtoType, nil
+		}
 		switch len(outs) {
 		case 0:
-			return "pgconn.CommandTag", nil
+			return cmdTagType, nil
 		case 1:
 			return outs[0].QualType, nil
 		default:
-			return tq.Name + "Row", nil
+			return tq.rowName(), nil
+		}
+	case ast.ResultKindMap:
+		many, err := tq.asMany().EmitResultType()
+		if err != nil {
+			return "", err
 		}
+		return "map[" + tq.KeyType + "]" + strings.TrimPrefix(many, "[]"), nil
+	case ast.ResultKindOpt:
+		if tq.ProtoType != "" {
+			return "*" + tq.ProtoType, nil
+		}
+		// A pointer to the :one result type, so nil means no rows. A nullable
+		// single column, like *string, becomes **string.
+		one := tq
+		one.ResultKind = ast.ResultKindOne
+		result, err := one.EmitResultType()
+		if err != nil {
+			return "", err
+		}
+		return "*" + result, nil
 	default:
 		return "", fmt.Errorf("unhandled EmitResultType kind: %s", tq.ResultKind)
 	}
@@ -294,6 +819,9 @@ func (tq TemplatedQuery) EmitResultType() (string, error) {
 func (tq TemplatedQuery) EmitResultTypeInit(name string) (string, error) {
 	switch tq.ResultKind {
 	case ast.ResultKindOne:
+		if tq.ProtoType != "" {
+			return name + " := &" + tq.ProtoType + "{}", nil
+		}
 		result, err := tq.EmitResultType()
 		if err != nil {
 			return "", fmt.Errorf("create result type for EmitResultTypeInit: %w", err)
@@ -310,6 +838,11 @@ func (tq TemplatedQuery) EmitResultTypeInit(name string) (string, error) {
 			return "", fmt.Errorf("create result type for EmitResultTypeInit: %w", err)
 		}
 		isArr := strings.HasPrefix(result, "[]")
+		if isArr && tq.GroupBy != "" {
+			indent := "\n\t" + strings.Repeat("\t", tq.indentDepth)
+			return name + " := " + result + "{}" +
+				indent + "index := map[" + tq.KeyType + "]int{} // index of each group in " + name, nil
+		}
 		if isArr {
 			return name + " := " + result + "{}", nil
 		}
@@ -318,6 +851,13 @@ func (tq TemplatedQuery) EmitResultTypeInit(name string) (string, error) {
 		result = strings.TrimPrefix(result, "*")
 		return "var " + name + " " + result, nil
 
+	case ast.ResultKindMap:
+		result, err := tq.EmitResultType()
+		if err != nil {
+			return "", fmt.Errorf("create result type for EmitResultTypeInit: %w", err)
+		}
+		return name + " := " + result + "{}", nil
+
 	case ast.ResultKindExec:
 		return "", fmt.Errorf("cannot EmitResultTypeInit for :exec query %s", tq.Name)
 
@@ -329,7 +869,26 @@ func (tq TemplatedQuery) EmitResultTypeInit(name string) (string, error) {
 // EmitResultDecoders declares all initialization required for output types.
 func (tq TemplatedQuery) EmitResultDecoders() (string, error) {
 	sb := &strings.Builder{}
-	const indent = "\n\t" // 1 level indent inside querier method
+	indent := "\n\t" + strings.Repeat("\t", tq.indentDepth) // 1 level indent inside querier method
+	if tq.ResultKind == ast.ResultKindIter {
+		indent += "\t" // an :iter query decodes rows in the returned func
+	}
+	if tq.ProtoType != "" {
+		for _, out := range tq.Outputs {
+			if out.ProtoField.ScanType == "" {
+				continue
+			}
+			sb.WriteString(indent)
+			sb.WriteString("var ")
+			sb.WriteString(out.LowerName)
+			sb.WriteString("Value ")
+			sb.WriteString(out.ProtoField.ScanType)
+		}
+		return sb.String(), nil
+	}
+	if tq.Driver == DriverDatabaseSQL || tq.PgxVersion == 5 {
+		return "", nil // decode directly into the Go types
+	}
 	for _, out := range tq.Outputs {
 		switch typ := gotype.UnwrapNestedType(out.Type).(type) {
 		case *gotype.CompositeType:
@@ -338,10 +897,21 @@ func (tq TemplatedQuery) EmitResultDecoders() (string, error) {
 			sb.WriteString("Row := q.types.")
 			sb.WriteString(NameCompositeTranscoderFunc(typ))
 			sb.WriteString("()")
+		case *gotype.RangeType:
+			sb.WriteString(indent)
+			sb.WriteString(out.LowerName)
+			sb.WriteString("Range := q.types.")
+			sb.WriteString(NameRangeTranscoderFunc(typ))
+			sb.WriteString("()")
+		case *gotype.MultirangeType:
+			sb.WriteString(indent)
+			sb.WriteString(out.LowerName)
+			sb.WriteString("Range := q.types.")
+			sb.WriteString(NameMultirangeTranscoderFunc(typ))
+			sb.WriteString("()")
 		case *gotype.ArrayType:
-			switch gotype.UnwrapNestedType(typ.Elem).(type) {
-			case *gotype.EnumType, *gotype.CompositeType:
-				// For all other array elems, a normal array works.
+			// For all other array elems, a normal array works.
+			if hasArrayTranscoder(typ) {
 				sb.WriteString(indent)
 				sb.WriteString(out.LowerName)
 				sb.WriteString("Array := q.types.")
@@ -362,52 +932,85 @@ func (tq TemplatedQuery) EmitResultDecoders() (string, error) {
 // output struct.
 //
 // Copies pgtype.EnumArray fields into Go enum array types.
+//
+// Copies range values into the generic Range and Multirange types.
+//
+// Converts temporary values into the fields of a protobuf message, like a
+// time.Time into a *timestamppb.Timestamp.
 func (tq TemplatedQuery) EmitResultAssigns(zeroVal string) (string, error) {
 	sb := &strings.Builder{}
-	indent := "\n\t"
-	if tq.ResultKind == ast.ResultKindMany {
-		indent += "\t" // a :many query processes items in a for loop
+	indent := "\n\t" + strings.Repeat("\t", tq.indentDepth)
+	switch tq.ResultKind {
+	case ast.ResultKindMany, ast.ResultKindMap:
+		indent += "\t" // a :many or :map query processes items in a for loop
+	case ast.ResultKindIter:
+		indent += "\t\t" // an :iter query processes items in a for loop in a func
+	}
+	// writeAssignErr writes the statements to return an assign error.
+	writeAssignErr := func() {
+		msg := "fmt.Errorf(\"assign " + tq.Name + " row: %w\", err)"
+		sb.WriteString(indent)
+		if tq.ResultKind == ast.ResultKindIter {
+			sb.WriteString("\tyield(" + zeroVal + ", " + msg + ")")
+			sb.WriteString(indent)
+			sb.WriteString("\treturn")
+		} else {
+			sb.WriteString("\treturn " + zeroVal + ", " + msg)
+		}
+		sb.WriteString(indent)
+		sb.WriteString("}")
 	}
+	if tq.ProtoType != "" {
+		for _, out := range tq.Outputs {
+			if out.ProtoField.ScanType == "" {
+				continue
+			}
+			value := out.LowerName + "Value"
+			sb.WriteString(indent)
+			sb.WriteString("if ")
+			sb.WriteString(value)
+			sb.WriteString(" != nil {")
+			sb.WriteString(indent)
+			sb.WriteString("\titem.")
+			sb.WriteString(out.ProtoField.GoName)
+			sb.WriteString(" = ")
+			sb.WriteString(fmt.Sprintf(out.ProtoField.Convert, value))
+			sb.WriteString(indent)
+			sb.WriteString("}")
+		}
+		return sb.String(), nil
+	}
+	if tq.Driver == DriverDatabaseSQL || tq.PgxVersion == 5 {
+		return "", nil // decode directly into the Go types
+	}
+	hasOnlyOneNonVoid := len(removeVoidColumns(tq.Outputs)) == 1
 	for _, out := range tq.Outputs {
 		switch typ := gotype.UnwrapNestedType(out.Type).(type) {
 		case *gotype.CompositeType:
 			sb.WriteString(indent)
 			sb.WriteString("if err := ")
 			sb.WriteString(out.LowerName)
-			sb.WriteString("Row.AssignTo(&item")
-			if len(removeVoidColumns(tq.Outputs)) > 1 {
-				sb.WriteRune('.')
-				sb.WriteString(out.UpperName)
-			}
+			sb.WriteString("Row.AssignTo(")
+			sb.WriteString(emitFieldDest(out, hasOnlyOneNonVoid))
 			sb.WriteString("); err != nil {")
+			writeAssignErr()
+		case *gotype.RangeType, *gotype.MultirangeType:
 			sb.WriteString(indent)
-			sb.WriteString("\treturn ")
-			sb.WriteString(zeroVal)
-			sb.WriteString(", fmt.Errorf(\"assign ")
-			sb.WriteString(tq.Name)
-			sb.WriteString(" row: %w\", err)")
-			sb.WriteString(indent)
-			sb.WriteString("}")
+			sb.WriteString("if err := ")
+			sb.WriteString(out.LowerName)
+			sb.WriteString("Range.AssignTo(")
+			sb.WriteString(emitFieldDest(out, hasOnlyOneNonVoid))
+			sb.WriteString("); err != nil {")
+			writeAssignErr()
 		case *gotype.ArrayType:
-			switch gotype.UnwrapNestedType(typ.Elem).(type) {
-			case *gotype.CompositeType, *gotype.EnumType:
+			if hasArrayTranscoder(typ) {
 				sb.WriteString(indent)
 				sb.WriteString("if err := ")
 				sb.WriteString(out.LowerName)
-				sb.WriteString("Array.AssignTo(&item")
-				if len(removeVoidColumns(tq.Outputs)) > 1 {
-					sb.WriteRune('.')
-					sb.WriteString(out.UpperName)
-				}
+				sb.WriteString("Array.AssignTo(")
+				sb.WriteString(emitFieldDest(out, hasOnlyOneNonVoid))
 				sb.WriteString("); err != nil {")
-				sb.WriteString(indent)
-				sb.WriteString("\treturn ")
-				sb.WriteString(zeroVal)
-				sb.WriteString(", fmt.Errorf(\"assign ")
-				sb.WriteString(tq.Name)
-				sb.WriteString(" row: %w\", err)")
-				sb.WriteString(indent)
-				sb.WriteString("}")
+				writeAssignErr()
 			}
 		}
 	}
@@ -417,9 +1020,17 @@ func (tq TemplatedQuery) EmitResultAssigns(zeroVal string) (string, error) {
 // EmitResultElem returns the string representing a single item in the overall
 // query result type. For :one and :exec queries, this is the same as
 // EmitResultType. For :many queries, this is the element type of the slice
-// result type.
+// result type. For :opt queries, this is the type the result points to. For
+// :map queries, this is the element type of the equivalent :many query.
 func (tq TemplatedQuery) EmitResultElem() (string, error) {
-	result, err := tq.EmitResultType()
+	emitType := tq.EmitResultType
+	switch tq.ResultKind {
+	case ast.ResultKindIter:
+		emitType = tq.EmitIterElem
+	case ast.ResultKindMap:
+		emitType = tq.asMany().EmitResultType
+	}
+	result, err := emitType()
 	if err != nil {
 		return "", fmt.Errorf("unhandled EmitResultElem type: %w", err)
 	}
@@ -452,6 +1063,22 @@ func (tq TemplatedQuery) EmitResultExpr(name string) (string, error) {
 		}
 		return name, nil
 
+	case ast.ResultKindOpt:
+		return "&" + name, nil // the result is a pointer to the row
+
+	case ast.ResultKindMap:
+		return tq.asMany().EmitResultExpr(name)
+
+	case ast.ResultKindIter:
+		elem, err := tq.EmitIterElem()
+		if err != nil {
+			return "", fmt.Errorf("unhandled EmitResultExpr type: %w", err)
+		}
+		if strings.HasPrefix(elem, "*") {
+			return "&" + name, nil
+		}
+		return name, nil
+
 	case ast.ResultKindExec:
 		return "", fmt.Errorf("cannot EmitResultExpr for :exec query %s", tq.Name)
 
@@ -486,39 +1113,69 @@ func getLongestOutput(outs []TemplatedColumn) (int, int) {
 // needed.
 func (tq TemplatedQuery) EmitRowStruct() string {
 	switch tq.ResultKind {
-	case ast.ResultKindExec:
+	case ast.ResultKindExec, ast.ResultKindExecRows, ast.ResultKindCopyFrom:
 		return ""
-	case ast.ResultKindOne, ast.ResultKindMany:
+	case ast.ResultKindOne, ast.ResultKindOpt, ast.ResultKindMany, ast.ResultKindMap, ast.ResultKindIter:
 		outs := removeVoidColumns(tq.Outputs)
-		if len(outs) <= 1 {
-			return "" // if there's only 1 output column, return it directly
+		if len(outs) <= 1 || tq.ProtoType != "" {
+			return "" // return a single output column or a protobuf message directly
+		}
+		if tq.RowType != "" {
+			return "" // the leader file declares the shared row type
 		}
 		sb := &strings.Builder{}
-		sb.WriteString("\n\ntype ")
-		sb.WriteString(tq.Name)
-		sb.WriteString("Row struct {\n")
-		maxNameLen, maxTypeLen := getLongestOutput(outs)
+		if tq.GroupBy == "" {
+			writeRowStruct(sb, tq.Name+"Row", outs)
+			return sb.String()
+		}
+		// A group-by query has a parent row with a slice of child rows.
+		parents := make([]TemplatedColumn, 0, len(outs))
+		children := make([]TemplatedColumn, 0, len(outs))
 		for _, out := range outs {
-			// Name
-			sb.WriteString("\t")
-			sb.WriteString(out.UpperName)
-			// Type
-			sb.WriteString(strings.Repeat(" ", maxNameLen-len(out.UpperName)))
-			sb.WriteString(out.QualType)
-			// JSON struct tag
-			sb.WriteString(strings.Repeat(" ", maxTypeLen-len(out.QualType)))
-			sb.WriteString("`json:")
-			sb.WriteString(strconv.Quote(out.PgName))
-			sb.WriteString("`")
-			sb.WriteRune('\n')
+			if out.IsChild {
+				out.PgName = strings.TrimPrefix(out.PgName, tq.ChildPrefix+".")
+				children = append(children, out)
+			} else {
+				parents = append(parents, out)
+			}
 		}
-		sb.WriteString("}")
+		parents = append(parents, TemplatedColumn{
+			PgName:    tq.ChildPrefix,
+			UpperName: tq.ChildField,
+			QualType:  "[]" + tq.childRowName(),
+		})
+		writeRowStruct(sb, tq.Name+"Row", parents)
+		writeRowStruct(sb, tq.childRowName(), children)
 		return sb.String()
 	default:
 		panic("unhandled result type: " + tq.ResultKind)
 	}
 }
 
+// writeRowStruct writes the struct definition named name with a field for
+// each column.
+func writeRowStruct(sb *strings.Builder, name string, outs []TemplatedColumn) {
+	sb.WriteString("\n\ntype ")
+	sb.WriteString(name)
+	sb.WriteString(" struct {\n")
+	maxNameLen, maxTypeLen := getLongestOutput(outs)
+	for _, out := range outs {
+		// Name
+		sb.WriteString("\t")
+		sb.WriteString(out.UpperName)
+		// Type
+		sb.WriteString(strings.Repeat(" ", maxNameLen-len(out.UpperName)))
+		sb.WriteString(out.QualType)
+		// JSON struct tag
+		sb.WriteString(strings.Repeat(" ", maxTypeLen-len(out.QualType)))
+		sb.WriteString("`json:")
+		sb.WriteString(strconv.Quote(out.PgName))
+		sb.WriteString("`")
+		sb.WriteRune('\n')
+	}
+	sb.WriteString("}")
+}
+
 // removeVoidColumns makes a copy of cols with all VoidType columns removed.
 // Useful because return types shouldn't contain the void type, but we need
 // to use a nil placeholder for void types when scanning a pgx.Row.
@@ -1,15 +1,21 @@
 package golang
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/ast"
 	"github.com/jschaf/pggen/internal/casing"
 	"github.com/jschaf/pggen/internal/codegen"
 	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
 	"github.com/jschaf/pggen/internal/gomod"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pginfer"
 )
 
 // Templater creates query file templates.
@@ -18,6 +24,15 @@ type Templater struct {
 	resolver         TypeResolver
 	pkg              string // Go package name
 	inlineParamCount int
+	pgxVersion       int    // major version of pgx for the generated code
+	driver           Driver // Go database API for the generated code
+	// Go types for query params and output columns, keyed by table column,
+	// like "users.settings", query column, or query param, like
+	// "FindUser.settings".
+	columnOverrides map[string]string
+	// Go structs for protobuf messages named by proto-type pragmas, keyed by
+	// full protobuf name, like "foo.bar.User".
+	protoMessages map[string]protoMessage
 }
 
 // TemplaterOpts is options to control the template logic.
@@ -27,14 +42,38 @@ type TemplaterOpts struct {
 	Pkg      string // Go package name
 	// How many params to inline when calling querier methods.
 	InlineParamCount int
+	// The major version of pgx for the generated code, either 4 or 5. Defaults
+	// to 4 if 0.
+	PgxVersion int
+	// The Go database API for the generated code. Defaults to DriverPgx if
+	// empty.
+	Driver Driver
+	// Fully qualified Go types keyed by a table column, like "users.settings",
+	// a query output column, or a query param, like "FindUser.settings".
+	ColumnOverrides map[string]string
+	// Go structs for protobuf messages named by proto-type pragmas, keyed by
+	// full protobuf name, like "foo.bar.User".
+	ProtoMessages map[string]protoMessage
 }
 
 func NewTemplater(opts TemplaterOpts) Templater {
+	pgxVersion := opts.PgxVersion
+	if pgxVersion == 0 {
+		pgxVersion = 4
+	}
+	driver := opts.Driver
+	if driver == "" {
+		driver = DriverPgx
+	}
 	return Templater{
 		pkg:              opts.Pkg,
 		caser:            opts.Caser,
 		resolver:         opts.Resolver,
 		inlineParamCount: opts.InlineParamCount,
+		pgxVersion:       pgxVersion,
+		driver:           driver,
+		columnOverrides:  opts.ColumnOverrides,
+		protoMessages:    opts.ProtoMessages,
 	}
 }
 
@@ -43,6 +82,19 @@ func (tm Templater) TemplateAll(files []codegen.QueryFile) ([]TemplatedFile, err
 	goQueryFiles := make([]TemplatedFile, 0, len(files))
 	allDeclarers := NewDeclarerSet()
 
+	// Register all types up front so the resolver can name Go types for
+	// Postgres types with the same name in different schemas.
+	pgTypes := listPgTypes(files)
+	if err := tm.resolver.RegisterTypes(pgTypes...); err != nil {
+		return nil, fmt.Errorf("register postgres types: %w", err)
+	}
+	if err := tm.validateColumnOverrides(files); err != nil {
+		return nil, err
+	}
+	if tm.driver != DriverDatabaseSQL && tm.pgxVersion == 5 {
+		allDeclarers.AddAll(NewRegisterTypesDeclarer(listRegisterTypeNames(pgTypes)))
+	}
+
 	// Pick leader file to define common structs and interfaces via Declarer.
 	firstIndex := -1
 	firstName := string(unicode.MaxRune)
@@ -60,21 +112,89 @@ func (tm Templater) TemplateAll(files []codegen.QueryFile) ([]TemplatedFile, err
 			return nil, fmt.Errorf("template query file %s for go: %w", queryFile.SourcePath, err)
 		}
 		goQueryFiles = append(goQueryFiles, goFile)
+		for _, decl := range decls.ListAll() {
+			if rowDecl, ok := decl.(RowTypeDeclarer); ok {
+				if err := addRowTypeDeclarer(allDeclarers, rowDecl); err != nil {
+					return nil, fmt.Errorf("template query file %s for go: %w", queryFile.SourcePath, err)
+				}
+			}
+		}
 		allDeclarers.AddAll(decls.ListAll()...)
 	}
+	if err := checkRowTypeNames(allDeclarers, goQueryFiles); err != nil {
+		return nil, err
+	}
 
 	// Add declarers to leader file.
 	goQueryFiles[firstIndex].Declarers = allDeclarers.ListAll()
+	// The multiArrayValue transcoder walks nested slices with reflection. The
+	// generic Null type wraps sql.Null and marshals JSON. The jsonValue type
+	// marshals and unmarshals Go types stored as json or jsonb.
+	leaderImports := NewImportSet()
+	for _, pkg := range goQueryFiles[firstIndex].Imports {
+		leaderImports.AddPackage(pkg)
+	}
+	if _, ok := allDeclarers[multiArrayValueKey]; ok {
+		leaderImports.AddPackage("reflect")
+	}
+	// The Querier interface in the leader file includes the iter.Seq2 results
+	// of :iter queries and cursor methods in all files.
+	for _, file := range files {
+		if usesIter(file) {
+			leaderImports.AddPackage("iter")
+		}
+	}
+	if _, ok := allDeclarers[nullTypeKey]; ok {
+		for _, pkg := range nullTypeImports {
+			leaderImports.AddPackage(pkg)
+		}
+	}
+	if _, ok := allDeclarers[jsonValueKey]; ok {
+		for _, pkg := range jsonValueImports {
+			leaderImports.AddPackage(pkg)
+		}
+	}
+	// The text codec encodes and decodes composite types and arrays for
+	// database/sql. Enums implement driver.Valuer.
+	if _, ok := allDeclarers[textCodecKey]; ok {
+		for _, pkg := range textCodecImports {
+			leaderImports.AddPackage(pkg)
+		}
+	}
+	for _, decl := range allDeclarers {
+		if _, ok := decl.(EnumValuerDeclarer); ok {
+			leaderImports.AddPackage("database/sql/driver")
+		}
+	}
+	// The sentinel errors, like ErrTooManyRows, are declared once in the leader
+	// file with errors.New. Other files only wrap the errors with fmt.Errorf,
+	// so only the leader file imports errors.
+	_, hasAffectedRows := allDeclarers[affectedRowsKey]
+	_, hasTooManyRows := allDeclarers[tooManyRowsKey]
+	_, hasDuplicateKey := allDeclarers[duplicateKeyKey]
+	if hasAffectedRows || hasTooManyRows || hasDuplicateKey {
+		leaderImports.AddPackage("errors")
+	}
+	if _, ok := allDeclarers[cursorSeqKey]; ok {
+		leaderImports.AddPackage("sync/atomic")
+	}
+	goQueryFiles[firstIndex].Imports = leaderImports.SortedPackages()
 
-	// Remove unneeded pgconn import if possible.
+	// Remove unneeded pgconn import if possible. For database/sql, templateFile
+	// only imports the sql package for sql.Result if needed because types, like
+	// sql.Null, might need the sql package.
+	pgconnPkg := "github.com/jackc/pgconn"
+	if tm.pgxVersion == 5 {
+		pgconnPkg = pgx5PgconnPkg
+	}
 	for i, file := range goQueryFiles {
-		if file.needsPgconnImport() {
+		if file.needsPgconnImport() || tm.driver == DriverDatabaseSQL {
 			continue
 		}
 		pgconnIdx := -1
 		imports := file.Imports
 		for i, pkg := range imports {
-			if pkg == "github.com/jackc/pgconn" {
+			if pkg == pgconnPkg {
 				pgconnIdx = i
 				break
 			}
@@ -107,6 +227,112 @@ func (tm Templater) TemplateAll(files []codegen.QueryFile) ([]TemplatedFile, err
 	return goQueryFiles, nil
 }
 
+// listPgTypes lists the Postgres types of all inputs and outputs in files.
+func listPgTypes(files []codegen.QueryFile) []pg.Type {
+	var types []pg.Type
+	for _, file := range files {
+		for _, query := range file.Queries {
+			for _, input := range query.Inputs {
+				types = append(types, input.PgType)
+			}
+			for _, output := range query.Outputs {
+				types = append(types, output.PgType)
+			}
+		}
+	}
+	return types
+}
+
+// findColumnOverride finds the user-provided Go type for a param or output
+// column of query. Prefers a go-type pragma, then a query override, like
+// "FindUser.settings", then a table column override, like "users.settings".
+// tableName and tableColumn are empty for params and for output columns not
+// backed by a table column.
+func (tm Templater) findColumnOverride(query pginfer.TypedQuery, name, tableName, tableColumn string) (string, bool) {
+	if goType, ok := query.GoTypes[name]; ok {
+		return goType, true
+	}
+	if goType, ok := tm.columnOverrides[query.Name+"."+name]; ok {
+		return goType, true
+	}
+	if tableName == "" {
+		return "", false
+	}
+	goType, ok := tm.columnOverrides[tableName+"."+tableColumn]
+	return goType, ok
+}
+
+// validateColumnOverrides checks that each column override matches a param or
+// output column of a query or a table column backing an output column.
+func (tm Templater) validateColumnOverrides(files []codegen.QueryFile) error {
+	used := make(map[string]bool, len(tm.columnOverrides))
+	for _, file := range files {
+		for _, query := range file.Queries {
+			for _, input := range query.Inputs {
+				used[query.Name+"."+input.PgName] = true
+			}
+			for _, out := range query.Outputs {
+				used[query.Name+"."+out.PgName] = true
+				if out.TableName != "" {
+					used[out.TableName+"."+out.TableColumn] = true
+				}
+			}
+		}
+	}
+	keys := make([]string, 0, len(tm.columnOverrides))
+	for key := range tm.columnOverrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !used[key] {
+			return fmt.Errorf("column type override %q does not match any query param, query column, or table column", key)
+		}
+	}
+	return nil
+}
+
+// isJSONPgType returns true if pgt is the Postgres json or jsonb type or a
+// domain over either type.
+func isJSONPgType(pgt pg.Type) bool {
+	if domain, ok := pgt.(pg.DomainType); ok {
+		return isJSONPgType(domain.BaseType)
+	}
+	oid := pgt.OID()
+	return oid == pgtype.JSONOID || oid == pgtype.JSONBOID
+}
+
+// hasResultKind returns true if file has a query with the result kind.
+func hasResultKind(file codegen.QueryFile, kind ast.ResultKind) bool {
+	for _, query := range file.Queries {
+		if query.ResultKind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCursorQuery returns true if file has a query with a cursor method.
+func hasCursorQuery(file codegen.QueryFile) bool {
+	for _, query := range file.Queries {
+		if query.CursorSize > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// usesIter returns true if file has an :iter query or a query with a cursor
+// method, both of which return an iter.Seq2.
+func usesIter(file codegen.QueryFile) bool {
+	for _, query := range file.Queries {
+		if query.ResultKind == ast.ResultKindIter || query.CursorSize > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // templateFile creates the data needed to build a Go file for a query file.
 // Also returns any declarations needed by this query file. The caller must
 // dedupe declarations.
@@ -114,10 +340,27 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 	imports := NewImportSet()
 	imports.AddPackage("context")
 	imports.AddPackage("fmt")
-	imports.AddPackage("github.com/jackc/pgconn")
-	if isLeader {
-		imports.AddPackage("github.com/jackc/pgtype")
+	switch {
+	case tm.driver == DriverDatabaseSQL:
+		// Leader files define genericConn.ExecContext which returns sql.Result.
+		// Cursor methods accept a *sql.Tx.
+		if isLeader || hasResultKind(file, ast.ResultKindExec) || hasCursorQuery(file) {
+			imports.AddPackage("database/sql")
+		}
+	case tm.pgxVersion == 5:
+		// Every file uses pgx.Batch and pgx.BatchResults for batch queries.
+		imports.AddPackage(pgx5PgconnPkg)
+		imports.AddPackage(pgx5Pkg)
+	default:
+		imports.AddPackage("github.com/jackc/pgconn")
 		imports.AddPackage("github.com/jackc/pgx/v4")
+		if isLeader {
+			imports.AddPackage("github.com/jackc/pgtype")
+		}
+	}
+
+	if usesIter(file) {
+		imports.AddPackage("iter")
 	}
 
 	pkgPath := ""
@@ -133,6 +376,19 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 	queries := make([]TemplatedQuery, 0, len(file.Queries))
 	declarers := NewDeclarerSet()
 	for _, query := range file.Queries {
+		if query.AffectedRows != nil {
+			declarers.AddAll(NewAffectedRowsDeclarer())
+		}
+		if query.CursorSize > 0 {
+			declarers.AddAll(NewCursorSeqDeclarer())
+		}
+		switch query.ResultKind {
+		case ast.ResultKindOpt:
+			declarers.AddAll(NewTooManyRowsDeclarer())
+		case ast.ResultKindMap:
+			declarers.AddAll(NewDuplicateKeyDeclarer())
+		}
+
 		// Build doc string.
 		docs := strings.Builder{}
 		avgCharsPerLine := 40
@@ -146,12 +402,24 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 			docs.WriteRune('\n')
 		}
 
+		if query.ResultKind == ast.ResultKindCopyFrom && tm.driver == DriverDatabaseSQL {
+			return TemplatedFile{}, nil, fmt.Errorf("query %s: :copyfrom requires pgx; database/sql doesn't support the COPY protocol", query.Name)
+		}
+
 		// Build inputs.
 		inputs := make([]TemplatedParam, len(query.Inputs))
 		for i, input := range query.Inputs {
-			goType, err := tm.resolver.Resolve(input.PgType /*nullable*/, false, pkgPath)
+			var goType gotype.Type
+			var err error
+			isJSON := false
+			if override, ok := tm.findColumnOverride(query, input.PgName, "", ""); ok {
+				goType, err = tm.resolver.resolveOverride(override, input.PgType)
+				isJSON = err == nil && isJSONPgType(input.PgType) && !gotype.IsRawJSON(goType)
+			} else {
+				goType, err = tm.resolver.Resolve(input.PgType /*nullable*/, false, pkgPath)
+			}
 			if err != nil {
-				return TemplatedFile{}, nil, err
+				return TemplatedFile{}, nil, fmt.Errorf("query %s param %s: %w", query.Name, input.PgName, err)
 			}
 			imports.AddType(goType)
 			inputs[i] = TemplatedParam{
@@ -160,17 +428,63 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 				QualType:  gotype.QualifyType(goType, pkgPath),
 				Type:      goType,
 				RawName:   query.Inputs[i],
+				IsJSON:    isJSON,
 			}
-			ds := FindInputDeclarers(goType).ListAll()
-			declarers.AddAll(ds...)
+			if isJSON {
+				declarers.AddAll(NewJSONValueDeclarer())
+			}
+			declarers.AddAll(tm.findInputDeclarers(goType).ListAll()...)
+			declarers.AddAll(FindNullTypeDeclarers(goType).ListAll()...)
 		}
 
 		// Build outputs.
+		protoType := ""
+		var protoMsg protoMessage
+		if query.ProtobufType != "" {
+			isRows := query.ResultKind == ast.ResultKindOne || query.ResultKind == ast.ResultKindOpt ||
+				query.ResultKind == ast.ResultKindMany || query.ResultKind == ast.ResultKindIter
+			if !isRows {
+				return TemplatedFile{}, nil, fmt.Errorf("query %s: proto-type requires a :one, :opt, :many, or :iter query", query.Name)
+			}
+			msg, ok := tm.protoMessages[query.ProtobufType]
+			if !ok {
+				return TemplatedFile{}, nil, fmt.Errorf("query %s: no Go type for proto-type %s", query.Name, query.ProtobufType)
+			}
+			protoMsg = msg
+			protoType = msg.goName
+			if msg.pkgPath != pkgPath {
+				protoType = gotype.ExtractShortPackage([]byte(msg.pkgPath)) + "." + msg.goName
+				imports.AddPackage(msg.pkgPath)
+			}
+		}
 		outputs := make([]TemplatedColumn, len(query.Outputs))
 		for i, out := range query.Outputs {
-			goType, err := tm.resolver.Resolve(out.PgType, out.Nullable, pkgPath)
+			if protoType != "" {
+				col, err := tm.templateProtoColumn(protoMsg, out, imports)
+				if err != nil {
+					return TemplatedFile{}, nil, fmt.Errorf("query %s proto-type %s: %w", query.Name, query.ProtobufType, err)
+				}
+				col.LowerName = tm.chooseLowerName(out.PgName, "UnnamedColumn", i, len(query.Outputs))
+				outputs[i] = col
+				if col.ProtoField.IsRepeated && tm.driver == DriverDatabaseSQL {
+					declarers.AddAll(textCodecDeclarer) // scanTextArray
+				}
+				continue
+			}
+			var goType gotype.Type
+			var err error
+			isJSON := false
+			if override, ok := tm.findColumnOverride(query, out.PgName, out.TableName, out.TableColumn); ok {
+				goType, err = tm.resolver.resolveOverride(override, out.PgType)
+				isJSON = err == nil && isJSONPgType(out.PgType) && !gotype.IsRawJSON(goType)
+				if err == nil && !isJSON {
+					err = tm.resolver.checkNullableColumnOverride(out.Nullable, override, goType)
+				}
+			} else {
+				goType, err = tm.resolver.Resolve(out.PgType, out.Nullable, pkgPath)
+			}
 			if err != nil {
-				return TemplatedFile{}, nil, err
+				return TemplatedFile{}, nil, fmt.Errorf("query %s column %s: %w", query.Name, out.PgName, err)
 			}
 			imports.AddType(goType)
 			outputs[i] = TemplatedColumn{
@@ -179,9 +493,47 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 				LowerName: tm.chooseLowerName(out.PgName, "UnnamedColumn", i, len(query.Outputs)),
 				Type:      goType,
 				QualType:  gotype.QualifyType(goType, pkgPath),
+				IsJSON:    isJSON,
+				Nullable:  out.Nullable,
+			}
+			if isJSON {
+				declarers.AddAll(NewJSONValueDeclarer())
+			}
+			declarers.AddAll(tm.findOutputDeclarers(goType).ListAll()...)
+			declarers.AddAll(FindNullTypeDeclarers(goType).ListAll()...)
+		}
+
+		if (query.MapKey != "" || query.GroupBy != "") && protoType != "" {
+			return TemplatedFile{}, nil, fmt.Errorf("query %s: proto-type doesn't support :map queries or group-by pragmas", query.Name)
+		}
+		// A :map query keys rows by the key pragma column. A group-by query
+		// keys the index of each group by the group-by column. The parser
+		// rejects queries with both.
+		var keyType string
+		keyPragma, keyColumn := "key", query.MapKey
+		if query.GroupBy != "" {
+			keyPragma, keyColumn = "group-by", query.GroupBy
+		}
+		if keyColumn != "" {
+			typ, err := templateKeyType(outputs, keyColumn, pkgPath)
+			if err != nil {
+				return TemplatedFile{}, nil, fmt.Errorf("query %s %s pragma: %w", query.Name, keyPragma, err)
+			}
+			keyType = typ
+		}
+		childPrefix, childField, err := tm.templateChildColumns(query, outputs)
+		if err != nil {
+			return TemplatedFile{}, nil, fmt.Errorf("query %s group-by pragma: %w", query.Name, err)
+		}
+		if query.RowType != "" {
+			outs := removeVoidColumns(outputs)
+			if len(outs) <= 1 {
+				return TemplatedFile{}, nil, fmt.Errorf("query %s row-type pragma: requires more than one output column", query.Name)
+			}
+			rowDecl := NewRowTypeDeclarer(query.RowType, query.Name, outs)
+			if err := addRowTypeDeclarer(declarers, rowDecl); err != nil {
+				return TemplatedFile{}, nil, err
 			}
-			ds := FindOutputDeclarers(goType).ListAll()
-			declarers.AddAll(ds...)
 		}
 
 		queries = append(queries, TemplatedQuery{
@@ -192,7 +544,19 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 			PreparedSQL:      query.PreparedSQL,
 			Inputs:           inputs,
 			Outputs:          outputs,
+			ProtoType:        protoType,
 			InlineParamCount: tm.inlineParamCount,
+			PgxVersion:       tm.pgxVersion,
+			Driver:           tm.driver,
+			CopyFrom:         query.CopyFrom,
+			CursorSize:       query.CursorSize,
+			AffectedRows:     query.AffectedRows,
+			MapKey:           query.MapKey,
+			GroupBy:          query.GroupBy,
+			KeyType:          keyType,
+			ChildPrefix:      childPrefix,
+			ChildField:       childField,
+			RowType:          query.RowType,
 		})
 	}
 
@@ -206,6 +570,51 @@ func (tm Templater) templateFile(file codegen.QueryFile, isLeader bool) (Templat
 	}, declarers, nil
 }
 
+// templateProtoColumn creates the output column for a query with a
+// proto-type pragma. The column scans into a field of the Go struct for the
+// protobuf message instead of a field of a generated row struct.
+func (tm Templater) templateProtoColumn(msg protoMessage, out pginfer.OutputColumn, imports *ImportSet) (TemplatedColumn, error) {
+	col := TemplatedColumn{PgName: out.PgName}
+	if _, ok := out.PgType.(pg.VoidType); ok {
+		col.Type = &gotype.VoidType{}
+		return col, nil
+	}
+	field, pkgs, err := templateProtoField(msg, out)
+	if err != nil {
+		return TemplatedColumn{}, err
+	}
+	for _, pkg := range pkgs {
+		imports.AddPackage(pkg)
+	}
+	col.UpperName = field.GoName
+	col.ProtoField = field
+	return col, nil
+}
+
+// findInputDeclarers finds the declarers for an input param type for the
+// driver and pgx version of the generated code.
+func (tm Templater) findInputDeclarers(typ gotype.Type) DeclarerSet {
+	switch {
+	case tm.driver == DriverDatabaseSQL:
+		return FindDeclarersDatabaseSQL(typ)
+	case tm.pgxVersion == 5:
+		return FindDeclarersPgx5(typ)
+	}
+	return FindInputDeclarers(typ)
+}
+
+// findOutputDeclarers finds the declarers for an output column type for the
+// driver and pgx version of the generated code.
+func (tm Templater) findOutputDeclarers(typ gotype.Type) DeclarerSet {
+	switch {
+	case tm.driver == DriverDatabaseSQL:
+		return FindDeclarersDatabaseSQL(typ)
+	case tm.pgxVersion == 5:
+		return FindDeclarersPgx5(typ)
+	}
+	return FindOutputDeclarers(typ)
+}
+
 // chooseUpperName converts pgName into a capitalized Go identifier name.
 // If it's not possible to convert pgName into an identifier, uses fallback with
 // a suffix using idx.
@@ -220,6 +629,71 @@ func (tm Templater) chooseUpperName(pgName string, fallback string, idx int, num
 	return fallback + suffix
 }
 
+// templateKeyType returns the Go type of the map key for the output column
+// named key, qualified relative to pkgPath. A nullable column, like *int64 or
+// Null[int64], keys the map by the non-NULL value, like int64. A nullable
+// column with another Go type, like pgtype.Text, can't tell if the key is
+// NULL. Types that aren't comparable, like slices, can't be map keys.
+func templateKeyType(outputs []TemplatedColumn, key string, pkgPath string) (string, error) {
+	for _, out := range outputs {
+		if out.PgName != key {
+			continue
+		}
+		var typ gotype.Type = out.Type
+		switch t := typ.(type) {
+		case *gotype.PointerType:
+			typ = t.Elem
+		case *gotype.NullType:
+			typ = t.Elem
+		}
+		if !gotype.IsComparable(typ) {
+			return "", fmt.Errorf("column %s has Go type %s that isn't comparable; "+
+				"the column must not be an array or contain an array", key, out.QualType)
+		}
+		if typ == out.Type && out.Nullable && !gotype.IsNonNullable(typ) {
+			return "", fmt.Errorf("column %s is nullable with Go type %s that can't tell if the key is NULL; "+
+				"use a pointer type, like with a nullable type override", key, out.QualType)
+		}
+		return gotype.QualifyType(typ, pkgPath), nil
+	}
+	return "", fmt.Errorf("no output column %s", key)
+}
+
+// templateChildColumns marks the output columns that belong to the child rows
+// of a group-by query. A child column is named with a prefix, like
+// "books.title". Renames each child column to the name without the prefix, like
+// "Title". Returns the prefix and the Go field name for the child rows, like
+// "books" and "Books". Returns empty strings if query has no group-by pragma.
+func (tm Templater) templateChildColumns(query pginfer.TypedQuery, outputs []TemplatedColumn) (string, string, error) {
+	if query.GroupBy == "" {
+		return "", "", nil
+	}
+	prefix := ""
+	for i, out := range outputs {
+		childPrefix, name, ok := strings.Cut(out.PgName, ".")
+		if !ok {
+			continue
+		}
+		if out.PgName == query.GroupBy {
+			return "", "", fmt.Errorf("group-by column %s must not be a child column", out.PgName)
+		}
+		if prefix != "" && childPrefix != prefix {
+			return "", "", fmt.Errorf("columns must have the same child prefix; got %s and %s", prefix, childPrefix)
+		}
+		prefix = childPrefix
+		outputs[i].IsChild = true
+		outputs[i].UpperName = tm.chooseUpperName(name, "UnnamedColumn", i, len(outputs))
+	}
+	if prefix == "" {
+		return "", "", errors.New("no child columns; name child columns with a prefix, like \"books.title\"")
+	}
+	field := tm.caser.ToUpperGoIdent(prefix)
+	if field == "" {
+		return "", "", fmt.Errorf("child prefix %q is not a valid Go identifier", prefix)
+	}
+	return prefix, field, nil
+}
+
 // chooseLowerName converts pgName into an uncapitalized Go identifier name.
 // If it's not possible to convert pgName into an identifier, uses fallback with
 // a suffix using idx.
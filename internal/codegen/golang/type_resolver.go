@@ -2,55 +2,439 @@ package golang
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/jackc/pgtype"
 	"github.com/jschaf/pggen/internal/casing"
 	"github.com/jschaf/pggen/internal/codegen/golang/gotype"
 	"github.com/jschaf/pggen/internal/pg"
 )
 
+// SchemaNaming is the policy to name Go types for Postgres types in a schema
+// other than public. The policy matters when different schemas have a type
+// with the same name, like billing.status and shipping.status.
+type SchemaNaming string
+
+const (
+	// SchemaNamingCollision prefixes the Go type name with the schema only if
+	// another schema has a type with the same name, like BillingStatus for
+	// billing.status. Types in the public schema keep the unqualified name.
+	SchemaNamingCollision SchemaNaming = "collision"
+	// SchemaNamingAlways prefixes the Go type name with the schema for all
+	// types not in the public schema.
+	SchemaNamingAlways SchemaNaming = "always"
+	// SchemaNamingNever never prefixes the Go type name with the schema. Types
+	// with the same name in different schemas cause an error.
+	SchemaNamingNever SchemaNaming = "never"
+)
+
+// ParseSchemaNaming parses the name of a SchemaNaming policy. The empty string
+// is SchemaNamingCollision.
+func ParseSchemaNaming(s string) (SchemaNaming, error) {
+	switch SchemaNaming(s) {
+	case "", SchemaNamingCollision:
+		return SchemaNamingCollision, nil
+	case SchemaNamingAlways, SchemaNamingNever:
+		return SchemaNaming(s), nil
+	default:
+		return "", fmt.Errorf("unknown schema naming %q; must be one of %q, %q, or %q",
+			s, SchemaNamingCollision, SchemaNamingAlways, SchemaNamingNever)
+	}
+}
+
+// NullableStyle is the policy to choose the Go type for a nullable Postgres
+// value of a builtin type, like a nullable varchar column.
+type NullableStyle string
+
+const (
+	// NullableStyleDefault uses the default Go type for each Postgres type, a
+	// mix of pointers, like *string for text, and pgtype types, like
+	// pgtype.Varchar for varchar.
+	NullableStyleDefault NullableStyle = ""
+	// NullableStylePgtype uses pgtype types, like pgtype.Text for text. Only
+	// supported for pgx v4.
+	NullableStylePgtype NullableStyle = "pgtype"
+	// NullableStylePointer uses a pointer to the native Go type, like *string
+	// for text.
+	NullableStylePointer NullableStyle = "pointer"
+	// NullableStyleSQLNull uses sql.Null with the native Go type, like
+	// sql.Null[string] for text.
+	NullableStyleSQLNull NullableStyle = "sqlnull"
+	// NullableStyleGeneric uses the generic Null type declared by pggen with
+	// the native Go type, like Null[string] for text. Unlike sql.Null, the
+	// Null type marshals NULL to JSON null.
+	NullableStyleGeneric NullableStyle = "generic"
+)
+
+// ParseNullableStyle parses the name of a NullableStyle. The empty string is
+// NullableStyleDefault.
+func ParseNullableStyle(s string) (NullableStyle, error) {
+	switch style := NullableStyle(s); style {
+	case NullableStyleDefault, NullableStylePgtype, NullableStylePointer, NullableStyleSQLNull, NullableStyleGeneric:
+		return style, nil
+	default:
+		return "", fmt.Errorf("unknown nullable style %q; must be one of %q, %q, %q, or %q",
+			s, NullableStylePgtype, NullableStylePointer, NullableStyleSQLNull, NullableStyleGeneric)
+	}
+}
+
 // TypeResolver handles the mapping between Postgres and Go types.
 type TypeResolver struct {
 	caser     casing.Caser
 	overrides map[string]string
+	// Overrides only for nullable values, like "int8" => "*int" from the
+	// override "int8?".
+	nullOverrides map[string]string
+	naming        SchemaNaming
+	// If true, resolve every composite field as nullable, ignoring not null
+	// constraints on table columns.
+	nullableFields bool
+	pgxVersion     int    // major version of pgx for the generated code
+	driver         Driver // Go database API for the generated code
+	nullStyle      NullableStyle
+	// Nullable styles by Postgres type name, like "varchar" => "pointer".
+	// Overrides nullStyle.
+	nullTypeStyles map[string]NullableStyle
+	// If true, resolve types nested in a composite or array type.
+	nested bool
+	// All schemas that contain a type with a given unqualified name, like
+	// "status" => {"billing", "shipping"}. Set by RegisterTypes.
+	schemas map[string]map[string]struct{}
+}
+
+// TypeResolverOpts are options to control how TypeResolver maps Postgres
+// types to Go types.
+type TypeResolverOpts struct {
+	// How to name Go types for Postgres types outside the public schema.
+	// Defaults to SchemaNamingCollision if empty.
+	SchemaNaming SchemaNaming
+	// If true, composite fields backed by a NOT NULL table column resolve to
+	// nullable Go types.
+	NullableCompositeFields bool
+	// The major version of pgx for the generated code, either 4 or 5. Defaults
+	// to 4 if 0.
+	PgxVersion int
+	// The Go database API for the generated code. Defaults to DriverPgx if
+	// empty.
+	Driver Driver
+	// How to choose the Go type for nullable builtin Postgres types.
+	NullableStyle NullableStyle
+	// Nullable styles by Postgres type name or alias, like
+	// "float8" or "double precision". Overrides NullableStyle for the type.
+	NullableTypeStyles map[string]NullableStyle
 }
 
-func NewTypeResolver(c casing.Caser, overrides map[string]string) TypeResolver {
+// NewTypeResolver creates a TypeResolver. The overrides keys are Postgres type
+// names, like "status", or schema-qualified type names, like
+// "billing.status". A key with a "?" suffix, like "int8?", only applies to
+// nullable values and takes precedence over the key without the suffix.
+func NewTypeResolver(c casing.Caser, overrides map[string]string, opts TypeResolverOpts) TypeResolver {
 	overs := make(map[string]string, len(overrides))
+	nullOvers := make(map[string]string)
 	for k, v := range overrides {
-		for _, alias := range listAliases(k) {
-			overs[alias] = v
+		dest := overs
+		if nullKey, isNullable := strings.CutSuffix(k, "?"); isNullable {
+			k = nullKey
+			dest = nullOvers
+		}
+		schema, name, isQualified := strings.Cut(k, ".")
+		if !isQualified {
+			name = k
 		}
+		for _, alias := range listAliases(name) {
+			switch {
+			case !isQualified, schema == "pg_catalog":
+				// Builtin types don't have a schema.
+				dest[alias] = v
+			default:
+				dest[schema+"."+alias] = v
+			}
+		}
+	}
+	naming := opts.SchemaNaming
+	if naming == "" {
+		naming = SchemaNamingCollision
+	}
+	pgxVersion := opts.PgxVersion
+	if pgxVersion == 0 {
+		pgxVersion = 4
+	}
+	driver := opts.Driver
+	if driver == "" {
+		driver = DriverPgx
+	}
+	nullTypeStyles := make(map[string]NullableStyle, len(opts.NullableTypeStyles))
+	for name, style := range opts.NullableTypeStyles {
+		for _, alias := range listAliases(name) {
+			nullTypeStyles[alias] = style
+		}
+	}
+	return TypeResolver{
+		caser:          c,
+		overrides:      overs,
+		nullOverrides:  nullOvers,
+		naming:         naming,
+		nullableFields: opts.NullableCompositeFields,
+		pgxVersion:     pgxVersion,
+		driver:         driver,
+		nullStyle:      opts.NullableStyle,
+		nullTypeStyles: nullTypeStyles,
+		schemas:        make(map[string]map[string]struct{}),
 	}
-	return TypeResolver{caser: c, overrides: overs}
+}
+
+// RegisterTypes records the schema of each Postgres type that pggen declares
+// a Go type for, like enums and composite types, including descendant types.
+// Resolve uses the schemas to name Go types for Postgres types with the same
+// name in different schemas. Must be called with all types before calling
+// Resolve. Returns an error if two Postgres types map to the same Go type
+// name.
+func (tr TypeResolver) RegisterTypes(types ...pg.Type) error {
+	seen := make(map[string]struct{})
+	var register func(pgt pg.Type)
+	register = func(pgt pg.Type) {
+		qualName := pg.QualifiedName(pgt)
+		if _, ok := seen[qualName]; ok {
+			return
+		}
+		seen[qualName] = struct{}{}
+		switch pgt := pgt.(type) {
+		case pg.ArrayType:
+			register(pgt.Elem)
+			return
+		case pg.DomainType:
+			register(pgt.BaseType)
+			return
+		case pg.CompositeType:
+			for _, colType := range pgt.ColumnTypes {
+				register(colType)
+			}
+		case pg.RangeType:
+			register(pgt.Subtype)
+		case pg.MultirangeType:
+			register(pgt.Range)
+		case pg.EnumType:
+			// No descendant types.
+		default:
+			return
+		}
+		schema := pg.SchemaName(pgt)
+		if _, ok := tr.findOverride(pgt /*nullable*/, false); ok || schema == "" {
+			return
+		}
+		if tr.schemas[pgt.String()] == nil {
+			tr.schemas[pgt.String()] = make(map[string]struct{}, 1)
+		}
+		tr.schemas[pgt.String()][schema] = struct{}{}
+	}
+	for _, typ := range types {
+		register(typ)
+	}
+
+	// Check that each Go type name maps to a single Postgres type.
+	goNames := make(map[string][]string, len(tr.schemas))
+	for name, schemas := range tr.schemas {
+		for schema := range schemas {
+			goName := tr.caser.ToUpperGoIdent(tr.qualifyName(schema, name))
+			goNames[goName] = append(goNames[goName], schema+"."+name)
+		}
+	}
+	for goName, qualNames := range goNames {
+		if len(qualNames) < 2 {
+			continue
+		}
+		sort.Strings(qualNames)
+		return fmt.Errorf("postgres types %s all map to Go type %s; "+
+			"add a --go-type override or use a different --schema-naming",
+			strings.Join(qualNames, ", "), goName)
+	}
+	return nil
+}
+
+// findOverride finds the user-provided Go type for a Postgres type. Prefers a
+// schema-qualified override, like "billing.status", over an unqualified
+// override, like "status". For nullable values, prefers a nullable override,
+// like "int8?", over a non-nullable override.
+func (tr TypeResolver) findOverride(pgt pg.Type, nullable bool) (string, bool) {
+	if nullable {
+		if goType, ok := findOverrideIn(tr.nullOverrides, pgt); ok {
+			return goType, true
+		}
+	}
+	return findOverrideIn(tr.overrides, pgt)
+}
+
+func findOverrideIn(overrides map[string]string, pgt pg.Type) (string, bool) {
+	if goType, ok := overrides[pg.QualifiedName(pgt)]; ok {
+		return goType, true
+	}
+	goType, ok := overrides[pgt.String()]
+	return goType, ok
+}
+
+// resolveOverride maps a Postgres type to a user-provided Go type, like
+// "github.com/jschaf/pggen.DeviceType".
+func (tr TypeResolver) resolveOverride(goType string, pgt pg.Type) (gotype.Type, error) {
+	opaque, err := gotype.ParseOpaqueType(goType, pgt)
+	if err != nil {
+		return nil, fmt.Errorf("resolve custom type: %w", err)
+	}
+	return opaque, nil
+}
+
+// qualifyName returns the Postgres-style name to derive the Go type name for
+// the Postgres type name in schema, like billing_status for billing.status,
+// depending on the schema naming policy.
+func (tr TypeResolver) qualifyName(schema, name string) string {
+	if schema == "" || schema == "public" {
+		return name
+	}
+	switch tr.naming {
+	case SchemaNamingAlways:
+		return schema + "_" + name
+	case SchemaNamingNever:
+		return name
+	default:
+		if len(tr.schemas[name]) > 1 {
+			return schema + "_" + name
+		}
+		return name
+	}
+}
+
+// goTypeName returns the Go type name for a Postgres type that pggen declares,
+// like an enum. Returns an empty string if there's no valid Go identifier for
+// the Postgres type name.
+func (tr TypeResolver) goTypeName(pgt pg.Type) string {
+	return tr.caser.ToUpperGoIdent(tr.qualifyName(pg.SchemaName(pgt), pgt.String()))
+}
+
+// checkNullableOverride checks that a nullable type doesn't use the override
+// goType, resolved as opaque, that can't represent NULL. Scanning NULL into a
+// non-nullable Go type fails at runtime. An explicit nullable override, like
+// "int8?=int", opts out of the check. Nested types keep the override since
+// most composite fields are nullable.
+func (tr TypeResolver) checkNullableOverride(pgt pg.Type, nullable bool, goType string, opaque gotype.Type) error {
+	if !nullable || tr.nested || !gotype.IsNonNullable(opaque) {
+		return nil
+	}
+	if _, hasNullOverride := findOverrideIn(tr.nullOverrides, pgt); hasNullOverride {
+		return nil
+	}
+	return fmt.Errorf("nullable type %s uses non-nullable Go type %s; "+
+		"add a nullable override like --go-type '%s?=*%s'", pgt, goType, pgt, goType)
+}
+
+// checkNullableColumnOverride checks that a nullable column doesn't use the
+// column override goType, resolved as opaque, that can't represent NULL.
+func (tr TypeResolver) checkNullableColumnOverride(nullable bool, goType string, opaque gotype.Type) error {
+	if !nullable || !gotype.IsNonNullable(opaque) {
+		return nil
+	}
+	return fmt.Errorf("nullable column uses non-nullable Go type %s from a column override; "+
+		"use a type that can represent NULL, like *%s", goType, goType)
 }
 
 // Resolve maps a Postgres type to a Go type.
 func (tr TypeResolver) Resolve(pgt pg.Type, nullable bool, pkgPath string) (gotype.Type, error) {
 	// Custom user override.
-	if goType, ok := tr.overrides[pgt.String()]; ok {
-		opaque, err := gotype.ParseOpaqueType(goType, pgt)
+	if goType, ok := tr.findOverride(pgt, nullable); ok {
+		opaque, err := tr.resolveOverride(goType, pgt)
 		if err != nil {
-			return nil, fmt.Errorf("resolve custom type: %w", err)
+			return nil, err
+		}
+		if err := tr.checkNullableOverride(pgt, nullable, goType, opaque); err != nil {
+			return nil, err
 		}
 		return opaque, nil
 	}
 
+	// Range types use the generic Range and Multirange types that pggen declares
+	// instead of the pgtype range types. For database/sql, range types use the
+	// Postgres text format as a string, like "[1,5)". Multi-dimensional arrays and domains
+	// over multi-dimensional arrays use nested slices. Anonymous records with
+	// known fields use a struct instead of pgtype.Record.
+	switch pgt := pgt.(type) {
+	case pg.CompositeType:
+		if pgt.IsRecord() {
+			comp, err := CreateCompositeType(pkgPath, pgt, tr, tr.caser)
+			if err != nil {
+				return nil, fmt.Errorf("create record type: %w", err)
+			}
+			return comp, nil
+		}
+	case pg.ArrayType:
+		if pgt.Dimensions > 1 {
+			return tr.resolveMultiArray(pgt, nullable, pkgPath)
+		}
+		// An array of a type with an override, like my_id, is a slice of the
+		// override Go type unless the array type has an override, like _my_id.
+		// Array elements are always nullable, so a nullable override, like
+		// my_id?, takes precedence regardless of the nullability of the array.
+		// Elements of an override that can't represent NULL, like int8=int,
+		// use a pointer to the override, like []*int.
+		if pgt.Elem != nil {
+			if _, ok := tr.findOverride(pgt.Elem, true); ok {
+				typ, err := tr.resolveArray(pgt, true, pkgPath)
+				if err != nil {
+					return nil, err
+				}
+				if _, hasNullOverride := findOverrideIn(tr.nullOverrides, pgt.Elem); !hasNullOverride && gotype.IsNonNullable(typ.Elem) {
+					typ.Elem = &gotype.PointerType{Elem: typ.Elem}
+				}
+				typ.ElemOverride = true
+				return typ, nil
+			}
+		}
+	case pg.DomainType:
+		if arr, ok := pgt.BaseType.(pg.ArrayType); ok && arr.Dimensions > 1 {
+			return tr.resolveMultiArray(arr, nullable, pkgPath)
+		}
+	case pg.RangeType:
+		if tr.driver == DriverDatabaseSQL {
+			return stringType(nullable), nil
+		}
+		rng, err := tr.resolveRange(pgt, pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		// The Range type declared by pggen can't represent NULL, unlike
+		// pgtype.Range for pgx v5, so a nullable output column uses a pointer.
+		if nullable && !tr.nested && tr.pgxVersion != 5 {
+			return &gotype.PointerType{Elem: rng}, nil
+		}
+		return rng, nil
+	case pg.MultirangeType:
+		if tr.driver == DriverDatabaseSQL {
+			return stringType(nullable), nil
+		}
+		rng, err := tr.resolveRange(pgt.Range, pkgPath)
+		if err != nil {
+			return nil, fmt.Errorf("resolve range type for multirange type %q: %w", pgt.Name, err)
+		}
+		multi := gotype.NewMultirangeType(pgt, rng, tr.caser)
+		if name := tr.goTypeName(pgt); name != "" {
+			multi.Name = name
+		}
+		return multi, nil
+	}
+
 	// Known type.
-	var typ gotype.Type
-	var isKnownType bool
-	if nullable {
-		typ, isKnownType = gotype.FindKnownTypeNullable(pgt.OID())
-	} else {
-		typ, isKnownType = gotype.FindKnownTypeNonNullable(pgt.OID())
+	typ, isKnownType := tr.findKnownType(pgt.OID(), nullable)
+	if styled, ok := tr.resolveNullableStyle(pgt, nullable); ok {
+		typ, isKnownType = styled, true
 	}
 	if isKnownType {
 		switch typ := typ.(type) {
 		case *gotype.ArrayType:
+			if typ == gotype.ByteSlice {
+				return typ, nil // []byte represents non-array types, like bytea
+			}
 			arrTyp, ok := pgt.(pg.ArrayType)
 			if !ok {
-				return nil, fmt.Errorf("resolve known type %q does not have pg array type %q", typ, pgt)
+				return nil, fmt.Errorf("resolve known type %q does not have pg array type %q", gotype.QualifyType(typ, ""), pgt)
 			}
 			typ.PgArray = arrTyp
 			return typ, nil
@@ -67,7 +451,7 @@ func (tr TypeResolver) Resolve(pgt pg.Type, nullable bool, pkgPath string) (goty
 		case *gotype.OpaqueType:
 			typ.PgType = pgt
 			return typ, nil
-		case *gotype.PointerType:
+		case *gotype.PointerType, *gotype.NullType:
 			return typ, nil
 		case *gotype.VoidType:
 			return &gotype.VoidType{}, nil
@@ -79,13 +463,9 @@ func (tr TypeResolver) Resolve(pgt pg.Type, nullable bool, pkgPath string) (goty
 	// New type that pggen will define in generated source code.
 	switch pgt := pgt.(type) {
 	case pg.ArrayType:
-		elemType, err := tr.Resolve(pgt.Elem, nullable, pkgPath)
-		if err != nil {
-			return nil, fmt.Errorf("resolve array elem type for array type %q: %w", pgt.Name, err)
-		}
-		return gotype.NewArrayType(pgt, elemType), nil
+		return tr.resolveArray(pgt, nullable, pkgPath)
 	case pg.EnumType:
-		enum := gotype.NewEnumType(pkgPath, pgt, tr.caser)
+		enum := gotype.NewNamedEnumType(pkgPath, pgt, tr.goTypeName(pgt), tr.caser)
 		return enum, nil
 	case pg.CompositeType:
 		comp, err := CreateCompositeType(pkgPath, pgt, tr, tr.caser)
@@ -98,6 +478,158 @@ func (tr TypeResolver) Resolve(pgt pg.Type, nullable bool, pkgPath string) (goty
 	return nil, fmt.Errorf("no go type found for Postgres type %s oid=%d", pgt.String(), pgt.OID())
 }
 
+// findKnownType returns the Go type, if known, for a Postgres OID for the
+// driver and pgx version of the generated code.
+func (tr TypeResolver) findKnownType(oid pgtype.OID, nullable bool) (gotype.Type, bool) {
+	switch {
+	case tr.driver == DriverDatabaseSQL:
+		return gotype.FindKnownTypeDatabaseSQL(oid, nullable)
+	case tr.pgxVersion == 5:
+		return gotype.FindKnownTypePgx5(oid, nullable)
+	case nullable:
+		return gotype.FindKnownTypeNullable(oid)
+	default:
+		return gotype.FindKnownTypeNonNullable(oid)
+	}
+}
+
+// resolveNullableStyle maps a builtin Postgres type to a Go type with the
+// nullable style for the type. The pointer, sqlnull, and generic styles use
+// the native Go type for non-nullable values, like string for varchar.
+// Returns false to use the default Go type, like for Postgres types without a
+// native Go type.
+func (tr TypeResolver) resolveNullableStyle(pgt pg.Type, nullable bool) (gotype.Type, bool) {
+	style := tr.nullStyle
+	if s, ok := tr.nullTypeStyles[pgt.String()]; ok {
+		style = s
+	}
+	switch {
+	case style == NullableStyleDefault:
+		return nil, false
+	case style == NullableStylePgtype:
+		if !nullable {
+			return nil, false
+		}
+		return gotype.FindKnownTypePgx(pgt.OID())
+	}
+	native, ok := gotype.FindKnownTypeNative(pgt.OID())
+	if !ok {
+		return nil, false
+	}
+	if _, isSlice := native.(*gotype.ArrayType); isSlice || !nullable {
+		return native, true // a nil slice represents NULL
+	}
+	// pgx v4 assigns composite fields with pgtype AssignTo, which doesn't
+	// support sql.Scanner. database/sql scans composite fields from the
+	// Postgres text format, which sql.Null can't convert to time.Time. Use
+	// pointers for nested types instead.
+	isPgx5 := tr.driver == DriverPgx && tr.pgxVersion == 5
+	if tr.nested && !isPgx5 {
+		style = NullableStylePointer
+	}
+	switch style {
+	case NullableStyleSQLNull:
+		return &gotype.NullType{Elem: native, PkgPath: "database/sql"}, true
+	case NullableStyleGeneric:
+		return &gotype.NullType{Elem: native}, true
+	default:
+		return &gotype.PointerType{Elem: native}, true
+	}
+}
+
+// stringType returns the Go string type for a Postgres value in the text
+// format.
+func stringType(nullable bool) gotype.Type {
+	if nullable {
+		return gotype.Stringp
+	}
+	return gotype.String
+}
+
+// resolveRange maps a Postgres range type to the generic Range type with
+// bounds of the Go type for the range subtype. For pgx v5, the generic Range
+// type is pgtype.Range instead of the Range type declared by pggen.
+func (tr TypeResolver) resolveRange(pgt pg.RangeType, pkgPath string) (*gotype.RangeType, error) {
+	var elemType gotype.Type
+	if _, ok := tr.findOverride(pgt.Subtype /*nullable*/, false); !ok {
+		if tr.pgxVersion == 5 {
+			elemType, _ = gotype.FindKnownTypePgx5RangeBound(pgt.Subtype.OID())
+		} else {
+			elemType, _ = gotype.FindKnownTypeRangeBound(pgt.Subtype.OID())
+		}
+	}
+	if elemType == nil {
+		typ, err := tr.Resolve(pgt.Subtype /*nullable*/, false, pkgPath)
+		if err != nil {
+			return nil, fmt.Errorf("resolve subtype for range type %q: %w", pgt.Name, err)
+		}
+		elemType = typ
+	}
+	if _, ok := gotype.UnwrapNestedType(elemType).(*gotype.CompositeType); ok {
+		return nil, fmt.Errorf("range type %q has unsupported composite subtype %q", pgt.Name, pgt.Subtype.String())
+	}
+	rng := gotype.NewRangeType(pgt, elemType, tr.caser)
+	if name := tr.goTypeName(pgt); name != "" {
+		rng.Name = name
+	}
+	if tr.pgxVersion == 5 {
+		rng.PkgPath = pgx5PgtypePkg
+	}
+	return rng, nil
+}
+
+// resolveArray maps a one-dimensional Postgres array type to a Go slice of the
+// Go type for the element type.
+func (tr TypeResolver) resolveArray(pgt pg.ArrayType, nullable bool, pkgPath string) (*gotype.ArrayType, error) {
+	elemType, err := tr.resolveNested(pgt.Elem, nullable, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve array elem type for array type %q: %w", pgt.Name, err)
+	}
+	switch elemType.(type) {
+	case *gotype.RangeType, *gotype.MultirangeType:
+		if tr.pgxVersion == 4 && tr.driver == DriverPgx {
+			return nil, fmt.Errorf("array type %q of range type %q is not supported", pgt.Name, pgt.Elem.String())
+		}
+	}
+	return &gotype.ArrayType{PgArray: pgt, Elem: elemType}, nil
+}
+
+// resolveMultiArray maps a multi-dimensional Postgres array type to a nested
+// Go slice, like [][]int32 for a two-dimensional int4 array. The innermost
+// slice is the same Go type as a one-dimensional array. If the one-dimensional
+// array isn't a Go slice, like pgtype.BoolArray, the pgtype array type already
+// supports multiple dimensions.
+func (tr TypeResolver) resolveMultiArray(pgt pg.ArrayType, nullable bool, pkgPath string) (gotype.Type, error) {
+	inner := pgt
+	inner.Dimensions--
+	innerType, err := tr.Resolve(inner, nullable, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %d-dimensional array type %q: %w", pgt.Dimensions, pgt.Name, err)
+	}
+	if _, ok := innerType.(*gotype.ArrayType); !ok {
+		return innerType, nil
+	}
+	return gotype.NewArrayType(pgt, innerType), nil
+}
+
+// resolveNested maps a Postgres type nested in a composite or array type to a
+// Go type. For pgx v4, nested builtin range types use the pgtype range types
+// because the pgtype.ValueTranscoder for the parent type uses the range type
+// registered in pgtype.ConnInfo.
+func (tr TypeResolver) resolveNested(pgt pg.Type, nullable bool, pkgPath string) (gotype.Type, error) {
+	tr.nested = true
+	switch pgt.(type) {
+	case pg.RangeType, pg.MultirangeType:
+		if _, ok := tr.findOverride(pgt, nullable); ok || tr.pgxVersion == 5 || tr.driver == DriverDatabaseSQL {
+			break
+		}
+		if typ, ok := gotype.FindKnownTypePgx(pgt.OID()); ok {
+			return typ, nil
+		}
+	}
+	return tr.Resolve(pgt, nullable, pkgPath)
+}
+
 // CreateCompositeType creates a struct to represent a Postgres composite type.
 // The type is rooted under pkgPath.
 func CreateCompositeType(
@@ -106,7 +638,7 @@ func CreateCompositeType(
 	resolver TypeResolver,
 	caser casing.Caser,
 ) (gotype.Type, error) {
-	name := caser.ToUpperGoIdent(pgt.Name)
+	name := resolver.goTypeName(pgt)
 	if name == "" {
 		name = gotype.ChooseFallbackName(pgt.Name, "UnnamedStruct")
 	}
@@ -118,7 +650,12 @@ func CreateCompositeType(
 			ident = gotype.ChooseFallbackName(colName, "UnnamedField"+strconv.Itoa(i))
 		}
 		fieldNames[i] = ident
-		fieldType, err := resolver.Resolve(pgt.ColumnTypes[i] /*nullable*/, true, pkgPath)
+		// A field backed by a NOT NULL table column is only null if the whole
+		// row is null, like with an outer join, so the composite type itself
+		// is null.
+		isNotNull := i < len(pgt.ColumnNotNulls) && pgt.ColumnNotNulls[i]
+		nullable := resolver.nullableFields || !isNotNull
+		fieldType, err := resolver.resolveNested(pgt.ColumnTypes[i], nullable, pkgPath)
 		if err != nil {
 			return nil, fmt.Errorf("resolve composite column type %s.%s: %w", pgt.Name, colName, err)
 		}
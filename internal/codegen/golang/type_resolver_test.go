@@ -1,7 +1,11 @@
 package golang
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"text/tabwriter"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jackc/pgtype"
@@ -10,6 +14,7 @@ import (
 	"github.com/jschaf/pggen/internal/difftest"
 	"github.com/jschaf/pggen/internal/pg"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTypeResolver_Resolve(t *testing.T) {
@@ -45,6 +50,48 @@ func TestTypeResolver_Resolve(t *testing.T) {
 				Elem:    &gotype.ImportType{PkgPath: testPkgPath, Type: goDeviceEnum},
 			},
 		},
+		{
+			name:   "enum array 2d",
+			pgType: pg.ArrayType{Name: "_device_type", Elem: pgDeviceEnum, Dimensions: 2},
+			want: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_device_type", Elem: pgDeviceEnum, Dimensions: 2},
+				Elem: &gotype.ArrayType{
+					PgArray: pg.ArrayType{Name: "_device_type", Elem: pgDeviceEnum, Dimensions: 1},
+					Elem:    &gotype.ImportType{PkgPath: testPkgPath, Type: goDeviceEnum},
+				},
+			},
+		},
+		{
+			name:   "int4 array 3d",
+			pgType: pg.ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: pg.Int4, Dimensions: 3},
+			want: &gotype.ArrayType{
+				PgArray: pg.ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: pg.Int4, Dimensions: 3},
+				Elem: &gotype.ArrayType{
+					PgArray: pg.ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: pg.Int4, Dimensions: 2},
+					Elem:    gotype.Int32Slice,
+				},
+			},
+		},
+		{
+			name:   "bool array 2d",
+			pgType: pg.ArrayType{ID: pgtype.BoolArrayOID, Name: "_bool", Elem: pg.Bool, Dimensions: 2},
+			want:   gotype.PgBoolArray,
+		},
+		{
+			name: "domain array 2d",
+			pgType: pg.DomainType{
+				Name:       "matrix",
+				BaseType:   pg.ArrayType{Name: "_device_type", Elem: pgDeviceEnum, Dimensions: 2},
+				Dimensions: 2,
+			},
+			want: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_device_type", Elem: pgDeviceEnum, Dimensions: 2},
+				Elem: &gotype.ArrayType{
+					PgArray: pg.ArrayType{Name: "_device_type", Elem: pgDeviceEnum, Dimensions: 1},
+					Elem:    &gotype.ImportType{PkgPath: testPkgPath, Type: goDeviceEnum},
+				},
+			},
+		},
 		{
 			name:   "void",
 			pgType: pg.VoidType{},
@@ -84,6 +131,31 @@ func TestTypeResolver_Resolve(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "override elem array",
+			overrides: map[string]string{"custom_type": "example.com/custom.QualType"},
+			pgType:    pg.ArrayType{Name: "_custom_type", Elem: pg.BaseType{Name: "custom_type"}},
+			want: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_custom_type", Elem: pg.BaseType{Name: "custom_type"}},
+				Elem: &gotype.ImportType{
+					PkgPath: "example.com/custom",
+					Type:    &gotype.OpaqueType{PgType: pg.BaseType{Name: "custom_type"}, Name: "QualType"},
+				},
+				ElemOverride: true,
+			},
+		},
+		{
+			name: "override array and elem",
+			overrides: map[string]string{
+				"custom_type":  "example.com/custom.QualType",
+				"_custom_type": "[]string",
+			},
+			pgType: pg.ArrayType{Name: "_custom_type", Elem: pg.BaseType{Name: "custom_type"}},
+			want: &gotype.ArrayType{
+				PgArray: pg.ArrayType{Name: "_custom_type", Elem: pg.BaseType{Name: "custom_type"}},
+				Elem:    &gotype.OpaqueType{Name: "string"},
+			},
+		},
 		{
 			name:     "known nonNullable empty",
 			pgType:   pg.BaseType{Name: "point", ID: pgtype.PointOID},
@@ -141,6 +213,37 @@ func TestTypeResolver_Resolve(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "range",
+			pgType: pg.Tstzrange,
+			want:   &gotype.RangeType{PgRange: pg.Tstzrange, Name: "Tstzrange", Elem: gotype.Time},
+		},
+		{
+			name:     "range nullable",
+			pgType:   pg.Tstzrange,
+			nullable: true,
+			want: &gotype.PointerType{
+				Elem: &gotype.RangeType{PgRange: pg.Tstzrange, Name: "Tstzrange", Elem: gotype.Time},
+			},
+		},
+		{
+			name:   "range enum",
+			pgType: pg.RangeType{Name: "device_range", Subtype: pgDeviceEnum},
+			want: &gotype.RangeType{
+				PgRange: pg.RangeType{Name: "device_range", Subtype: pgDeviceEnum},
+				Name:    "DeviceRange",
+				Elem:    &gotype.ImportType{PkgPath: testPkgPath, Type: goDeviceEnum},
+			},
+		},
+		{
+			name:   "multirange",
+			pgType: pg.Int4multirange,
+			want: &gotype.MultirangeType{
+				PgMultirange: pg.Int4multirange,
+				Name:         "Int4multirange",
+				Range:        &gotype.RangeType{PgRange: pg.Int4range, Name: "Int4range", Elem: gotype.Int32},
+			},
+		},
 		{
 			name: "composite",
 			pgType: pg.CompositeType{
@@ -166,10 +269,37 @@ func TestTypeResolver_Resolve(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "record",
+			pgType: pg.CompositeType{
+				ID:          pgtype.RecordOID,
+				Name:        "FindPair_pair",
+				ColumnNames: []string{"id", "name"},
+				ColumnTypes: []pg.Type{pg.Int8, pg.Text},
+			},
+			nullable: true,
+			want: &gotype.ImportType{
+				PkgPath: testPkgPath,
+				Type: &gotype.CompositeType{
+					PgComposite: pg.CompositeType{
+						ID:          pgtype.RecordOID,
+						Name:        "FindPair_pair",
+						ColumnNames: []string{"id", "name"},
+						ColumnTypes: []pg.Type{pg.Int8, pg.Text},
+					},
+					Name:       "FindPairPair",
+					FieldNames: []string{"ID", "Name"},
+					FieldTypes: []gotype.Type{
+						&gotype.PointerType{Elem: &gotype.OpaqueType{Name: "int", PgType: pg.Int8}},
+						&gotype.PointerType{Elem: &gotype.OpaqueType{Name: "string", PgType: pg.Text}},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resolver := NewTypeResolver(caser, tt.overrides)
+			resolver := NewTypeResolver(caser, tt.overrides, TypeResolverOpts{})
 			got, err := resolver.Resolve(tt.pgType, tt.nullable, testPkgPath)
 			if err != nil {
 				t.Fatal(err)
@@ -181,6 +311,336 @@ func TestTypeResolver_Resolve(t *testing.T) {
 	}
 }
 
+func TestTypeResolver_SchemaNaming(t *testing.T) {
+	caser := casing.NewCaser()
+	billingStatus := pg.EnumType{Name: "status", Schema: "billing", Labels: []string{"paid"}}
+	shippingStatus := pg.EnumType{Name: "status", Schema: "shipping", Labels: []string{"sent"}}
+	publicStatus := pg.EnumType{Name: "status", Schema: "public", Labels: []string{"open"}}
+	billingInvoice := pg.CompositeType{
+		Name:        "invoice",
+		Schema:      "billing",
+		ColumnNames: []string{"status"},
+		ColumnTypes: []pg.Type{billingStatus},
+	}
+	tests := []struct {
+		name      string
+		naming    SchemaNaming
+		overrides map[string]string
+		types     []pg.Type
+		resolve   pg.Type
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:    "collision - unique name",
+			naming:  SchemaNamingCollision,
+			types:   []pg.Type{billingStatus},
+			resolve: billingStatus,
+			want:    "Status",
+		},
+		{
+			name:    "collision - qualified",
+			naming:  SchemaNamingCollision,
+			types:   []pg.Type{billingStatus, shippingStatus},
+			resolve: shippingStatus,
+			want:    "ShippingStatus",
+		},
+		{
+			name:    "collision - public unqualified",
+			naming:  SchemaNamingCollision,
+			types:   []pg.Type{publicStatus, billingStatus},
+			resolve: publicStatus,
+			want:    "Status",
+		},
+		{
+			name:    "collision - composite child",
+			naming:  SchemaNamingCollision,
+			types:   []pg.Type{billingInvoice, pg.ArrayType{Name: "_status", Schema: "shipping", Elem: shippingStatus}},
+			resolve: billingStatus,
+			want:    "BillingStatus",
+		},
+		{
+			name:    "always",
+			naming:  SchemaNamingAlways,
+			types:   []pg.Type{billingInvoice},
+			resolve: billingInvoice,
+			want:    "BillingInvoice",
+		},
+		{
+			name:    "always - public unqualified",
+			naming:  SchemaNamingAlways,
+			types:   []pg.Type{publicStatus},
+			resolve: publicStatus,
+			want:    "Status",
+		},
+		{
+			name:    "never",
+			naming:  SchemaNamingNever,
+			types:   []pg.Type{billingStatus},
+			resolve: billingStatus,
+			want:    "Status",
+		},
+		{
+			name:    "never - collision",
+			naming:  SchemaNamingNever,
+			types:   []pg.Type{billingStatus, shippingStatus},
+			wantErr: true,
+		},
+		{
+			name:      "never - collision with override",
+			naming:    SchemaNamingNever,
+			overrides: map[string]string{"shipping.status": "string"},
+			types:     []pg.Type{billingStatus, shippingStatus},
+			resolve:   billingStatus,
+			want:      "Status",
+		},
+		{
+			name:      "qualified override",
+			naming:    SchemaNamingCollision,
+			overrides: map[string]string{"billing.status": "example.com/billing.Status", "status": "string"},
+			types:     []pg.Type{billingStatus, shippingStatus},
+			resolve:   billingStatus,
+			want:      "billing.Status",
+		},
+		{
+			name:      "unqualified override",
+			naming:    SchemaNamingCollision,
+			overrides: map[string]string{"billing.status": "example.com/billing.Status", "status": "string"},
+			types:     []pg.Type{billingStatus, shippingStatus},
+			resolve:   shippingStatus,
+			want:      "string",
+		},
+		{
+			name:      "pg_catalog override",
+			naming:    SchemaNamingCollision,
+			overrides: map[string]string{"pg_catalog.integer": "int"},
+			resolve:   pg.Int4,
+			want:      "int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewTypeResolver(caser, tt.overrides, TypeResolverOpts{SchemaNaming: tt.naming})
+			err := resolver.RegisterTypes(tt.types...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := resolver.Resolve(tt.resolve /*nullable*/, false, "example.com/foo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.want, gotype.QualifyType(got, "example.com/foo"))
+		})
+	}
+}
+
+func TestTypeResolver_ResolvePgx5(t *testing.T) {
+	testPkgPath := "github.com/jschaf/pggen/internal/codegen/golang/test_resolve"
+	caser := casing.NewCaser()
+	caser.AddAcronym("id", "ID")
+	deviceType := pg.EnumType{ID: 16400, Name: "device_type", Labels: []string{"phone"}}
+	tests := []struct {
+		name     string
+		pgType   pg.Type
+		nullable bool
+		want     string
+	}{
+		{"int4", pg.Int4, false, "int32"},
+		{"int4 nullable", pg.Int4, true, "*int32"},
+		{"text nullable", pg.Text, true, "*string"},
+		{"timestamptz", pg.Timestamptz, false, "pgtype.Timestamptz"},
+		{"numeric", pg.Numeric, true, "pgtype.Numeric"},
+		{"inet", pg.Inet, false, "netip.Prefix"},
+		{"jsonb", pg.JSONB, true, "[]byte"},
+		{"int8 array", pg.ArrayType{ID: pgtype.Int8ArrayOID, Name: "_int8", Elem: pg.Int8}, false, "[]int"},
+		{"uuid array", pg.ArrayType{ID: pgtype.UUIDArrayOID, Name: "_uuid", Elem: pg.UUID}, false, "[]pgtype.UUID"},
+		{"enum array", pg.ArrayType{ID: 16401, Name: "_device_type", Elem: deviceType}, false, "[]DeviceType"},
+		{"tstzrange", pg.Tstzrange, false, "pgtype.Range[time.Time]"},
+		{"int4multirange", pg.Int4multirange, false, "pgtype.Multirange[pgtype.Range[int32]]"},
+		{"record", pg.Record, false, "any"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewTypeResolver(caser, nil, TypeResolverOpts{PgxVersion: 5})
+			got, err := resolver.Resolve(tt.pgType, tt.nullable, testPkgPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gotype.QualifyType(got, testPkgPath))
+		})
+	}
+}
+
+func TestTypeResolver_ResolveDatabaseSQL(t *testing.T) {
+	testPkgPath := "github.com/jschaf/pggen/internal/codegen/golang/test_resolve"
+	caser := casing.NewCaser()
+	deviceType := pg.EnumType{ID: 16400, Name: "device_type", Labels: []string{"phone"}}
+	tests := []struct {
+		name     string
+		pgType   pg.Type
+		nullable bool
+		want     string
+	}{
+		{"int4", pg.Int4, false, "int32"},
+		{"int4 nullable", pg.Int4, true, "*int32"},
+		{"timestamptz", pg.Timestamptz, false, "time.Time"},
+		{"timestamptz nullable", pg.Timestamptz, true, "*time.Time"},
+		{"numeric", pg.Numeric, false, "string"},
+		{"uuid nullable", pg.UUID, true, "*string"},
+		{"jsonb", pg.JSONB, true, "[]byte"},
+		{"text array", pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}, false, "[]string"},
+		{"enum array", pg.ArrayType{ID: 16401, Name: "_device_type", Elem: deviceType}, false, "[]DeviceType"},
+		{"tstzrange", pg.Tstzrange, false, "string"},
+		{"int4multirange nullable", pg.Int4multirange, true, "*string"},
+		{"record", pg.Record, false, "any"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewTypeResolver(caser, nil, TypeResolverOpts{Driver: DriverDatabaseSQL})
+			got, err := resolver.Resolve(tt.pgType, tt.nullable, testPkgPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gotype.QualifyType(got, testPkgPath))
+		})
+	}
+}
+
+func TestTypeResolver_ResolveNullableStyle(t *testing.T) {
+	testPkgPath := "github.com/jschaf/pggen/internal/codegen/golang/test_resolve"
+	caser := casing.NewCaser()
+	comp := pg.CompositeType{
+		Name:        "qux",
+		ColumnNames: []string{"name"},
+		ColumnTypes: []pg.Type{pg.Varchar},
+	}
+	tests := []struct {
+		name       string
+		style      NullableStyle
+		typeStyles map[string]NullableStyle
+		pgxVersion int
+		pgType     pg.Type
+		nullable   bool
+		want       string
+	}{
+		{"default", "", nil, 4, pg.Varchar, true, "pgtype.Varchar"},
+		{"pgtype", NullableStylePgtype, nil, 4, pg.Text, true, "pgtype.Text"},
+		{"pgtype non-nullable", NullableStylePgtype, nil, 4, pg.Text, false, "string"},
+		{"pointer", NullableStylePointer, nil, 4, pg.Varchar, true, "*string"},
+		{"pointer non-nullable", NullableStylePointer, nil, 4, pg.Varchar, false, "string"},
+		{"pointer time", NullableStylePointer, nil, 4, pg.Timestamptz, true, "*time.Time"},
+		{"pointer no native type", NullableStylePointer, nil, 4, pg.Numeric, true, "pgtype.Numeric"},
+		{"sqlnull", NullableStyleSQLNull, nil, 4, pg.Int4, true, "sql.Null[int32]"},
+		{"sqlnull slice", NullableStyleSQLNull, nil, 4, pg.JSONB, true, "[]byte"},
+		{"generic", NullableStyleGeneric, nil, 4, pg.Date, true, "Null[time.Time]"},
+		{"generic pgx v5", NullableStyleGeneric, nil, 5, pg.Float8, true, "Null[float64]"},
+		{"type style", NullableStylePointer, map[string]NullableStyle{"double precision": NullableStylePgtype}, 4, pg.Float8, true, "pgtype.Float8"},
+		{"type style only", "", map[string]NullableStyle{"text": NullableStyleSQLNull}, 4, pg.Text, true, "sql.Null[string]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewTypeResolver(caser, nil, TypeResolverOpts{
+				PgxVersion:         tt.pgxVersion,
+				NullableStyle:      tt.style,
+				NullableTypeStyles: tt.typeStyles,
+			})
+			got, err := resolver.Resolve(tt.pgType, tt.nullable, testPkgPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gotype.QualifyType(got, testPkgPath))
+		})
+	}
+
+	// pgx v4 can't assign composite fields to a Null type, so composite fields
+	// use pointers instead.
+	for pgxVersion, want := range map[int]string{4: "*string", 5: "Null[string]"} {
+		resolver := NewTypeResolver(caser, nil, TypeResolverOpts{PgxVersion: pgxVersion, NullableStyle: NullableStyleGeneric})
+		got, err := resolver.Resolve(comp, true, "")
+		require.NoError(t, err)
+		fieldType := gotype.UnwrapNestedType(got).(*gotype.CompositeType).FieldTypes[0]
+		assert.Equal(t, want, gotype.QualifyType(fieldType, ""), "pgx v%d", pgxVersion)
+	}
+}
+
+func TestTypeResolver_ResolveNullableOverride(t *testing.T) {
+	testPkgPath := "github.com/jschaf/pggen/internal/codegen/golang/test_resolve"
+	caser := casing.NewCaser()
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		pgType    pg.Type
+		nullable  bool
+		want      string
+	}{
+		{"non-nullable", map[string]string{"int8": "int", "int8?": "*int"}, pg.Int8, false, "int"},
+		{"nullable", map[string]string{"int8": "int", "int8?": "*int"}, pg.Int8, true, "*int"},
+		{"nullable alias", map[string]string{"int8": "int", "bigint?": "*int"}, pg.Int8, true, "*int"},
+		{"nullable only", map[string]string{"text?": "*string"}, pg.Text, true, "*string"},
+		{"nullable only non-nullable", map[string]string{"text?": "*string"}, pg.Text, false, "string"},
+		{"explicit non-nullable", map[string]string{"int8?": "int"}, pg.Int8, true, "int"},
+		{"pointer", map[string]string{"int8": "*int"}, pg.Int8, true, "*int"},
+		{"custom", map[string]string{"int8": "example.com/foo.Int"}, pg.Int8, true, "foo.Int"},
+		{"array elem", map[string]string{"int8": "int", "int8?": "*int"}, pg.Int8Array, false, "[]*int"},
+		{"nullable array elem", map[string]string{"int8": "int", "int8?": "*int"}, pg.Int8Array, true, "[]*int"},
+		{"array elem nullable only", map[string]string{"int8?": "*int"}, pg.Int8Array, false, "[]*int"},
+		{"array elem non-nullable", map[string]string{"int8": "int"}, pg.Int8Array, false, "[]*int"},
+		{"array elem explicit non-nullable", map[string]string{"int8?": "int"}, pg.Int8Array, false, "[]int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewTypeResolver(caser, tt.overrides, TypeResolverOpts{})
+			got, err := resolver.Resolve(tt.pgType, tt.nullable, testPkgPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gotype.QualifyType(got, testPkgPath))
+		})
+	}
+
+	resolver := NewTypeResolver(caser, map[string]string{"int8": "int"}, TypeResolverOpts{})
+	_, err := resolver.Resolve(pg.Int8, true, testPkgPath)
+	require.ErrorContains(t, err, "nullable type int8 uses non-nullable Go type int")
+
+	// Composite fields keep the non-nullable override.
+	comp := pg.CompositeType{Name: "qux", ColumnNames: []string{"num"}, ColumnTypes: []pg.Type{pg.Int8}}
+	got, err := resolver.Resolve(comp, true, "")
+	require.NoError(t, err)
+	fieldType := gotype.UnwrapNestedType(got).(*gotype.CompositeType).FieldTypes[0]
+	assert.Equal(t, "int", gotype.QualifyType(fieldType, ""))
+}
+
+func TestTypeResolver_Presets(t *testing.T) {
+	caser := casing.NewCaser()
+	for _, name := range gotype.ListPresetNames() {
+		t.Run(name, func(t *testing.T) {
+			overrides, err := applyTypePresets([]string{name}, nil, DriverPgx, 4)
+			require.NoError(t, err)
+			resolver := NewTypeResolver(caser, overrides, TypeResolverOpts{})
+			sb := &strings.Builder{}
+			w := tabwriter.NewWriter(sb, 0, 0, 1, ' ', 0)
+			for _, pgType := range pg.ListKnownTypes() {
+				_, _ = fmt.Fprintf(w, "%d\t%s", pgType.OID(), pgType)
+				for _, nullable := range []bool{false, true} {
+					typ, err := resolver.Resolve(pgType, nullable, "")
+					if err != nil {
+						_, _ = fmt.Fprint(w, "\terror")
+						continue
+					}
+					_, _ = fmt.Fprintf(w, "\t%s", gotype.QualifyType(typ, ""))
+				}
+				_, _ = fmt.Fprintln(w)
+			}
+			require.NoError(t, w.Flush())
+
+			golden := "testdata/type_resolver_preset_" + name + ".golden"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, []byte(sb.String()), 0o644))
+				return
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), sb.String())
+		})
+	}
+}
+
 func TestType_QualifyRel(t *testing.T) {
 	caser := casing.NewCaser()
 	tests := []struct {
@@ -206,6 +666,19 @@ func TestType_QualifyRel(t *testing.T) {
 			otherPkgPath: "example.com/bar",
 			want:         "Device",
 		},
+		{
+			typ: gotype.NewRangeType(
+				pg.RangeType{Name: "device_range"},
+				gotype.NewEnumType(
+					"example.com/foo",
+					pg.EnumType{Name: "device", Labels: []string{"macos"}},
+					caser,
+				),
+				caser,
+			),
+			otherPkgPath: "example.com/bar",
+			want:         "Range[foo.Device]",
+		},
 		{
 			typ:          gotype.MustParseOpaqueType("example.com/bar.Baz"),
 			otherPkgPath: "example.com/bar",
@@ -232,7 +705,7 @@ func TestType_QualifyRel(t *testing.T) {
 
 func TestCreateCompositeType(t *testing.T) {
 	caser := casing.NewCaser()
-	resolver := NewTypeResolver(caser, nil)
+	resolver := NewTypeResolver(caser, nil, TypeResolverOpts{})
 	tests := []struct {
 		pkgPath string
 		pgType  pg.CompositeType
@@ -271,3 +744,42 @@ func TestCreateCompositeType(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateCompositeType_NotNullFields(t *testing.T) {
+	caser := casing.NewCaser()
+	pgType := pg.CompositeType{
+		Name:           "author",
+		ColumnNames:    []string{"id", "suffix"},
+		ColumnTypes:    []pg.Type{pg.Int8, pg.Text},
+		ColumnNotNulls: []bool{true, false},
+	}
+	tests := []struct {
+		name           string
+		nullableFields bool
+		want           []gotype.Type
+	}{
+		{
+			name: "not null",
+			want: []gotype.Type{
+				&gotype.OpaqueType{PgType: pg.Int8, Name: "int"},
+				&gotype.PointerType{Elem: &gotype.OpaqueType{PgType: pg.Text, Name: "string"}},
+			},
+		},
+		{
+			name:           "nullable fields",
+			nullableFields: true,
+			want: []gotype.Type{
+				&gotype.PointerType{Elem: &gotype.OpaqueType{PgType: pg.Int8, Name: "int"}},
+				&gotype.PointerType{Elem: &gotype.OpaqueType{PgType: pg.Text, Name: "string"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewTypeResolver(caser, nil, TypeResolverOpts{NullableCompositeFields: tt.nullableFields})
+			got, err := CreateCompositeType("", pgType, resolver, caser)
+			assert.NoError(t, err)
+			difftest.AssertSame(t, tt.want, got.(*gotype.CompositeType).FieldTypes)
+		})
+	}
+}
@@ -0,0 +1,443 @@
+// Package proto generates protobuf message definitions for the output rows of
+// queries.
+package proto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jschaf/pggen/internal/ast"
+	"github.com/jschaf/pggen/internal/casing"
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/pg"
+)
+
+// GenerateOptions are options to control generated .proto output.
+type GenerateOptions struct {
+	OutputDir string
+	// The protobuf package for the generated files, like "acme.users.v1".
+	// Defaults to the base name of OutputDir if empty.
+	ProtoPackage string
+	// The go_package option for the generated files, like
+	// "example.com/gen/userpb". Omitted if empty.
+	GoPackage string
+	// A map of lowercase acronyms to the upper case equivalent, like:
+	// "api" => "API".
+	Acronyms map[string]string
+}
+
+var protoPackageRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
+
+// Generate emits a .proto file for each of the queryFiles. Each file has a
+// message for the output row of each :one and :many query, except queries
+// with a proto-type pragma. The leader file, the first file by source path,
+// also declares an enum for each Postgres enum and a message for each
+// composite type used by any query. Reads and updates the NumbersFile in the
+// output directory so that field numbers never change.
+func Generate(opts GenerateOptions, queryFiles []codegen.QueryFile) error {
+	protoPkg := opts.ProtoPackage
+	if protoPkg == "" {
+		protoPkg = filepath.Base(opts.OutputDir)
+	}
+	if !protoPackageRegexp.MatchString(protoPkg) {
+		return fmt.Errorf("invalid proto package %q; must be dot-separated identifiers like acme.users", protoPkg)
+	}
+	numsPath := filepath.Join(opts.OutputDir, NumbersFile)
+	nums, err := readNumbers(numsPath)
+	if err != nil {
+		return err
+	}
+	caser := casing.NewCaser()
+	caser.AddAcronyms(opts.Acronyms)
+	g := &generator{
+		caser:      caser,
+		nums:       nums,
+		enums:      make(map[string]pg.EnumType),
+		composites: make(map[string]pg.CompositeType),
+		declared:   make(map[string]string),
+	}
+
+	files := make([]protoFile, len(queryFiles))
+	outNames := make(map[string]string, len(queryFiles))
+	for i, queryFile := range queryFiles {
+		file, err := g.buildFile(queryFile)
+		if err != nil {
+			return fmt.Errorf("generate proto for query file %s: %w", queryFile.SourcePath, err)
+		}
+		if other, ok := outNames[file.name]; ok {
+			return fmt.Errorf("query files %s and %s both generate %s", other, queryFile.SourcePath, file.name)
+		}
+		outNames[file.name] = queryFile.SourcePath
+		files[i] = file
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].sourcePath < files[j].sourcePath })
+
+	// Declare enums and composite types in the leader file.
+	leader := &files[0]
+	leader.enums, leader.messages, err = g.buildSharedTypes(leader.messages)
+	if err != nil {
+		return err
+	}
+	for _, msg := range leader.messages {
+		for _, f := range msg.fields {
+			if imp, ok := wellKnownImports[f.typ.name]; ok {
+				leader.imports[imp] = struct{}{}
+			}
+		}
+	}
+	for i := range files[1:] {
+		if files[i+1].usesShared {
+			files[i+1].imports[leader.name] = struct{}{}
+		}
+	}
+
+	for _, file := range files {
+		out := filepath.Join(opts.OutputDir, file.name)
+		if err := os.WriteFile(out, []byte(file.emit(protoPkg, opts.GoPackage)), 0o644); err != nil {
+			return fmt.Errorf("write generated proto file: %w", err)
+		}
+	}
+	return writeNumbers(numsPath, g.nums)
+}
+
+// generator builds protobuf messages and enums from Postgres types.
+type generator struct {
+	caser      casing.Caser
+	nums       numbers
+	enums      map[string]pg.EnumType      // Postgres enums to declare, keyed by protobuf name
+	composites map[string]pg.CompositeType // Postgres composite types to declare, keyed by protobuf name
+	// The Postgres type or query for each declared protobuf name, to detect
+	// collisions, like a composite type named "find_user_row" and a query
+	// named "FindUser".
+	declared map[string]string
+}
+
+// protoFile is a .proto file generated for a query file.
+type protoFile struct {
+	sourcePath string              // absolute path to the source SQL query file
+	name       string              // name of the .proto file, like "query.sql.proto"
+	messages   []message           // messages in order of declaration
+	enums      []enum              // enums in order of declaration; only set for the leader
+	imports    map[string]struct{} // imported .proto files
+	usesShared bool                // if true, a message uses an enum or composite type
+}
+
+// message is a protobuf message for the output row of a query or for a
+// composite type.
+type message struct {
+	name     string
+	doc      string // comment describing the message, without the "//" prefix
+	fields   []field
+	reserved []reservation // removed fields
+}
+
+type field struct {
+	name     string // protobuf field name, like "first_name"
+	typ      fieldType
+	optional bool // if true, the field tracks presence for a nullable column
+	num      int
+}
+
+// enum is a protobuf enum for a Postgres enum.
+type enum struct {
+	name     string
+	doc      string
+	values   []enumValue
+	reserved []reservation // removed labels
+}
+
+type enumValue struct {
+	name string // like "DEVICE_TYPE_PHONE"
+	num  int
+}
+
+// column is a column of an output row or a composite type.
+type column struct {
+	pgName   string
+	pgType   pg.Type
+	nullable bool
+}
+
+// buildFile builds the messages for the output rows of the queries in file.
+func (g *generator) buildFile(file codegen.QueryFile) (protoFile, error) {
+	pf := protoFile{
+		sourcePath: file.SourcePath,
+		name:       filepath.Base(file.SourcePath) + ".proto",
+		imports:    make(map[string]struct{}),
+	}
+	for _, query := range file.Queries {
+		switch {
+		case query.ResultKind == ast.ResultKindExec, query.ResultKind == ast.ResultKindExecRows,
+			query.ResultKind == ast.ResultKindCopyFrom, query.ProtobufType != "":
+			continue
+		}
+		cols := make([]column, 0, len(query.Outputs))
+		for _, out := range query.Outputs {
+			if _, ok := out.PgType.(pg.VoidType); ok {
+				continue
+			}
+			cols = append(cols, column{pgName: out.PgName, pgType: out.PgType, nullable: out.Nullable})
+		}
+		if len(cols) == 0 {
+			continue
+		}
+		name := g.caser.ToUpperGoIdent(query.Name) + "Row"
+		if err := g.declare(name, "query "+query.Name); err != nil {
+			return protoFile{}, err
+		}
+		doc := name + " is a row returned by the " + query.Name + " query."
+		msg, err := g.buildMessage(name, doc, cols)
+		if err != nil {
+			return protoFile{}, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		for _, f := range msg.fields {
+			if imp, ok := wellKnownImports[f.typ.name]; ok {
+				pf.imports[imp] = struct{}{}
+			}
+			pf.usesShared = pf.usesShared || f.typ.isShared
+		}
+		pf.messages = append(pf.messages, msg)
+	}
+	return pf, nil
+}
+
+// declare records that the protobuf name is declared for source. Returns an
+// error if a different source already declared the name.
+func (g *generator) declare(name, source string) error {
+	if other, ok := g.declared[name]; ok && other != source {
+		return fmt.Errorf("%s and %s both map to protobuf name %s", other, source, name)
+	}
+	g.declared[name] = source
+	return nil
+}
+
+// buildMessage builds a message with a field for each column. Assigns field
+// numbers from the numbers file.
+func (g *generator) buildMessage(name, doc string, cols []column) (message, error) {
+	fields := make([]field, len(cols))
+	names := make([]string, len(cols))
+	seen := make(map[string]string, len(cols))
+	for i, col := range cols {
+		fieldName := toFieldName(col.pgName)
+		if fieldName == "" {
+			fieldName = "column_" + strconv.Itoa(i+1)
+		}
+		if other, ok := seen[fieldName]; ok {
+			return message{}, fmt.Errorf("columns %q and %q both map to field %s of message %s", other, col.pgName, fieldName, name)
+		}
+		seen[fieldName] = col.pgName
+		typ, err := g.resolveType(col.pgType)
+		if err != nil {
+			return message{}, fmt.Errorf("column %s: %w", col.pgName, err)
+		}
+		fields[i] = field{
+			name:     fieldName,
+			typ:      typ,
+			optional: col.nullable && !typ.repeated && !typ.isMessage,
+		}
+		names[i] = fieldName
+	}
+	assigned, ok := g.nums.Messages[name]
+	if !ok {
+		assigned = make(map[string]int, len(names))
+		g.nums.Messages[name] = assigned
+	}
+	nums, reserved := assignNumbers(names, assigned, 1)
+	for i := range fields {
+		fields[i].num = nums[i]
+	}
+	return message{name: name, doc: doc, fields: fields, reserved: reserved}, nil
+}
+
+// registerEnum registers a Postgres enum to declare in the leader file.
+func (g *generator) registerEnum(typ pg.EnumType) error {
+	name := g.caser.ToUpperGoIdent(typ.Name)
+	if err := g.declare(name, pgTypeSource(typ.Schema, typ.Name)); err != nil {
+		return err
+	}
+	g.enums[name] = typ
+	return nil
+}
+
+// registerComposite registers a Postgres composite type to declare in the
+// leader file. Also registers the types of the composite type fields.
+func (g *generator) registerComposite(typ pg.CompositeType) error {
+	name := g.caser.ToUpperGoIdent(typ.Name)
+	if err := g.declare(name, pgTypeSource(typ.Schema, typ.Name)); err != nil {
+		return err
+	}
+	if _, ok := g.composites[name]; ok {
+		return nil
+	}
+	g.composites[name] = typ
+	for i, colType := range typ.ColumnTypes {
+		if _, err := g.resolveType(colType); err != nil {
+			return fmt.Errorf("composite type %s field %s: %w", typ.Name, typ.ColumnNames[i], err)
+		}
+	}
+	return nil
+}
+
+// pgTypeSource describes a Postgres type for collision errors, like
+// "Postgres type public.device_type".
+func pgTypeSource(schema, name string) string {
+	if schema == "" {
+		return "Postgres type " + name
+	}
+	return "Postgres type " + schema + "." + name
+}
+
+// buildSharedTypes builds the enums and composite type messages registered
+// while building the query messages. Returns the messages appended to msgs.
+func (g *generator) buildSharedTypes(msgs []message) ([]enum, []message, error) {
+	enumNames := make([]string, 0, len(g.enums))
+	for name := range g.enums {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+	enums := make([]enum, 0, len(enumNames))
+	for _, name := range enumNames {
+		e, err := g.buildEnum(name, g.enums[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		enums = append(enums, e)
+	}
+
+	compositeNames := make([]string, 0, len(g.composites))
+	for name := range g.composites {
+		compositeNames = append(compositeNames, name)
+	}
+	sort.Strings(compositeNames)
+	for _, name := range compositeNames {
+		typ := g.composites[name]
+		cols := make([]column, len(typ.ColumnNames))
+		for i, colName := range typ.ColumnNames {
+			notNull := i < len(typ.ColumnNotNulls) && typ.ColumnNotNulls[i]
+			cols[i] = column{pgName: colName, pgType: typ.ColumnTypes[i], nullable: !notNull}
+		}
+		doc := fmt.Sprintf("%s represents the Postgres composite type %q.", name, typ.Name)
+		msg, err := g.buildMessage(name, doc, cols)
+		if err != nil {
+			return nil, nil, fmt.Errorf("composite type %s: %w", typ.Name, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return enums, msgs, nil
+}
+
+// buildEnum builds a protobuf enum with a value for each label of a Postgres
+// enum. The zero value is the UNSPECIFIED value required by proto3. Assigns
+// value numbers from the numbers file keyed by label.
+func (g *generator) buildEnum(name string, typ pg.EnumType) (enum, error) {
+	prefix := toEnumValuePrefix(typ.Name)
+	valueName := func(label string, i int) string {
+		suffix := strings.ToUpper(toFieldName(label))
+		if suffix == "" {
+			suffix = "VALUE_" + strconv.Itoa(i+1)
+		}
+		return prefix + "_" + suffix
+	}
+	unspecified := prefix + "_UNSPECIFIED"
+	seen := map[string]string{unspecified: ""}
+	for i, label := range typ.Labels {
+		value := valueName(label, i)
+		if other, ok := seen[value]; ok {
+			return enum{}, fmt.Errorf("enum %s labels %q and %q both map to enum value %s", typ.Name, other, label, value)
+		}
+		seen[value] = label
+	}
+
+	assigned, ok := g.nums.Enums[name]
+	if !ok {
+		assigned = make(map[string]int, len(typ.Labels))
+		g.nums.Enums[name] = assigned
+	}
+	nums, reserved := assignNumbers(typ.Labels, assigned, 1)
+	values := make([]enumValue, 0, len(typ.Labels)+1)
+	values = append(values, enumValue{name: unspecified, num: 0})
+	for i, label := range typ.Labels {
+		values = append(values, enumValue{name: valueName(label, i), num: nums[i]})
+	}
+	for i, r := range reserved {
+		reserved[i].name = valueName(r.name, r.num-1)
+	}
+	return enum{
+		name:     name,
+		doc:      fmt.Sprintf("%s represents the Postgres enum %q.", name, typ.Name),
+		values:   values,
+		reserved: reserved,
+	}, nil
+}
+
+// emit emits the content of the .proto file.
+func (pf protoFile) emit(protoPkg, goPkg string) string {
+	sb := &strings.Builder{}
+	sb.WriteString("// Code generated by pggen. DO NOT EDIT.\n\n")
+	sb.WriteString("syntax = \"proto3\";\n\n")
+	sb.WriteString("package " + protoPkg + ";\n")
+	if len(pf.imports) > 0 {
+		imports := make([]string, 0, len(pf.imports))
+		for imp := range pf.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		sb.WriteByte('\n')
+		for _, imp := range imports {
+			sb.WriteString("import " + strconv.Quote(imp) + ";\n")
+		}
+	}
+	if goPkg != "" {
+		sb.WriteString("\noption go_package = " + strconv.Quote(goPkg) + ";\n")
+	}
+	for _, msg := range pf.messages {
+		sb.WriteString("\n// " + msg.doc + "\n")
+		sb.WriteString("message " + msg.name + " {\n")
+		for _, f := range msg.fields {
+			sb.WriteString("  ")
+			switch {
+			case f.typ.repeated:
+				sb.WriteString("repeated ")
+			case f.optional:
+				sb.WriteString("optional ")
+			}
+			sb.WriteString(f.typ.name + " " + f.name + " = " + strconv.Itoa(f.num) + ";\n")
+		}
+		emitReserved(sb, msg.reserved)
+		sb.WriteString("}\n")
+	}
+	for _, e := range pf.enums {
+		sb.WriteString("\n// " + e.doc + "\n")
+		sb.WriteString("enum " + e.name + " {\n")
+		for _, v := range e.values {
+			sb.WriteString("  " + v.name + " = " + strconv.Itoa(v.num) + ";\n")
+		}
+		emitReserved(sb, e.reserved)
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// emitReserved emits reserved statements for the numbers and names of
+// removed fields or enum values.
+func emitReserved(sb *strings.Builder, reserved []reservation) {
+	if len(reserved) == 0 {
+		return
+	}
+	nums := make([]string, len(reserved))
+	names := make([]string, len(reserved))
+	for i, r := range reserved {
+		nums[i] = strconv.Itoa(r.num)
+		names[i] = strconv.Quote(r.name)
+	}
+	sb.WriteString("  reserved " + strings.Join(nums, ", ") + ";\n")
+	sb.WriteString("  reserved " + strings.Join(names, ", ") + ";\n")
+}
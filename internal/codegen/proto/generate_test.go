@@ -0,0 +1,229 @@
+package proto
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/ast"
+	"github.com/jschaf/pggen/internal/codegen"
+	"github.com/jschaf/pggen/internal/pg"
+	"github.com/jschaf/pggen/internal/pginfer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var update = flag.Bool("update", false, "update golden files if true")
+
+func TestGenerate(t *testing.T) {
+	deviceType := pg.EnumType{ID: 16400, Name: "device_type", Schema: "public", Labels: []string{"phone", "laptop", "smart watch"}}
+	userType := pg.CompositeType{
+		ID:             16410,
+		Name:           "user",
+		Schema:         "public",
+		ColumnNames:    []string{"id", "name", "joined_at"},
+		ColumnTypes:    []pg.Type{pg.Int8, pg.Text, pg.Timestamptz},
+		ColumnNotNulls: []bool{true, false, false},
+	}
+	dir := t.TempDir()
+	deviceFile := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "device.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:       "FindDevices",
+				ResultKind: ast.ResultKindMany,
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+					{PgName: "name", PgType: pg.Text, Nullable: true},
+					{PgName: "type", PgType: deviceType},
+					{PgName: "owner", PgType: userType, Nullable: true},
+					{PgName: "tags", PgType: pg.ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: pg.Text}},
+					{PgName: "uptime", PgType: pg.Interval, Nullable: true},
+					{PgName: "pg_sleep", PgType: pg.Void},
+				},
+			},
+			{
+				Name:       "DeleteDevice",
+				ResultKind: ast.ResultKindExec,
+			},
+		},
+	}
+	userFile := codegen.QueryFile{
+		SourcePath: filepath.Join(dir, "author.sql"),
+		Queries: []pginfer.TypedQuery{
+			{
+				Name:       "CountAuthors",
+				ResultKind: ast.ResultKindOne,
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "count", PgType: pg.Int8},
+				},
+			},
+			{
+				Name:         "FindAuthorProto",
+				ResultKind:   ast.ResultKindOne,
+				ProtobufType: "acme.users.v1.Author",
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "id", PgType: pg.Int8},
+				},
+			},
+			{
+				Name:       "FindAuthor",
+				ResultKind: ast.ResultKindOne,
+				Outputs: []pginfer.OutputColumn{
+					{PgName: "author_id", PgType: pg.Int4},
+					{PgName: "?column?", PgType: pg.Bool, Nullable: true},
+					{PgName: "avatar", PgType: pg.Bytea, Nullable: true},
+					{PgName: "balance", PgType: pg.Numeric, Nullable: true},
+					{PgName: "born_on", PgType: pg.Date},
+				},
+			},
+		},
+	}
+
+	err := Generate(GenerateOptions{
+		OutputDir:    dir,
+		ProtoPackage: "acme.users.v1",
+		GoPackage:    "example.com/gen/userpb",
+	}, []codegen.QueryFile{deviceFile, userFile})
+	require.NoError(t, err)
+
+	for _, name := range []string{"author.sql.proto", "device.sql.proto", NumbersFile} {
+		t.Run(name, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			golden := filepath.Join("testdata", "generate_"+name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got, 0o644))
+				return
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+func TestGenerate_StableNumbers(t *testing.T) {
+	dir := t.TempDir()
+	status := pg.EnumType{ID: 16400, Name: "status", Labels: []string{"active", "banned"}}
+	newFile := func(outputs ...pginfer.OutputColumn) []codegen.QueryFile {
+		return []codegen.QueryFile{{
+			SourcePath: filepath.Join(dir, "query.sql"),
+			Queries: []pginfer.TypedQuery{{
+				Name:       "FindUser",
+				ResultKind: ast.ResultKindOne,
+				Outputs:    outputs,
+			}},
+		}}
+	}
+	opts := GenerateOptions{OutputDir: dir, ProtoPackage: "users"}
+	err := Generate(opts, newFile(
+		pginfer.OutputColumn{PgName: "id", PgType: pg.Int8},
+		pginfer.OutputColumn{PgName: "name", PgType: pg.Text},
+		pginfer.OutputColumn{PgName: "status", PgType: status},
+	))
+	require.NoError(t, err)
+
+	// Remove the name column, add an email column before the status column,
+	// and add an enum label before the existing labels.
+	status.Labels = []string{"pending", "active", "banned"}
+	err = Generate(opts, newFile(
+		pginfer.OutputColumn{PgName: "id", PgType: pg.Int8},
+		pginfer.OutputColumn{PgName: "email", PgType: pg.Text},
+		pginfer.OutputColumn{PgName: "status", PgType: status},
+	))
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "query.sql.proto"))
+	require.NoError(t, err)
+	want := `// Code generated by pggen. DO NOT EDIT.
+
+syntax = "proto3";
+
+package users;
+
+// FindUserRow is a row returned by the FindUser query.
+message FindUserRow {
+  int64 id = 1;
+  string email = 4;
+  Status status = 3;
+  reserved 2;
+  reserved "name";
+}
+
+// Status represents the Postgres enum "status".
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_PENDING = 3;
+  STATUS_ACTIVE = 1;
+  STATUS_BANNED = 2;
+}
+`
+	assert.Equal(t, want, string(got))
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     string
+		outputs []pginfer.OutputColumn
+		wantErr string
+	}{
+		{
+			name:    "invalid package",
+			pkg:     "acme-users",
+			wantErr: `invalid proto package "acme-users"`,
+		},
+		{
+			name: "duplicate field",
+			pkg:  "users",
+			outputs: []pginfer.OutputColumn{
+				{PgName: "first name", PgType: pg.Text},
+				{PgName: "first_name", PgType: pg.Text},
+			},
+			wantErr: `query FindUser: columns "first name" and "first_name" both map to field first_name of message FindUserRow`,
+		},
+		{
+			name: "multidimensional array",
+			pkg:  "users",
+			outputs: []pginfer.OutputColumn{
+				{PgName: "grid", PgType: pg.ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: pg.Int4, Dimensions: 2}},
+			},
+			wantErr: "query FindUser: column grid: multidimensional array type _int4 is not supported",
+		},
+		{
+			name: "enum value collision",
+			pkg:  "users",
+			outputs: []pginfer.OutputColumn{
+				{PgName: "status", PgType: pg.EnumType{Name: "status", Labels: []string{"in progress", "in_progress"}}},
+			},
+			wantErr: `enum status labels "in progress" and "in_progress" both map to enum value STATUS_IN_PROGRESS`,
+		},
+		{
+			name: "name collision",
+			pkg:  "users",
+			outputs: []pginfer.OutputColumn{
+				{PgName: "row", PgType: pg.CompositeType{Name: "find_user_row", Schema: "public"}},
+			},
+			wantErr: "query FindUser and Postgres type public.find_user_row both map to protobuf name FindUserRow",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := []codegen.QueryFile{{
+				SourcePath: filepath.Join(dir, "query.sql"),
+				Queries: []pginfer.TypedQuery{{
+					Name:       "FindUser",
+					ResultKind: ast.ResultKindOne,
+					Outputs:    tt.outputs,
+				}},
+			}}
+			err := Generate(GenerateOptions{OutputDir: dir, ProtoPackage: tt.pkg}, files)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package proto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// NumbersFile is the name of the sidecar file in the output directory that
+// records the field number of each message field and the number of each enum
+// value. pggen reads the file before generating .proto files so that fields
+// and enum values keep their numbers across runs.
+const NumbersFile = "pggen_proto_numbers.json"
+
+// numbers is the content of NumbersFile. Each map is keyed by the name of the
+// message or enum. The inner map is keyed by field name or enum label. pggen
+// never removes an entry, so a removed field keeps its number to reserve it.
+type numbers struct {
+	Messages map[string]map[string]int `json:"messages"`
+	Enums    map[string]map[string]int `json:"enums"`
+}
+
+// readNumbers reads the numbers file at path. Returns empty numbers if the
+// file doesn't exist.
+func readNumbers(path string) (numbers, error) {
+	nums := numbers{
+		Messages: make(map[string]map[string]int),
+		Enums:    make(map[string]map[string]int),
+	}
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nums, nil
+	}
+	if err != nil {
+		return numbers{}, fmt.Errorf("read proto numbers file: %w", err)
+	}
+	if err := json.Unmarshal(bs, &nums); err != nil {
+		return numbers{}, fmt.Errorf("unmarshal proto numbers file %s: %w", path, err)
+	}
+	if nums.Messages == nil {
+		nums.Messages = make(map[string]map[string]int)
+	}
+	if nums.Enums == nil {
+		nums.Enums = make(map[string]map[string]int)
+	}
+	return nums, nil
+}
+
+// writeNumbers writes the numbers file to path with sorted keys.
+func writeNumbers(path string, nums numbers) error {
+	bs, err := json.MarshalIndent(nums, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal proto numbers file: %w", err)
+	}
+	bs = append(bs, '\n')
+	if err := os.WriteFile(path, bs, 0o644); err != nil {
+		return fmt.Errorf("write proto numbers file: %w", err)
+	}
+	return nil
+}
+
+// assignNumbers returns the number for each name in names using the
+// previously assigned numbers in assigned. Assigns new names the next unused
+// number after the largest assigned number, starting at first. Adds the new
+// names to assigned. Also returns the numbers and names in assigned that
+// aren't in names, sorted by number, so the caller can reserve them.
+func assignNumbers(names []string, assigned map[string]int, first int) ([]int, []reservation) {
+	next := first
+	for _, num := range assigned {
+		if num >= next {
+			next = num + 1
+		}
+	}
+	nums := make([]int, len(names))
+	used := make(map[string]bool, len(names))
+	for i, name := range names {
+		num, ok := assigned[name]
+		if !ok {
+			num = next
+			next++
+			assigned[name] = num
+		}
+		nums[i] = num
+		used[name] = true
+	}
+	var reserved []reservation
+	for name, num := range assigned {
+		if !used[name] {
+			reserved = append(reserved, reservation{name: name, num: num})
+		}
+	}
+	sort.Slice(reserved, func(i, j int) bool { return reserved[i].num < reserved[j].num })
+	return nums, reserved
+}
+
+// reservation is a field or enum value that was removed since a previous run.
+// The generated message or enum reserves the number and name so that no
+// future field or value reuses them.
+type reservation struct {
+	name string
+	num  int
+}
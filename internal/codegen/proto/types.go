@@ -0,0 +1,121 @@
+package proto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgtype"
+	"github.com/jschaf/pggen/internal/pg"
+)
+
+const (
+	timestampType = "google.protobuf.Timestamp"
+	durationType  = "google.protobuf.Duration"
+)
+
+// wellKnownImports maps a protobuf well-known type to the file to import.
+//
+//nolint:gochecknoglobals
+var wellKnownImports = map[string]string{
+	timestampType: "google/protobuf/timestamp.proto",
+	durationType:  "google/protobuf/duration.proto",
+}
+
+// fieldType is the protobuf type of a message field.
+type fieldType struct {
+	name      string // protobuf type name, like "int64" or "DeviceType"
+	repeated  bool   // if true, a repeated field for a Postgres array
+	isMessage bool   // if true, a message type that tracks presence without optional
+	isShared  bool   // if true, an enum or composite message declared in the leader file
+}
+
+// scalarTypes maps a Postgres type OID to the protobuf scalar type or
+// well-known type. Postgres types not in the map, like text, numeric, uuid,
+// and json, use the string type with the Postgres text format.
+//
+//nolint:gochecknoglobals
+var scalarTypes = map[pgtype.OID]string{
+	pgtype.BoolOID:        "bool",
+	pgtype.Int2OID:        "int32",
+	pgtype.Int4OID:        "int32",
+	pgtype.Int8OID:        "int64",
+	pgtype.OIDOID:         "uint32",
+	pgtype.XIDOID:         "uint32",
+	pgtype.CIDOID:         "uint32",
+	pgtype.Float4OID:      "float",
+	pgtype.Float8OID:      "double",
+	pgtype.ByteaOID:       "bytes",
+	pgtype.DateOID:        timestampType,
+	pgtype.TimestampOID:   timestampType,
+	pgtype.TimestamptzOID: timestampType,
+	pgtype.IntervalOID:    durationType,
+}
+
+// resolveType resolves the protobuf type for a Postgres type. Registers enums
+// and composite types with the generator so the leader file declares them.
+func (g *generator) resolveType(pgt pg.Type) (fieldType, error) {
+	switch pgt := pgt.(type) {
+	case pg.DomainType:
+		return g.resolveType(pgt.BaseType)
+	case pg.ArrayType:
+		if pgt.OID() == pgtype.ByteaArrayOID {
+			return fieldType{name: "bytes", repeated: true}, nil
+		}
+		elem, err := g.resolveType(pgt.Elem)
+		if err != nil {
+			return fieldType{}, err
+		}
+		if elem.repeated || pgt.Dimensions > 1 {
+			return fieldType{}, fmt.Errorf("multidimensional array type %s is not supported", pgt.Name)
+		}
+		elem.repeated = true
+		return elem, nil
+	case pg.EnumType:
+		if err := g.registerEnum(pgt); err != nil {
+			return fieldType{}, err
+		}
+		return fieldType{name: g.caser.ToUpperGoIdent(pgt.Name), isShared: true}, nil
+	case pg.CompositeType:
+		if err := g.registerComposite(pgt); err != nil {
+			return fieldType{}, err
+		}
+		return fieldType{name: g.caser.ToUpperGoIdent(pgt.Name), isMessage: true, isShared: true}, nil
+	default:
+		name, ok := scalarTypes[pgt.OID()]
+		if !ok {
+			return fieldType{name: "string"}, nil
+		}
+		_, isWellKnown := wellKnownImports[name]
+		return fieldType{name: name, isMessage: isWellKnown}, nil
+	}
+}
+
+// toFieldName converts a Postgres column name into a protobuf field name,
+// like "first_name". Replaces characters that aren't legal in an identifier
+// with an underscore. Returns the empty string if no conversion is possible.
+func toFieldName(pgName string) string {
+	sb := strings.Builder{}
+	for _, ch := range strings.ToLower(pgName) {
+		switch {
+		case ch >= 'a' && ch <= 'z', ch == '_':
+			sb.WriteRune(ch)
+		case ch >= '0' && ch <= '9':
+			if sb.Len() == 0 {
+				sb.WriteString("column_")
+			}
+			sb.WriteRune(ch)
+		default:
+			if sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+		}
+	}
+	return strings.Trim(sb.String(), "_")
+}
+
+// toEnumValuePrefix converts an enum name into the prefix of each enum value
+// name, like "DEVICE_TYPE" for "device_type". protobuf enum values are
+// siblings of the enum type, so the prefix avoids collisions between enums.
+func toEnumValuePrefix(pgName string) string {
+	return strings.ToUpper(toFieldName(pgName))
+}
@@ -0,0 +1,37 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFieldName(t *testing.T) {
+	tests := []struct {
+		pgName string
+		want   string
+	}{
+		{"id", "id"},
+		{"first_name", "first_name"},
+		{"FirstName", "firstname"},
+		{"first name", "first_name"},
+		{"2fa_enabled", "column_2fa_enabled"},
+		{"?column?", "column"},
+		{"_private", "private"},
+		{"", ""},
+		{"??", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pgName, func(t *testing.T) {
+			assert.Equal(t, tt.want, toFieldName(tt.pgName))
+		})
+	}
+}
+
+func TestAssignNumbers(t *testing.T) {
+	assigned := map[string]int{"id": 1, "name": 2, "email": 5}
+	nums, reserved := assignNumbers([]string{"id", "age", "email", "bio"}, assigned, 1)
+	assert.Equal(t, []int{1, 6, 5, 7}, nums)
+	assert.Equal(t, []reservation{{name: "name", num: 2}}, reserved)
+	assert.Equal(t, map[string]int{"id": 1, "name": 2, "email": 5, "age": 6, "bio": 7}, assigned)
+}
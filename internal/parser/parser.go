@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	goscan "go/scanner"
 	gotok "go/token"
@@ -203,7 +204,7 @@ func (p *parser) errorExpected(pos gotok.Pos, msg string) {
 }
 
 // Regexp to extract query annotations that control output.
-var annotationRegexp = regexp.MustCompile(`name: ([a-zA-Z0-9_$]+)[ \t]+(:many|:one|:exec)[ \t]*(.*)`)
+var annotationRegexp = regexp.MustCompile(`name: ([a-zA-Z0-9_$]+)[ \t]+(:many|:one|:opt|:map|:execrows|:execresult|:exec|:copyfrom|:iter)[ \t]*(.*)`)
 
 func (p *parser) parseQuery() ast.Query {
 	if p.trace {
@@ -263,6 +264,48 @@ func (p *parser) parseQuery() ast.Query {
 	templateSQL := sql.String()
 	preparedSQL, params := prepareSQL(templateSQL, names)
 
+	resultKind := ast.ResultKind(annotations[2])
+	if resultKind == ":execresult" {
+		// :exec already returns the command tag for pgx or sql.Result for
+		// database/sql, so there's nothing for :execresult to add.
+		p.error(pos, "unsupported result kind :execresult; use :exec, which returns the pgconn.CommandTag or sql.Result")
+		return &ast.BadQuery{From: pos, To: p.pos}
+	}
+	if pragmas.CursorSize > 0 && resultKind != ast.ResultKindMany && resultKind != ast.ResultKindIter {
+		p.error(pos, "invalid query pragma: cursor requires a :many or :iter query")
+		return &ast.BadQuery{From: pos, To: p.pos}
+	}
+	if pragmas.AffectedRows != nil && resultKind != ast.ResultKindExec && resultKind != ast.ResultKindExecRows {
+		p.error(pos, "invalid query pragma: affected requires an :exec or :execrows query")
+		return &ast.BadQuery{From: pos, To: p.pos}
+	}
+	if (pragmas.MapKey != "") != (resultKind == ast.ResultKindMap) {
+		p.error(pos, "invalid query pragma: a :map query requires a key pragma and only a :map query may have a key pragma")
+		return &ast.BadQuery{From: pos, To: p.pos}
+	}
+	if pragmas.GroupBy != "" && (resultKind != ast.ResultKindMany || pragmas.CursorSize > 0) {
+		p.error(pos, "invalid query pragma: group-by requires a :many query without a cursor pragma")
+		return &ast.BadQuery{From: pos, To: p.pos}
+	}
+	if pragmas.RowType != "" {
+		switch {
+		case resultKind == ast.ResultKindExec || resultKind == ast.ResultKindExecRows || resultKind == ast.ResultKindCopyFrom:
+			p.error(pos, "invalid query pragma: row-type requires a query that returns rows")
+			return &ast.BadQuery{From: pos, To: p.pos}
+		case pragmas.ProtobufType != "" || pragmas.GroupBy != "":
+			p.error(pos, "invalid query pragma: row-type can't be used with a proto-type or group-by pragma")
+			return &ast.BadQuery{From: pos, To: p.pos}
+		}
+	}
+	var copyFrom ast.CopyFromTarget
+	if resultKind == ast.ResultKindCopyFrom {
+		copyFrom, err = parseCopyFromTarget(preparedSQL, len(params))
+		if err != nil {
+			p.error(pos, "invalid :copyfrom query: "+err.Error())
+			return &ast.BadQuery{From: pos, To: p.pos}
+		}
+	}
+
 	return &ast.SourceQuery{
 		Name:        annotations[1],
 		Doc:         doc,
@@ -270,8 +313,9 @@ func (p *parser) parseQuery() ast.Query {
 		SourceSQL:   templateSQL,
 		PreparedSQL: preparedSQL,
 		ParamNames:  params,
-		ResultKind:  ast.ResultKind(annotations[2]),
+		ResultKind:  resultKind,
 		Pragmas:     pragmas,
+		CopyFrom:    copyFrom,
 		Semi:        semi,
 	}
 }
@@ -296,6 +340,57 @@ func parsePragmas(allPragmas string) (ast.Pragmas, error) {
 				return ast.Pragmas{}, err
 			}
 			qp.ProtobufType = p
+		case "record":
+			col, fields, err := parseRecordPragma(val)
+			if err != nil {
+				return ast.Pragmas{}, err
+			}
+			if _, ok := qp.RecordTypes[col]; ok {
+				return ast.Pragmas{}, fmt.Errorf("duplicate record pragma for column %q", col)
+			}
+			if qp.RecordTypes == nil {
+				qp.RecordTypes = make(map[string][]ast.RecordField, 1)
+			}
+			qp.RecordTypes[col] = fields
+		case "go-type":
+			name, goType, ok := strings.Cut(val, ":")
+			if !ok || name == "" || goType == "" {
+				return ast.Pragmas{}, fmt.Errorf("invalid go-type, expected format name:type; got %q", val)
+			}
+			if _, ok := qp.GoTypes[name]; ok {
+				return ast.Pragmas{}, fmt.Errorf("duplicate go-type pragma for %q", name)
+			}
+			if qp.GoTypes == nil {
+				qp.GoTypes = make(map[string]string, 1)
+			}
+			qp.GoTypes[name] = goType
+		case "cursor":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return ast.Pragmas{}, fmt.Errorf("invalid cursor, expected a positive number of rows to fetch; got %q", val)
+			}
+			qp.CursorSize = n
+		case "affected":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return ast.Pragmas{}, fmt.Errorf("invalid affected, expected a non-negative number of rows; got %q", val)
+			}
+			qp.AffectedRows = &n
+		case "key":
+			if val == "" {
+				return ast.Pragmas{}, errors.New("invalid key, expected an output column name")
+			}
+			qp.MapKey = val
+		case "group-by":
+			if val == "" {
+				return ast.Pragmas{}, errors.New("invalid group-by, expected an output column name")
+			}
+			qp.GroupBy = val
+		case "row-type":
+			if !gotok.IsIdentifier(val) {
+				return ast.Pragmas{}, fmt.Errorf("invalid row-type, expected a Go identifier; got %q", val)
+			}
+			qp.RowType = val
 		default:
 			return ast.Pragmas{}, fmt.Errorf("unsupported pramga %q", key)
 		}
@@ -328,6 +423,52 @@ func validateProtoMsgType(val string) (string, error) {
 	return val, nil
 }
 
+// parseRecordPragma parses the column name and fields of a record pragma value
+// like pair(id:int8,name:text). The Postgres type of a field may contain
+// parentheses and commas, like numeric(10,2).
+func parseRecordPragma(val string) (string, []ast.RecordField, error) {
+	open := strings.IndexByte(val, '(')
+	if open <= 0 || !strings.HasSuffix(val, ")") {
+		return "", nil, fmt.Errorf("invalid record, expected format column(field:type,...); got %q", val)
+	}
+	col := val[:open]
+	var fields []ast.RecordField
+	for _, f := range splitTopLevel(val[open+1 : len(val)-1]) {
+		name, typ, ok := strings.Cut(f, ":")
+		if !ok || name == "" || typ == "" {
+			return "", nil, fmt.Errorf("invalid record field for column %q, expected format field:type; got %q", col, f)
+		}
+		fields = append(fields, ast.RecordField{Name: name, Type: typ})
+	}
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("invalid record, column %q must have at least one field", col)
+	}
+	return col, fields, nil
+}
+
+// splitTopLevel splits s on commas that aren't nested in parentheses.
+func splitTopLevel(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
 // argPos is the name and position of expression like pggen.arg('foo').
 type argPos struct {
 	lo, hi int
@@ -396,6 +537,91 @@ func prepareSQL(sql string, args []argPos) (string, []string) {
 	return sb.String(), params
 }
 
+// copyFromRegexp matches the prepared SQL of a :copyfrom query, like
+// INSERT INTO author (first_name, last_name) VALUES ($1, $2).
+var copyFromRegexp = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+([^\s(]+)\s*\(([^)]*)\)\s*VALUES\s*\(([^)]*)\)\s*;?\s*$`)
+
+// parseCopyFromTarget parses the table and columns from the prepared SQL of a
+// :copyfrom query. Each value must be a distinct pggen.arg, prepared as $n, so
+// that each param maps to exactly one column.
+func parseCopyFromTarget(preparedSQL string, numParams int) (ast.CopyFromTarget, error) {
+	m := copyFromRegexp.FindStringSubmatch(preparedSQL)
+	if m == nil {
+		return ast.CopyFromTarget{}, errors.New("expected an INSERT INTO table (col, ...) VALUES (pggen.arg('col'), ...) statement")
+	}
+	table, err := splitIdentifier(m[1])
+	if err != nil {
+		return ast.CopyFromTarget{}, fmt.Errorf("table name: %w", err)
+	}
+	var cols []string
+	for _, col := range strings.Split(m[2], ",") {
+		parts, err := splitIdentifier(strings.TrimSpace(col))
+		if err != nil || len(parts) != 1 {
+			return ast.CopyFromTarget{}, fmt.Errorf("invalid column name %q", strings.TrimSpace(col))
+		}
+		cols = append(cols, parts[0])
+	}
+	values := strings.Split(m[3], ",")
+	if len(values) != len(cols) || numParams != len(cols) {
+		return ast.CopyFromTarget{}, fmt.Errorf("expected one distinct pggen.arg for each of the %d columns", len(cols))
+	}
+	for i, val := range values {
+		if strings.TrimSpace(val) != "$"+strconv.Itoa(i+1) {
+			return ast.CopyFromTarget{}, fmt.Errorf("value for column %s must be a pggen.arg; got %q", cols[i], strings.TrimSpace(val))
+		}
+	}
+	return ast.CopyFromTarget{Table: table, Columns: cols}, nil
+}
+
+// splitIdentifier splits a possibly schema-qualified Postgres identifier, like
+// public."Author", into parts. Folds unquoted parts to lower case like
+// Postgres.
+func splitIdentifier(ident string) ([]string, error) {
+	var parts []string
+	for ident != "" {
+		var part string
+		if ident[0] == '"' {
+			end := 1
+			for {
+				idx := strings.IndexByte(ident[end:], '"')
+				if idx == -1 {
+					return nil, fmt.Errorf("unterminated quoted identifier %q", ident)
+				}
+				end += idx + 1
+				if end < len(ident) && ident[end] == '"' {
+					end++ // escaped quote
+					continue
+				}
+				break
+			}
+			part = strings.ReplaceAll(ident[1:end-1], `""`, `"`)
+			ident = ident[end:]
+		} else {
+			end := strings.IndexByte(ident, '.')
+			if end == -1 {
+				end = len(ident)
+			}
+			part = strings.ToLower(ident[:end])
+			ident = ident[end:]
+		}
+		if part == "" {
+			return nil, errors.New("empty identifier")
+		}
+		parts = append(parts, part)
+		if ident == "" {
+			break
+		}
+		if ident[0] != '.' || len(ident) == 1 {
+			return nil, fmt.Errorf("invalid identifier near %q", ident)
+		}
+		ident = ident[1:]
+	}
+	if len(parts) == 0 || len(parts) > 2 {
+		return nil, errors.New("expected a table name or schema.table")
+	}
+	return parts, nil
+}
+
 // ----------------------------------------------------------------------------
 // Source files
 
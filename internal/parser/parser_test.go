@@ -2,11 +2,13 @@ package parser
 
 import (
 	gotok "go/token"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/jschaf/pggen/internal/ast"
+	"github.com/jschaf/pggen/internal/ptrs"
 )
 
 func ignoreCommentPos() cmp.Option {
@@ -121,6 +123,156 @@ func TestParseFile_Queries(t *testing.T) {
 				Pragmas:     ast.Pragmas{ProtobufType: "Bar"},
 			},
 		},
+		{
+			"-- name: Qux :one record=pair(id:int8,price:numeric(10,2)) record=tags(names:text[])\nSELECT 1;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :one record=pair(id:int8,price:numeric(10,2)) record=tags(names:text[])"}}},
+				SourceSQL:   "SELECT 1;",
+				PreparedSQL: "SELECT 1;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindOne,
+				Pragmas: ast.Pragmas{RecordTypes: map[string][]ast.RecordField{
+					"pair": {{Name: "id", Type: "int8"}, {Name: "price", Type: "numeric(10,2)"}},
+					"tags": {{Name: "names", Type: "text[]"}},
+				}},
+			},
+		},
+		{
+			"-- name: Qux :one go-type=settings:example.com/user.Settings go-type=id:*int\nSELECT 1;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :one go-type=settings:example.com/user.Settings go-type=id:*int"}}},
+				SourceSQL:   "SELECT 1;",
+				PreparedSQL: "SELECT 1;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindOne,
+				Pragmas: ast.Pragmas{GoTypes: map[string]string{
+					"settings": "example.com/user.Settings",
+					"id":       "*int",
+				}},
+			},
+		},
+		{
+			"-- name: Qux :many cursor=1000\nSELECT 1;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :many cursor=1000"}}},
+				SourceSQL:   "SELECT 1;",
+				PreparedSQL: "SELECT 1;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindMany,
+				Pragmas:     ast.Pragmas{CursorSize: 1000},
+			},
+		},
+		{
+			"-- name: Qux :opt\nSELECT 1;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :opt"}}},
+				SourceSQL:   "SELECT 1;",
+				PreparedSQL: "SELECT 1;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindOpt,
+			},
+		},
+		{
+			"-- name: Qux :map key=id\nSELECT 1 AS id;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :map key=id"}}},
+				SourceSQL:   "SELECT 1 AS id;",
+				PreparedSQL: "SELECT 1 AS id;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindMap,
+				Pragmas:     ast.Pragmas{MapKey: "id"},
+			},
+		},
+		{
+			"-- name: Qux :many group-by=id\nSELECT 1 AS id;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :many group-by=id"}}},
+				SourceSQL:   "SELECT 1 AS id;",
+				PreparedSQL: "SELECT 1 AS id;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindMany,
+				Pragmas:     ast.Pragmas{GroupBy: "id"},
+			},
+		},
+		{
+			"-- name: Qux :one row-type=Author\nSELECT 1 AS id, 'a' AS name;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :one row-type=Author"}}},
+				SourceSQL:   "SELECT 1 AS id, 'a' AS name;",
+				PreparedSQL: "SELECT 1 AS id, 'a' AS name;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindOne,
+				Pragmas:     ast.Pragmas{RowType: "Author"},
+			},
+		},
+		{
+			"-- name: Qux :execrows\nDELETE FROM foo;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :execrows"}}},
+				SourceSQL:   "DELETE FROM foo;",
+				PreparedSQL: "DELETE FROM foo;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindExecRows,
+			},
+		},
+		{
+			"-- name: Qux :exec affected=1\nUPDATE foo SET version = version + 1;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :exec affected=1"}}},
+				SourceSQL:   "UPDATE foo SET version = version + 1;",
+				PreparedSQL: "UPDATE foo SET version = version + 1;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindExec,
+				Pragmas:     ast.Pragmas{AffectedRows: ptrs.Int(1)},
+			},
+		},
+		{
+			"-- name: Qux :execrows affected=0\nDELETE FROM foo;",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :execrows affected=0"}}},
+				SourceSQL:   "DELETE FROM foo;",
+				PreparedSQL: "DELETE FROM foo;",
+				ParamNames:  nil,
+				ResultKind:  ast.ResultKindExecRows,
+				Pragmas:     ast.Pragmas{AffectedRows: ptrs.Int(0)},
+			},
+		},
+		{
+			"-- name: Qux :iter\nSELECT pggen.arg('limit');",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :iter"}}},
+				SourceSQL:   "SELECT pggen.arg('limit');",
+				PreparedSQL: "SELECT $1;",
+				ParamNames:  []string{"limit"},
+				ResultKind:  ast.ResultKindIter,
+			},
+		},
+		{
+			"-- name: Qux :copyfrom\nINSERT INTO public.\"Author\" (id, \"First Name\") VALUES (pggen.arg('id'), pggen.arg('first_name'));",
+			&ast.SourceQuery{
+				Name:        "Qux",
+				Doc:         &ast.CommentGroup{List: []*ast.LineComment{{Text: "-- name: Qux :copyfrom"}}},
+				SourceSQL:   "INSERT INTO public.\"Author\" (id, \"First Name\") VALUES (pggen.arg('id'), pggen.arg('first_name'));",
+				PreparedSQL: "INSERT INTO public.\"Author\" (id, \"First Name\") VALUES ($1, $2);",
+				ParamNames:  []string{"id", "first_name"},
+				ResultKind:  ast.ResultKindCopyFrom,
+				CopyFrom: ast.CopyFromTarget{
+					Table:   []string{"public", "Author"},
+					Columns: []string{"id", "First Name"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,3 +306,70 @@ func TestParseFile_Queries_Fuzz(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFile_InvalidPragmas(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{"-- name: Qux :one record=pair\nSELECT 1;", "invalid record"},
+		{"-- name: Qux :one record=pair()\nSELECT 1;", "must have at least one field"},
+		{"-- name: Qux :one record=pair(id)\nSELECT 1;", "expected format field:type"},
+		{"-- name: Qux :one record=pair(id:int8) record=pair(id:int8)\nSELECT 1;", "duplicate record pragma"},
+		{"-- name: Qux :one go-type=settings\nSELECT 1;", "invalid go-type"},
+		{"-- name: Qux :one go-type=:string\nSELECT 1;", "invalid go-type"},
+		{"-- name: Qux :one go-type=id:int go-type=id:int\nSELECT 1;", "duplicate go-type pragma"},
+		{"-- name: Qux :many cursor=0\nSELECT 1;", "invalid cursor"},
+		{"-- name: Qux :many cursor=all\nSELECT 1;", "invalid cursor"},
+		{"-- name: Qux :one cursor=100\nSELECT 1;", "cursor requires a :many or :iter query"},
+		{"-- name: Qux :exec affected=-1\nSELECT 1;", "invalid affected"},
+		{"-- name: Qux :many affected=1\nSELECT 1;", "affected requires an :exec or :execrows query"},
+		{"-- name: Qux :many affected=0\nSELECT 1;", "affected requires an :exec or :execrows query"},
+		{"-- name: Qux :execresult\nDELETE FROM foo;", "unsupported result kind :execresult; use :exec"},
+		{"-- name: Qux :map\nSELECT 1;", "a :map query requires a key pragma"},
+		{"-- name: Qux :many key=id\nSELECT 1;", "a :map query requires a key pragma"},
+		{"-- name: Qux :map key=\nSELECT 1;", "invalid key"},
+		{"-- name: Qux :one group-by=id\nSELECT 1;", "group-by requires a :many query"},
+		{"-- name: Qux :many group-by=id cursor=10\nSELECT 1;", "group-by requires a :many query without a cursor pragma"},
+		{"-- name: Qux :one row-type=1Author\nSELECT 1;", "invalid row-type"},
+		{"-- name: Qux :exec row-type=Author\nSELECT 1;", "row-type requires a query that returns rows"},
+		{"-- name: Qux :one row-type=Author proto-type=foo.Bar\nSELECT 1;", "row-type can't be used with a proto-type or group-by pragma"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := ParseFile(gotok.NewFileSet(), "", tt.src, Trace)
+			if err == nil {
+				t.Fatalf("ParseFile() expected error containing %q; got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseFile() error %q does not contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseFile_InvalidCopyFrom(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{"-- name: Qux :copyfrom\nSELECT pggen.arg('id');", "expected an INSERT INTO table"},
+		{"-- name: Qux :copyfrom\nINSERT INTO author (id) SELECT pggen.arg('id');", "expected an INSERT INTO table"},
+		{"-- name: Qux :copyfrom\nINSERT INTO author (id, name) VALUES (pggen.arg('id'), 'bob');", "expected one distinct pggen.arg for each of the 2 columns"},
+		{"-- name: Qux :copyfrom\nINSERT INTO author (id, name) VALUES (pggen.arg('id'), pggen.arg('id'));", "expected one distinct pggen.arg for each of the 2 columns"},
+		{"-- name: Qux :copyfrom\nINSERT INTO author (id) VALUES (pggen.arg('id') + 1);", "value for column id must be a pggen.arg"},
+		{"-- name: Qux :copyfrom\nINSERT INTO a.b.c (id) VALUES (pggen.arg('id'));", "expected a table name or schema.table"},
+		{"-- name: Qux :copyfrom\nINSERT INTO author (author.id) VALUES (pggen.arg('id'));", "invalid column name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := ParseFile(gotok.NewFileSet(), "", tt.src, Trace)
+			if err == nil {
+				t.Fatalf("ParseFile() expected error containing %q; got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseFile() error %q does not contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -22,6 +22,9 @@ type Column struct {
 	Number    uint16     // pg_attribute.attnum: the number of column starting from 1
 	Type      Type       // pg_attribute.atttypid: data type of the column
 	Null      bool       // pg_attribute.attnotnull: represents a not-null constraint
+	// pg_attribute.attndims or pg_type.typndims for domains: number of
+	// dimensions if the column is an array type, otherwise 0
+	Dimensions int
 }
 
 // ColumnKey is a composite key of a table OID and the number of the column
@@ -77,9 +80,11 @@ func FetchColumns(conn *pgx.Conn, keys []ColumnKey) ([]Column, error) {
 					 cls.relname     AS table_name,
 					 attr.attname    AS col_name,
 					 attr.attnum     AS col_num,
-					 attr.attnotnull AS col_null
+					 attr.attnotnull AS col_null,
+					 CASE WHEN typ.typtype = 'd' THEN typ.typndims ELSE attr.attndims END AS col_dims
 		FROM pg_class cls
 					 JOIN pg_attribute attr ON (attr.attrelid = cls.oid)
+					 JOIN pg_type typ ON (typ.oid = attr.atttypid)
 	`) + "\nWHERE " + predicate.String()
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -91,7 +96,7 @@ func FetchColumns(conn *pgx.Conn, keys []ColumnKey) ([]Column, error) {
 	for rows.Next() {
 		col := Column{}
 		notNull := false
-		if err := rows.Scan(&col.TableOID, &col.TableName, &col.Name, &col.Number, &notNull); err != nil {
+		if err := rows.Scan(&col.TableOID, &col.TableName, &col.Name, &col.Number, &notNull, &col.Dimensions); err != nil {
 			return nil, fmt.Errorf("scan fetch column row: %w", err)
 		}
 		col.Null = !notNull
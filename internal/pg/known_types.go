@@ -1,6 +1,8 @@
 package pg
 
 import (
+	"sort"
+
 	"github.com/jackc/pgtype"
 	"github.com/jschaf/pggen/internal/pg/pgoid"
 )
@@ -31,54 +33,60 @@ var (
 	Polygon          = BaseType{ID: pgtype.PolygonOID, Name: "polygon"}
 	Line             = BaseType{ID: pgtype.LineOID, Name: "line"}
 	CIDR             = BaseType{ID: pgtype.CIDROID, Name: "cidr"}
-	CIDRArray        = ArrayType{ID: pgtype.CIDRArrayOID, Name: "_cidr"}
+	CIDRArray        = ArrayType{ID: pgtype.CIDRArrayOID, Name: "_cidr", Elem: CIDR}
 	Float4           = BaseType{ID: pgtype.Float4OID, Name: "float4"}
 	Float8           = BaseType{ID: pgtype.Float8OID, Name: "float8"}
 	Unknown          = BaseType{ID: pgtype.UnknownOID, Name: "unknown"}
 	Circle           = BaseType{ID: pgtype.CircleOID, Name: "circle"}
 	Macaddr          = BaseType{ID: pgtype.MacaddrOID, Name: "macaddr"}
 	Inet             = BaseType{ID: pgtype.InetOID, Name: "inet"}
-	BoolArray        = ArrayType{ID: pgtype.BoolArrayOID, Name: "_bool"}
-	ByteaArray       = ArrayType{ID: pgtype.ByteaArrayOID, Name: "_bytea"}
-	Int2Array        = ArrayType{ID: pgtype.Int2ArrayOID, Name: "_int2"}
-	Int4Array        = ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4"}
-	TextArray        = ArrayType{ID: pgtype.TextArrayOID, Name: "_text"}
-	BPCharArray      = ArrayType{ID: pgtype.BPCharArrayOID, Name: "_bpchar"}
-	VarcharArray     = ArrayType{ID: pgtype.VarcharArrayOID, Name: "_varchar"}
-	Int8Array        = ArrayType{ID: pgtype.Int8ArrayOID, Name: "_int8"}
-	Float4Array      = ArrayType{ID: pgtype.Float4ArrayOID, Name: "_float4"}
-	Float8Array      = ArrayType{ID: pgtype.Float8ArrayOID, Name: "_float8"}
-	OIDArray         = ArrayType{ID: pgoid.OIDArray, Name: "_oid"}
+	BoolArray        = ArrayType{ID: pgtype.BoolArrayOID, Name: "_bool", Elem: Bool}
+	ByteaArray       = ArrayType{ID: pgtype.ByteaArrayOID, Name: "_bytea", Elem: Bytea}
+	Int2Array        = ArrayType{ID: pgtype.Int2ArrayOID, Name: "_int2", Elem: Int2}
+	Int4Array        = ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: Int4}
+	TextArray        = ArrayType{ID: pgtype.TextArrayOID, Name: "_text", Elem: Text}
+	BPCharArray      = ArrayType{ID: pgtype.BPCharArrayOID, Name: "_bpchar", Elem: BPChar}
+	VarcharArray     = ArrayType{ID: pgtype.VarcharArrayOID, Name: "_varchar", Elem: Varchar}
+	Int8Array        = ArrayType{ID: pgtype.Int8ArrayOID, Name: "_int8", Elem: Int8}
+	Float4Array      = ArrayType{ID: pgtype.Float4ArrayOID, Name: "_float4", Elem: Float4}
+	Float8Array      = ArrayType{ID: pgtype.Float8ArrayOID, Name: "_float8", Elem: Float8}
+	OIDArray         = ArrayType{ID: pgoid.OIDArray, Name: "_oid", Elem: OID}
 	ACLItem          = BaseType{ID: pgtype.ACLItemOID, Name: "aclitem"}
-	ACLItemArray     = ArrayType{ID: pgtype.ACLItemArrayOID, Name: "_aclitem"}
-	InetArray        = ArrayType{ID: pgtype.InetArrayOID, Name: "_inet"}
-	MacaddrArray     = ArrayType{ID: pgoid.MacaddrArray, Name: "_macaddr"}
+	ACLItemArray     = ArrayType{ID: pgtype.ACLItemArrayOID, Name: "_aclitem", Elem: ACLItem}
+	InetArray        = ArrayType{ID: pgtype.InetArrayOID, Name: "_inet", Elem: Inet}
+	MacaddrArray     = ArrayType{ID: pgoid.MacaddrArray, Name: "_macaddr", Elem: Macaddr}
 	BPChar           = BaseType{ID: pgtype.BPCharOID, Name: "bpchar"}
 	Varchar          = BaseType{ID: pgtype.VarcharOID, Name: "varchar"}
 	Date             = BaseType{ID: pgtype.DateOID, Name: "date"}
 	Time             = BaseType{ID: pgtype.TimeOID, Name: "time"}
 	Timestamp        = BaseType{ID: pgtype.TimestampOID, Name: "timestamp"}
-	TimestampArray   = ArrayType{ID: pgtype.TimestampArrayOID, Name: "_timestamp"}
-	DateArray        = ArrayType{ID: pgtype.DateArrayOID, Name: "_date"}
+	TimestampArray   = ArrayType{ID: pgtype.TimestampArrayOID, Name: "_timestamp", Elem: Timestamp}
+	DateArray        = ArrayType{ID: pgtype.DateArrayOID, Name: "_date", Elem: Date}
 	Timestamptz      = BaseType{ID: pgtype.TimestamptzOID, Name: "timestamptz"}
-	TimestamptzArray = ArrayType{ID: pgtype.TimestamptzArrayOID, Name: "_timestamptz"}
+	TimestamptzArray = ArrayType{ID: pgtype.TimestamptzArrayOID, Name: "_timestamptz", Elem: Timestamptz}
 	Interval         = BaseType{ID: pgtype.IntervalOID, Name: "interval"}
-	NumericArray     = ArrayType{ID: pgtype.NumericArrayOID, Name: "_numeric"}
+	NumericArray     = ArrayType{ID: pgtype.NumericArrayOID, Name: "_numeric", Elem: Numeric}
 	Bit              = BaseType{ID: pgtype.BitOID, Name: "bit"}
 	Varbit           = BaseType{ID: pgtype.VarbitOID, Name: "varbit"}
 	Numeric          = BaseType{ID: pgtype.NumericOID, Name: "numeric"}
 	Record           = BaseType{ID: pgtype.RecordOID, Name: "record"}
 	Void             = VoidType{}
 	UUID             = BaseType{ID: pgtype.UUIDOID, Name: "uuid"}
-	UUIDArray        = ArrayType{ID: pgtype.UUIDArrayOID, Name: "_uuid"}
+	UUIDArray        = ArrayType{ID: pgtype.UUIDArrayOID, Name: "_uuid", Elem: UUID}
 	JSONB            = BaseType{ID: pgtype.JSONBOID, Name: "jsonb"}
-	JSONBArray       = ArrayType{ID: pgtype.JSONBArrayOID, Name: "_jsonb"}
-	Int4range        = BaseType{ID: pgtype.Int4rangeOID, Name: "int4range"}
-	Numrange         = BaseType{ID: pgtype.NumrangeOID, Name: "numrange"}
-	Tsrange          = BaseType{ID: pgtype.TsrangeOID, Name: "tsrange"}
-	Tstzrange        = BaseType{ID: pgtype.TstzrangeOID, Name: "tstzrange"}
-	Daterange        = BaseType{ID: pgtype.DaterangeOID, Name: "daterange"}
-	Int8range        = BaseType{ID: pgtype.Int8rangeOID, Name: "int8range"}
+	JSONBArray       = ArrayType{ID: pgtype.JSONBArrayOID, Name: "_jsonb", Elem: JSONB}
+	Int4range        = RangeType{ID: pgtype.Int4rangeOID, Name: "int4range", Subtype: Int4}
+	Numrange         = RangeType{ID: pgtype.NumrangeOID, Name: "numrange", Subtype: Numeric}
+	Tsrange          = RangeType{ID: pgtype.TsrangeOID, Name: "tsrange", Subtype: Timestamp}
+	Tstzrange        = RangeType{ID: pgtype.TstzrangeOID, Name: "tstzrange", Subtype: Timestamptz}
+	Daterange        = RangeType{ID: pgtype.DaterangeOID, Name: "daterange", Subtype: Date}
+	Int8range        = RangeType{ID: pgtype.Int8rangeOID, Name: "int8range", Subtype: Int8}
+	Int4multirange   = MultirangeType{ID: pgtype.Int4multirangeOID, Name: "int4multirange", Range: Int4range}
+	Nummultirange    = MultirangeType{ID: pgtype.NummultirangeOID, Name: "nummultirange", Range: Numrange}
+	Tsmultirange     = MultirangeType{ID: pgoid.Tsmultirange, Name: "tsmultirange", Range: Tsrange}
+	Tstzmultirange   = MultirangeType{ID: pgoid.Tstzmultirange, Name: "tstzmultirange", Range: Tstzrange}
+	Datemultirange   = MultirangeType{ID: pgoid.Datemultirange, Name: "datemultirange", Range: Daterange}
+	Int8multirange   = MultirangeType{ID: pgtype.Int8multirangeOID, Name: "int8multirange", Range: Int8range}
 )
 
 // All known Postgres types by OID.
@@ -154,4 +162,20 @@ var defaultKnownTypes = map[pgtype.OID]Type{
 	pgtype.TstzrangeOID:        Tstzrange,
 	pgtype.DaterangeOID:        Daterange,
 	pgtype.Int8rangeOID:        Int8range,
+	pgtype.Int4multirangeOID:   Int4multirange,
+	pgtype.NummultirangeOID:    Nummultirange,
+	pgoid.Tsmultirange:         Tsmultirange,
+	pgoid.Tstzmultirange:       Tstzmultirange,
+	pgoid.Datemultirange:       Datemultirange,
+	pgtype.Int8multirangeOID:   Int8multirange,
+}
+
+// ListKnownTypes returns all known Postgres types, sorted by OID.
+func ListKnownTypes() []Type {
+	types := make([]Type, 0, len(defaultKnownTypes))
+	for _, typ := range defaultKnownTypes {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].OID() < types[j].OID() })
+	return types
 }
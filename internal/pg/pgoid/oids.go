@@ -5,4 +5,8 @@ const (
 	OIDArray     = 1028
 	MacaddrArray = 1040
 	Void         = 2278
+	// Multirange types, added in Postgres 14, without a pgtype OID constant.
+	Tsmultirange   = 4533
+	Tstzmultirange = 4534
+	Datemultirange = 4535
 )
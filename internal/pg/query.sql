@@ -23,6 +23,8 @@ SELECT
   typ.oid           AS oid,
   -- typename: Data type name.
   typ.typname::text AS type_name,
+  -- nspname: The schema containing the type.
+  ns.nspname::text  AS schema_name,
   enum.enum_oids    AS child_oids,
   enum.enum_orders  AS orders,
   enum.enum_labels  AS labels,
@@ -39,6 +41,7 @@ SELECT
   COALESCE(typ.typdefault, '')    AS default_expr
 FROM pg_type typ
   JOIN enums enum ON typ.oid = enum.enum_type
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
 WHERE typ.typisdefined
   AND typ.typtype = 'e'
   AND typ.oid = ANY (pggen.arg('OIDs')::oid[]);
@@ -48,6 +51,8 @@ SELECT
   arr_typ.oid           AS oid,
   -- typename: Data type name.
   arr_typ.typname::text AS type_name,
+  -- nspname: The schema containing the type.
+  ns.nspname::text      AS schema_name,
   elem_typ.oid          AS elem_oid,
   -- typtype: b for a base type, c for a composite type (e.g., a table's
   -- row type), d for a domain, e for an enum type, p for a pseudo-type,
@@ -55,6 +60,7 @@ SELECT
   arr_typ.typtype       AS type_kind
 FROM pg_type arr_typ
   JOIN pg_type elem_typ ON arr_typ.typelem = elem_typ.oid
+  JOIN pg_namespace ns ON arr_typ.typnamespace = ns.oid
 WHERE arr_typ.typisdefined
   AND arr_typ.typtype = 'b' -- Array types are base types
   -- If typelem is not 0 then it identifies another row in pg_type. The current
@@ -82,32 +88,100 @@ WITH table_cols AS (
   SELECT
     cls.relname                                         AS table_name,
     cls.oid                                             AS table_oid,
+    -- relkind: r for an ordinary table, p for a partitioned table, c for a
+    -- composite type created with CREATE TYPE, v for a view, and so on.
+    cls.relkind                                         AS table_kind,
     array_agg(attr.attname::text ORDER BY attr.attnum)  AS col_names,
     array_agg(attr.atttypid::int8 ORDER BY attr.attnum) AS col_oids,
     array_agg(attr.attnum::int8 ORDER BY attr.attnum)   AS col_orders,
     array_agg(attr.attnotnull ORDER BY attr.attnum)     AS col_not_nulls,
-    array_agg(typ.typname::text ORDER BY attr.attnum)   AS col_type_names
+    array_agg(typ.typname::text ORDER BY attr.attnum)   AS col_type_names,
+    -- attndims is only set for array columns. Domains over an array type store
+    -- the number of dimensions in typndims.
+    array_agg(
+      (CASE WHEN typ.typtype = 'd' THEN typ.typndims ELSE attr.attndims END)::int8
+      ORDER BY attr.attnum
+    )                                                   AS col_dims
   FROM pg_attribute attr
     JOIN pg_class cls ON attr.attrelid = cls.oid
     JOIN pg_type typ ON typ.oid = attr.atttypid
   WHERE attr.attnum > 0 -- Postgres represents system columns with attnum <= 0
     AND NOT attr.attisdropped
-  GROUP BY cls.relname, cls.oid
+  GROUP BY cls.relname, cls.oid, cls.relkind
 )
 SELECT
   typ.typname::text AS table_type_name,
   typ.oid           AS table_type_oid,
+  ns.nspname::text  AS schema_name,
   table_name,
+  table_kind,
   col_names,
   col_oids,
   col_orders,
   col_not_nulls,
-  col_type_names
+  col_type_names,
+  col_dims
 FROM pg_type typ
   JOIN table_cols cols ON typ.typrelid = cols.table_oid
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
 WHERE typ.oid = ANY (pggen.arg('oids')::oid[])
   AND typ.typtype = 'c';
 
+-- A range type represents a range of values of a subtype, like tstzrange or
+-- a user-defined range created with CREATE TYPE. Postgres 14 and later also
+-- creates a multirange type for each range type.
+-- https://www.postgresql.org/docs/14/rangetypes.html
+-- name: FindRangeTypes :many
+WITH ranges AS (
+  SELECT
+    rng.rngtypid                             AS range_oid,
+    rng.rngsubtype                           AS subtype_oid,
+    -- rngmultitypid only exists in Postgres 14 and later. Read the column from
+    -- the JSON representation of the row so the query works on older versions.
+    (to_jsonb(rng) ->> 'rngmultitypid')::oid AS multirange_oid
+  FROM pg_range rng
+)
+SELECT
+  range_typ.oid              AS oid,
+  -- typename: Data type name.
+  range_typ.typname::text    AS type_name,
+  -- nspname: The schema containing the type.
+  range_ns.nspname::text     AS schema_name,
+  ranges.subtype_oid         AS subtype_oid,
+  COALESCE(multi_typ.oid, 0) AS multirange_oid,
+  multi_typ.typname          AS multirange_name,
+  multi_ns.nspname           AS multirange_schema_name
+FROM ranges
+  JOIN pg_type range_typ ON ranges.range_oid = range_typ.oid
+  JOIN pg_namespace range_ns ON range_typ.typnamespace = range_ns.oid
+  LEFT JOIN pg_type multi_typ ON ranges.multirange_oid = multi_typ.oid
+  LEFT JOIN pg_namespace multi_ns ON multi_typ.typnamespace = multi_ns.oid
+WHERE ranges.range_oid = ANY (pggen.arg('oids')::oid[])
+  OR ranges.multirange_oid = ANY (pggen.arg('oids')::oid[]);
+
+-- A domain type is a user-defined type based on an underlying base type with
+-- optional constraints.
+-- https://www.postgresql.org/docs/13/domains.html
+-- name: FindDomainTypes :many
+SELECT
+  typ.oid                    AS oid,
+  -- typename: Data type name.
+  typ.typname::text          AS type_name,
+  -- nspname: The schema containing the type.
+  ns.nspname::text           AS schema_name,
+  -- typnotnull represents a not-null constraint on the domain.
+  typ.typnotnull             AS is_not_null,
+  typ.typdefault IS NOT NULL AS has_default,
+  -- typbasetype identifies the type on which the domain is based.
+  typ.typbasetype            AS base_type_oid,
+  -- typndims is the number of array dimensions for a domain over an array
+  -- type, zero otherwise.
+  typ.typndims               AS dimensions
+FROM pg_type typ
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
+WHERE typ.typtype = 'd'
+  AND typ.oid = ANY (pggen.arg('oids')::oid[]);
+
 -- Recursively expands all given OIDs to all descendants through composite
 -- types.
 -- name: FindDescendantOIDs :many
@@ -134,16 +208,36 @@ WITH RECURSIVE oid_descs(oid) AS (
     FROM pg_type arr_typ
       JOIN pg_type elem_typ ON arr_typ.typelem = elem_typ.oid
       JOIN all_oids od ON arr_typ.oid = od.oid
+    UNION
+    -- All range subtypes.
+    SELECT rng.rngsubtype
+    FROM pg_range rng
+      JOIN all_oids od ON rng.rngtypid = od.oid
+    UNION
+    -- All ranges of multirange types. Only exists in Postgres 14 and later.
+    SELECT rng.rngtypid
+    FROM pg_range rng
+      JOIN all_oids od ON (to_jsonb(rng) ->> 'rngmultitypid')::oid = od.oid
+    UNION
+    -- All base types of domain types.
+    SELECT typ.typbasetype
+    FROM pg_type typ
+      JOIN all_oids od ON typ.oid = od.oid
+    WHERE typ.typtype = 'd'
   ) t
 )
 SELECT oid
 FROM oid_descs;
 
+-- Finds the OID of a type by the unqualified name, like 'status', or by the
+-- schema-qualified name, like 'billing.status'.
 -- name: FindOIDByName :one
-SELECT oid
-FROM pg_type
-WHERE typname::text = pggen.arg('name')
-ORDER BY oid DESC
+SELECT typ.oid
+FROM pg_type typ
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
+WHERE typ.typname::text = pggen.arg('name')
+  OR ns.nspname || '.' || typ.typname = pggen.arg('name')
+ORDER BY typ.oid DESC
 LIMIT 1;
 
 -- name: FindOIDName :one
@@ -152,6 +246,7 @@ FROM pg_type
 WHERE oid = pggen.arg('oid');
 
 -- name: FindOIDNames :many
-SELECT oid, typname AS name, typtype AS kind
-FROM pg_type
-WHERE oid = ANY (pggen.arg('oid')::oid[]);
+SELECT typ.oid, typ.typname AS name, typ.typtype AS kind, ns.nspname::text AS schema_name
+FROM pg_type typ
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
+WHERE typ.oid = ANY (pggen.arg('oid')::oid[]);
@@ -21,10 +21,23 @@ type Querier interface {
 	// https://www.postgresql.org/docs/13/rowtypes.html
 	FindCompositeTypes(ctx context.Context, oids []uint32) ([]FindCompositeTypesRow, error)
 
+	// A range type represents a range of values of a subtype, like tstzrange or
+	// a user-defined range created with CREATE TYPE. Postgres 14 and later also
+	// creates a multirange type for each range type.
+	// https://www.postgresql.org/docs/14/rangetypes.html
+	FindRangeTypes(ctx context.Context, oids []uint32) ([]FindRangeTypesRow, error)
+
+	// A domain type is a user-defined type based on an underlying base type with
+	// optional constraints.
+	// https://www.postgresql.org/docs/13/domains.html
+	FindDomainTypes(ctx context.Context, oids []uint32) ([]FindDomainTypesRow, error)
+
 	// Recursively expands all given OIDs to all descendants through composite
 	// types.
 	FindDescendantOIDs(ctx context.Context, oids []uint32) ([]pgtype.OID, error)
 
+	// Finds the OID of a type by the unqualified name, like 'status', or by the
+	// schema-qualified name, like 'billing.status'.
 	FindOIDByName(ctx context.Context, name string) (pgtype.OID, error)
 
 	FindOIDName(ctx context.Context, oid pgtype.OID) (pgtype.Name, error)
@@ -104,6 +117,8 @@ SELECT
   typ.oid           AS oid,
   -- typename: Data type name.
   typ.typname::text AS type_name,
+  -- nspname: The schema containing the type.
+  ns.nspname::text  AS schema_name,
   enum.enum_oids    AS child_oids,
   enum.enum_orders  AS orders,
   enum.enum_labels  AS labels,
@@ -120,6 +135,7 @@ SELECT
   COALESCE(typ.typdefault, '')    AS default_expr
 FROM pg_type typ
   JOIN enums enum ON typ.oid = enum.enum_type
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
 WHERE typ.typisdefined
   AND typ.typtype = 'e'
   AND typ.oid = ANY ($1::oid[]);`
@@ -127,6 +143,7 @@ WHERE typ.typisdefined
 type FindEnumTypesRow struct {
 	OID         pgtype.OID   `json:"oid"`
 	TypeName    string       `json:"type_name"`
+	SchemaName  string       `json:"schema_name"`
 	ChildOIDs   []int        `json:"child_oids"`
 	Orders      []float32    `json:"orders"`
 	Labels      []string     `json:"labels"`
@@ -145,7 +162,7 @@ func (q *DBQuerier) FindEnumTypes(ctx context.Context, oids []uint32) ([]FindEnu
 	items := []FindEnumTypesRow{}
 	for rows.Next() {
 		var item FindEnumTypesRow
-		if err := rows.Scan(&item.OID, &item.TypeName, &item.ChildOIDs, &item.Orders, &item.Labels, &item.TypeKind, &item.DefaultExpr); err != nil {
+		if err := rows.Scan(&item.OID, &item.TypeName, &item.SchemaName, &item.ChildOIDs, &item.Orders, &item.Labels, &item.TypeKind, &item.DefaultExpr); err != nil {
 			return nil, fmt.Errorf("scan FindEnumTypes row: %w", err)
 		}
 		items = append(items, item)
@@ -160,6 +177,8 @@ const findArrayTypesSQL = `SELECT
   arr_typ.oid           AS oid,
   -- typename: Data type name.
   arr_typ.typname::text AS type_name,
+  -- nspname: The schema containing the type.
+  ns.nspname::text      AS schema_name,
   elem_typ.oid          AS elem_oid,
   -- typtype: b for a base type, c for a composite type (e.g., a table's
   -- row type), d for a domain, e for an enum type, p for a pseudo-type,
@@ -167,6 +186,7 @@ const findArrayTypesSQL = `SELECT
   arr_typ.typtype       AS type_kind
 FROM pg_type arr_typ
   JOIN pg_type elem_typ ON arr_typ.typelem = elem_typ.oid
+  JOIN pg_namespace ns ON arr_typ.typnamespace = ns.oid
 WHERE arr_typ.typisdefined
   AND arr_typ.typtype = 'b' -- Array types are base types
   -- If typelem is not 0 then it identifies another row in pg_type. The current
@@ -186,10 +206,11 @@ WHERE arr_typ.typisdefined
   AND arr_typ.oid = ANY ($1::oid[]);`
 
 type FindArrayTypesRow struct {
-	OID      pgtype.OID   `json:"oid"`
-	TypeName string       `json:"type_name"`
-	ElemOID  pgtype.OID   `json:"elem_oid"`
-	TypeKind pgtype.QChar `json:"type_kind"`
+	OID        pgtype.OID   `json:"oid"`
+	TypeName   string       `json:"type_name"`
+	SchemaName string       `json:"schema_name"`
+	ElemOID    pgtype.OID   `json:"elem_oid"`
+	TypeKind   pgtype.QChar `json:"type_kind"`
 }
 
 // FindArrayTypes implements Querier.FindArrayTypes.
@@ -203,7 +224,7 @@ func (q *DBQuerier) FindArrayTypes(ctx context.Context, oids []uint32) ([]FindAr
 	items := []FindArrayTypesRow{}
 	for rows.Next() {
 		var item FindArrayTypesRow
-		if err := rows.Scan(&item.OID, &item.TypeName, &item.ElemOID, &item.TypeKind); err != nil {
+		if err := rows.Scan(&item.OID, &item.TypeName, &item.SchemaName, &item.ElemOID, &item.TypeKind); err != nil {
 			return nil, fmt.Errorf("scan FindArrayTypes row: %w", err)
 		}
 		items = append(items, item)
@@ -218,41 +239,57 @@ const findCompositeTypesSQL = `WITH table_cols AS (
   SELECT
     cls.relname                                         AS table_name,
     cls.oid                                             AS table_oid,
+    -- relkind: r for an ordinary table, p for a partitioned table, c for a
+    -- composite type created with CREATE TYPE, v for a view, and so on.
+    cls.relkind                                         AS table_kind,
     array_agg(attr.attname::text ORDER BY attr.attnum)  AS col_names,
     array_agg(attr.atttypid::int8 ORDER BY attr.attnum) AS col_oids,
     array_agg(attr.attnum::int8 ORDER BY attr.attnum)   AS col_orders,
     array_agg(attr.attnotnull ORDER BY attr.attnum)     AS col_not_nulls,
-    array_agg(typ.typname::text ORDER BY attr.attnum)   AS col_type_names
+    array_agg(typ.typname::text ORDER BY attr.attnum)   AS col_type_names,
+    -- attndims is only set for array columns. Domains over an array type store
+    -- the number of dimensions in typndims.
+    array_agg(
+      (CASE WHEN typ.typtype = 'd' THEN typ.typndims ELSE attr.attndims END)::int8
+      ORDER BY attr.attnum
+    )                                                   AS col_dims
   FROM pg_attribute attr
     JOIN pg_class cls ON attr.attrelid = cls.oid
     JOIN pg_type typ ON typ.oid = attr.atttypid
   WHERE attr.attnum > 0 -- Postgres represents system columns with attnum <= 0
     AND NOT attr.attisdropped
-  GROUP BY cls.relname, cls.oid
+  GROUP BY cls.relname, cls.oid, cls.relkind
 )
 SELECT
   typ.typname::text AS table_type_name,
   typ.oid           AS table_type_oid,
+  ns.nspname::text  AS schema_name,
   table_name,
+  table_kind,
   col_names,
   col_oids,
   col_orders,
   col_not_nulls,
-  col_type_names
+  col_type_names,
+  col_dims
 FROM pg_type typ
   JOIN table_cols cols ON typ.typrelid = cols.table_oid
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
 WHERE typ.oid = ANY ($1::oid[])
   AND typ.typtype = 'c';`
 
 type FindCompositeTypesRow struct {
 	TableTypeName string           `json:"table_type_name"`
 	TableTypeOID  pgtype.OID       `json:"table_type_oid"`
+	SchemaName    string           `json:"schema_name"`
 	TableName     pgtype.Name      `json:"table_name"`
+	TableKind     pgtype.QChar     `json:"table_kind"`
 	ColNames      []string         `json:"col_names"`
 	ColOIDs       []int            `json:"col_oids"`
 	ColOrders     []int            `json:"col_orders"`
 	ColNotNulls   pgtype.BoolArray `json:"col_not_nulls"`
 	ColTypeNames  []string         `json:"col_type_names"`
+	ColDims       []int            `json:"col_dims"`
 }
 
 // FindCompositeTypes implements Querier.FindCompositeTypes.
@@ -266,7 +303,7 @@ func (q *DBQuerier) FindCompositeTypes(ctx context.Context, oids []uint32) ([]Fi
 	items := []FindCompositeTypesRow{}
 	for rows.Next() {
 		var item FindCompositeTypesRow
-		if err := rows.Scan(&item.TableTypeName, &item.TableTypeOID, &item.TableName, &item.ColNames, &item.ColOIDs, &item.ColOrders, &item.ColNotNulls, &item.ColTypeNames); err != nil {
+		if err := rows.Scan(&item.TableTypeName, &item.TableTypeOID, &item.SchemaName, &item.TableName, &item.TableKind, &item.ColNames, &item.ColOIDs, &item.ColOrders, &item.ColNotNulls, &item.ColTypeNames, &item.ColDims); err != nil {
 			return nil, fmt.Errorf("scan FindCompositeTypes row: %w", err)
 		}
 		items = append(items, item)
@@ -277,6 +314,116 @@ func (q *DBQuerier) FindCompositeTypes(ctx context.Context, oids []uint32) ([]Fi
 	return items, err
 }
 
+const findRangeTypesSQL = `WITH ranges AS (
+  SELECT
+    rng.rngtypid                             AS range_oid,
+    rng.rngsubtype                           AS subtype_oid,
+    -- rngmultitypid only exists in Postgres 14 and later. Read the column from
+    -- the JSON representation of the row so the query works on older versions.
+    (to_jsonb(rng) ->> 'rngmultitypid')::oid AS multirange_oid
+  FROM pg_range rng
+)
+SELECT
+  range_typ.oid              AS oid,
+  -- typename: Data type name.
+  range_typ.typname::text    AS type_name,
+  -- nspname: The schema containing the type.
+  range_ns.nspname::text     AS schema_name,
+  ranges.subtype_oid         AS subtype_oid,
+  COALESCE(multi_typ.oid, 0) AS multirange_oid,
+  multi_typ.typname          AS multirange_name,
+  multi_ns.nspname           AS multirange_schema_name
+FROM ranges
+  JOIN pg_type range_typ ON ranges.range_oid = range_typ.oid
+  JOIN pg_namespace range_ns ON range_typ.typnamespace = range_ns.oid
+  LEFT JOIN pg_type multi_typ ON ranges.multirange_oid = multi_typ.oid
+  LEFT JOIN pg_namespace multi_ns ON multi_typ.typnamespace = multi_ns.oid
+WHERE ranges.range_oid = ANY ($1::oid[])
+  OR ranges.multirange_oid = ANY ($1::oid[]);`
+
+type FindRangeTypesRow struct {
+	OID                  pgtype.OID  `json:"oid"`
+	TypeName             string      `json:"type_name"`
+	SchemaName           string      `json:"schema_name"`
+	SubtypeOID           pgtype.OID  `json:"subtype_oid"`
+	MultirangeOID        pgtype.OID  `json:"multirange_oid"`
+	MultirangeName       pgtype.Name `json:"multirange_name"`
+	MultirangeSchemaName pgtype.Name `json:"multirange_schema_name"`
+}
+
+// FindRangeTypes implements Querier.FindRangeTypes.
+func (q *DBQuerier) FindRangeTypes(ctx context.Context, oids []uint32) ([]FindRangeTypesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindRangeTypes")
+	rows, err := q.conn.Query(ctx, findRangeTypesSQL, oids)
+	if err != nil {
+		return nil, fmt.Errorf("query FindRangeTypes: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRangeTypesRow{}
+	for rows.Next() {
+		var item FindRangeTypesRow
+		if err := rows.Scan(&item.OID, &item.TypeName, &item.SchemaName, &item.SubtypeOID, &item.MultirangeOID, &item.MultirangeName, &item.MultirangeSchemaName); err != nil {
+			return nil, fmt.Errorf("scan FindRangeTypes row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRangeTypes rows: %w", err)
+	}
+	return items, err
+}
+
+const findDomainTypesSQL = `SELECT
+  typ.oid                    AS oid,
+  -- typename: Data type name.
+  typ.typname::text          AS type_name,
+  -- nspname: The schema containing the type.
+  ns.nspname::text           AS schema_name,
+  -- typnotnull represents a not-null constraint on the domain.
+  typ.typnotnull             AS is_not_null,
+  typ.typdefault IS NOT NULL AS has_default,
+  -- typbasetype identifies the type on which the domain is based.
+  typ.typbasetype            AS base_type_oid,
+  -- typndims is the number of array dimensions for a domain over an array
+  -- type, zero otherwise.
+  typ.typndims               AS dimensions
+FROM pg_type typ
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
+WHERE typ.typtype = 'd'
+  AND typ.oid = ANY ($1::oid[]);`
+
+type FindDomainTypesRow struct {
+	OID         pgtype.OID `json:"oid"`
+	TypeName    string     `json:"type_name"`
+	SchemaName  string     `json:"schema_name"`
+	IsNotNull   *bool      `json:"is_not_null"`
+	HasDefault  *bool      `json:"has_default"`
+	BaseTypeOID pgtype.OID `json:"base_type_oid"`
+	Dimensions  *int32     `json:"dimensions"`
+}
+
+// FindDomainTypes implements Querier.FindDomainTypes.
+func (q *DBQuerier) FindDomainTypes(ctx context.Context, oids []uint32) ([]FindDomainTypesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindDomainTypes")
+	rows, err := q.conn.Query(ctx, findDomainTypesSQL, oids)
+	if err != nil {
+		return nil, fmt.Errorf("query FindDomainTypes: %w", err)
+	}
+	defer rows.Close()
+	items := []FindDomainTypesRow{}
+	for rows.Next() {
+		var item FindDomainTypesRow
+		if err := rows.Scan(&item.OID, &item.TypeName, &item.SchemaName, &item.IsNotNull, &item.HasDefault, &item.BaseTypeOID, &item.Dimensions); err != nil {
+			return nil, fmt.Errorf("scan FindDomainTypes row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindDomainTypes rows: %w", err)
+	}
+	return items, err
+}
+
 const findDescendantOIDsSQL = `WITH RECURSIVE oid_descs(oid) AS (
   -- Base case.
   SELECT oid
@@ -300,6 +447,22 @@ const findDescendantOIDsSQL = `WITH RECURSIVE oid_descs(oid) AS (
     FROM pg_type arr_typ
       JOIN pg_type elem_typ ON arr_typ.typelem = elem_typ.oid
       JOIN all_oids od ON arr_typ.oid = od.oid
+    UNION
+    -- All range subtypes.
+    SELECT rng.rngsubtype
+    FROM pg_range rng
+      JOIN all_oids od ON rng.rngtypid = od.oid
+    UNION
+    -- All ranges of multirange types. Only exists in Postgres 14 and later.
+    SELECT rng.rngtypid
+    FROM pg_range rng
+      JOIN all_oids od ON (to_jsonb(rng) ->> 'rngmultitypid')::oid = od.oid
+    UNION
+    -- All base types of domain types.
+    SELECT typ.typbasetype
+    FROM pg_type typ
+      JOIN all_oids od ON typ.oid = od.oid
+    WHERE typ.typtype = 'd'
   ) t
 )
 SELECT oid
@@ -327,10 +490,12 @@ func (q *DBQuerier) FindDescendantOIDs(ctx context.Context, oids []uint32) ([]pg
 	return items, err
 }
 
-const findOIDByNameSQL = `SELECT oid
-FROM pg_type
-WHERE typname::text = $1
-ORDER BY oid DESC
+const findOIDByNameSQL = `SELECT typ.oid
+FROM pg_type typ
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
+WHERE typ.typname::text = $1
+  OR ns.nspname || '.' || typ.typname = $1
+ORDER BY typ.oid DESC
 LIMIT 1;`
 
 // FindOIDByName implements Querier.FindOIDByName.
@@ -359,14 +524,16 @@ func (q *DBQuerier) FindOIDName(ctx context.Context, oid pgtype.OID) (pgtype.Nam
 	return item, nil
 }
 
-const findOIDNamesSQL = `SELECT oid, typname AS name, typtype AS kind
-FROM pg_type
-WHERE oid = ANY ($1::oid[]);`
+const findOIDNamesSQL = `SELECT typ.oid, typ.typname AS name, typ.typtype AS kind, ns.nspname::text AS schema_name
+FROM pg_type typ
+  JOIN pg_namespace ns ON typ.typnamespace = ns.oid
+WHERE typ.oid = ANY ($1::oid[]);`
 
 type FindOIDNamesRow struct {
-	OID  pgtype.OID   `json:"oid"`
-	Name pgtype.Name  `json:"name"`
-	Kind pgtype.QChar `json:"kind"`
+	OID        pgtype.OID   `json:"oid"`
+	Name       pgtype.Name  `json:"name"`
+	Kind       pgtype.QChar `json:"kind"`
+	SchemaName string       `json:"schema_name"`
 }
 
 // FindOIDNames implements Querier.FindOIDNames.
@@ -380,7 +547,7 @@ func (q *DBQuerier) FindOIDNames(ctx context.Context, oid []uint32) ([]FindOIDNa
 	items := []FindOIDNamesRow{}
 	for rows.Next() {
 		var item FindOIDNamesRow
-		if err := rows.Scan(&item.OID, &item.Name, &item.Kind); err != nil {
+		if err := rows.Scan(&item.OID, &item.Name, &item.Kind, &item.SchemaName); err != nil {
 			return nil, fmt.Errorf("scan FindOIDNames row: %w", err)
 		}
 		items = append(items, item)
@@ -33,9 +33,10 @@ func (tf *TypeFetcher) FindTypesByOIDs(oids ...uint32) (map[pgtype.OID]Type, err
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// First, recursively find all descendant OIDs from composite or array types.
-	// Composite types are the only type that can be nested. Array types have an
-	// element type that might be a composite type.
+	// First, recursively find all descendant OIDs from composite, array, or range
+	// types. Composite types are the only type that can be nested. Array types
+	// have an element type and range types have a subtype that might be a
+	// composite type.
 	descOIDs, err := tf.querier.FindDescendantOIDs(ctx, oids)
 	if err != nil {
 		return nil, fmt.Errorf("find descendant oids: %w", err)
@@ -66,6 +67,33 @@ func (tf *TypeFetcher) FindTypesByOIDs(oids ...uint32) (map[pgtype.OID]Type, err
 		delete(uncached, comp.ID)
 	}
 
+	rngs, multis, err := tf.findRangeTypes(ctx, uncached)
+	if err != nil {
+		return nil, fmt.Errorf("find range types: %w", err)
+	}
+	for _, rng := range rngs {
+		types[rng.ID] = rng
+		tf.cache.addType(rng)
+		delete(uncached, rng.ID)
+	}
+	for _, multi := range multis {
+		types[multi.ID] = multi
+		tf.cache.addType(multi)
+		delete(uncached, multi.ID)
+	}
+
+	// Find domains before arrays because an array type might have a domain
+	// element type.
+	domains, err := tf.findDomainTypes(ctx, uncached)
+	if err != nil {
+		return nil, fmt.Errorf("find domain types: %w", err)
+	}
+	for _, domain := range domains {
+		types[domain.ID] = domain
+		tf.cache.addType(domain)
+		delete(uncached, domain.ID)
+	}
+
 	arrs, err := tf.findArrayTypes(ctx, uncached)
 	if err != nil {
 		return nil, fmt.Errorf("find array types: %w", err)
@@ -90,6 +118,11 @@ func (tf *TypeFetcher) FindTypesByOIDs(oids ...uint32) (map[pgtype.OID]Type, err
 	if err := tf.resolvePlaceholderTypes(types); err != nil {
 		return nil, err
 	}
+	// Replace cached types that contained a placeholder, like a domain type,
+	// with the resolved type.
+	for _, typ := range types {
+		tf.cache.addType(typ)
+	}
 
 	if len(uncached) > 0 {
 		return nil, fmt.Errorf("had %d unclassified types: %v", len(uncached), uncached)
@@ -112,6 +145,7 @@ func (tf *TypeFetcher) findEnumTypes(ctx context.Context, uncached map[pgtype.OI
 		types[i] = EnumType{
 			ID:        enum.OID,
 			Name:      enum.TypeName,
+			Schema:    enum.SchemaName,
 			Labels:    enum.Labels,
 			Orders:    enum.Orders,
 			ChildOIDs: childOIDs,
@@ -140,6 +174,17 @@ func (tf *TypeFetcher) findCompositeTypes(ctx context.Context, uncached map[pgty
 
 		colTypes := make([]Type, len(row.ColOIDs))
 		colNames := make([]string, len(row.ColOIDs))
+		// Only tables enforce not null constraints. Postgres ignores the
+		// constraints for composite types created with CREATE TYPE.
+		var colNotNulls []bool
+		if isTableKind(row.TableKind) {
+			colNotNulls = make([]bool, len(row.ColOIDs))
+			for i := range colNotNulls {
+				if i < len(row.ColNotNulls.Elements) {
+					colNotNulls[i] = row.ColNotNulls.Elements[i].Bool
+				}
+			}
+		}
 		// Build each column of the composite type.
 		for i, colOID := range row.ColOIDs {
 			if colType, ok := tf.cache.getOID(uint32(colOID)); ok {
@@ -152,12 +197,17 @@ func (tf *TypeFetcher) findCompositeTypes(ctx context.Context, uncached map[pgty
 				colTypes[i] = placeholderType{ID: pgtype.OID(colOID)}
 				colNames[i] = row.ColNames[i]
 			}
+			if i < len(row.ColDims) {
+				colTypes[i] = WithDimensions(colTypes[i], row.ColDims[i])
+			}
 		}
 		typ := CompositeType{
-			ID:          row.TableTypeOID,
-			Name:        row.TableName.String,
-			ColumnNames: colNames,
-			ColumnTypes: colTypes,
+			ID:             row.TableTypeOID,
+			Name:           row.TableName.String,
+			Schema:         row.SchemaName,
+			ColumnNames:    colNames,
+			ColumnTypes:    colTypes,
+			ColumnNotNulls: colNotNulls,
 		}
 		tf.cache.addType(typ)
 		types = append(types, typ)
@@ -165,6 +215,77 @@ func (tf *TypeFetcher) findCompositeTypes(ctx context.Context, uncached map[pgty
 	return types, nil
 }
 
+// findRangeTypes finds all range types and multirange types in uncached.
+// Postgres creates a multirange type for each range type in Postgres 14 and
+// later, so a range type might not have a multirange type. Each row has both a
+// range and its multirange, but we only return the types in uncached.
+func (tf *TypeFetcher) findRangeTypes(ctx context.Context, uncached map[pgtype.OID]struct{}) ([]RangeType, []MultirangeType, error) {
+	oids := oidKeys(uncached)
+	rows, err := tf.querier.FindRangeTypes(ctx, oids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find range types: %w", err)
+	}
+	rngs := make([]RangeType, 0, len(rows))
+	multis := make([]MultirangeType, 0, len(rows))
+	for _, row := range rows {
+		subtype, ok := tf.cache.getOID(uint32(row.SubtypeOID))
+		if !ok {
+			// We might resolve the subtype in a future pass like findArrayTypes.
+			subtype = placeholderType{ID: row.SubtypeOID}
+		}
+		rng := RangeType{
+			ID:      row.OID,
+			Name:    row.TypeName,
+			Schema:  row.SchemaName,
+			Subtype: subtype,
+		}
+		if _, ok := uncached[rng.ID]; ok {
+			rngs = append(rngs, rng)
+		}
+		if _, ok := uncached[row.MultirangeOID]; ok && row.MultirangeOID != 0 {
+			multis = append(multis, MultirangeType{
+				ID:     row.MultirangeOID,
+				Name:   row.MultirangeName.String,
+				Schema: row.MultirangeSchemaName.String,
+				Range:  rng,
+			})
+		}
+	}
+	return rngs, multis, nil
+}
+
+// findDomainTypes finds all domain types in uncached. The base type of a domain
+// is usually a placeholder that we resolve after finding all other types.
+func (tf *TypeFetcher) findDomainTypes(ctx context.Context, uncached map[pgtype.OID]struct{}) ([]DomainType, error) {
+	oids := oidKeys(uncached)
+	rows, err := tf.querier.FindDomainTypes(ctx, oids)
+	if err != nil {
+		return nil, fmt.Errorf("find domain types: %w", err)
+	}
+	types := make([]DomainType, len(rows))
+	for i, row := range rows {
+		dims := 0
+		if row.Dimensions != nil {
+			dims = int(*row.Dimensions)
+		}
+		baseType, ok := tf.cache.getOID(uint32(row.BaseTypeOID))
+		if !ok {
+			// We might resolve the base type in a future pass like findArrayTypes.
+			baseType = placeholderType{ID: row.BaseTypeOID}
+		}
+		types[i] = DomainType{
+			ID:         row.OID,
+			Name:       row.TypeName,
+			Schema:     row.SchemaName,
+			IsNotNull:  row.IsNotNull != nil && *row.IsNotNull,
+			HasDefault: row.HasDefault != nil && *row.HasDefault,
+			BaseType:   WithDimensions(baseType, dims),
+			Dimensions: dims,
+		}
+	}
+	return types, nil
+}
+
 func (tf *TypeFetcher) findUnknownTypes(ctx context.Context, uncached map[pgtype.OID]struct{}) ([]UnknownType, error) {
 	oids := oidKeys(uncached)
 	rows, err := tf.querier.FindOIDNames(ctx, oids)
@@ -176,6 +297,7 @@ func (tf *TypeFetcher) findUnknownTypes(ctx context.Context, uncached map[pgtype
 		types[i] = UnknownType{
 			ID:     row.OID,
 			Name:   row.Name.String,
+			Schema: row.SchemaName,
 			PgKind: TypeKind(row.Kind.Int),
 		}
 	}
@@ -195,9 +317,10 @@ func (tf *TypeFetcher) findArrayTypes(ctx context.Context, uncached map[pgtype.O
 			return nil, fmt.Errorf("find type for array elem %s oid=%d", row.TypeName, row.OID)
 		}
 		types[i] = ArrayType{
-			ID:   row.OID,
-			Name: row.TypeName,
-			Elem: elemType,
+			ID:     row.OID,
+			Name:   row.TypeName,
+			Schema: row.SchemaName,
+			Elem:   elemType,
 		}
 	}
 	return types, nil
@@ -226,12 +349,37 @@ func (tf *TypeFetcher) resolvePlaceholderTypes(knownTypes map[pgtype.OID]Type) e
 			}
 			typ.Elem = newType
 			return typ, nil
+		case RangeType:
+			newType, err := resolveType(typ.Subtype)
+			if err != nil {
+				return nil, fmt.Errorf("range %q subtype: %w", typ.Name, err)
+			}
+			typ.Subtype = newType
+			return typ, nil
+		case MultirangeType:
+			newType, err := resolveType(typ.Range)
+			if err != nil {
+				return nil, fmt.Errorf("multirange %q range: %w", typ.Name, err)
+			}
+			rng, ok := newType.(RangeType)
+			if !ok {
+				return nil, fmt.Errorf("multirange %q range: want range type; got %T", typ.Name, newType)
+			}
+			typ.Range = rng
+			return typ, nil
+		case DomainType:
+			newType, err := resolveType(typ.BaseType)
+			if err != nil {
+				return nil, fmt.Errorf("domain %q base type: %w", typ.Name, err)
+			}
+			typ.BaseType = newType
+			return typ, nil
 		case placeholderType:
 			newType, ok := knownTypes[typ.ID]
 			if !ok {
 				return nil, fmt.Errorf("unresolved placeholder type oid=%d", typ.ID)
 			}
-			return newType, nil
+			return WithDimensions(newType, typ.Dimensions), nil
 		default:
 			return typ, nil
 		}
@@ -254,3 +402,9 @@ func oidKeys(os map[pgtype.OID]struct{}) []uint32 {
 	}
 	return oids
 }
+
+// isTableKind returns true if the pg_class.relkind is an ordinary or
+// partitioned table.
+func isTableKind(kind pgtype.QChar) bool {
+	return kind.Int == 'r' || kind.Int == 'p'
+}
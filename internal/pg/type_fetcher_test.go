@@ -80,6 +80,33 @@ func TestNewTypeFetcher(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "int4range",
+			schema:   "",
+			fetchOID: Int4range.ID,
+			wants:    []Type{Int4range},
+		},
+		{
+			name:     "custom range",
+			schema:   `CREATE TYPE floatrange AS RANGE (subtype = float8, subtype_diff = float8mi);`,
+			fetchOID: "floatrange",
+			wants: []Type{
+				RangeType{Name: "floatrange", Subtype: Float8},
+				Float8,
+			},
+		},
+		{
+			name: "custom multirange",
+			schema: `CREATE TYPE floatrange AS RANGE (
+				subtype = float8, subtype_diff = float8mi, multirange_type_name = floatmultirange
+			);`,
+			fetchOID: "floatmultirange",
+			wants: []Type{
+				MultirangeType{Name: "floatmultirange", Range: RangeType{Name: "floatrange", Subtype: Float8}},
+				RangeType{Name: "floatrange", Subtype: Float8},
+				Float8,
+			},
+		},
 		{
 			name:     "composite table",
 			schema:   `CREATE TABLE qux (id text, foo int8);`,
@@ -87,10 +114,27 @@ func TestNewTypeFetcher(t *testing.T) {
 			wants: []Type{
 				Int8,
 				CompositeType{
-					ID:          0, // set in test
-					Name:        "qux",
-					ColumnNames: []string{"id", "foo"},
-					ColumnTypes: []Type{Text, Int8},
+					ID:             0, // set in test
+					Name:           "qux",
+					ColumnNames:    []string{"id", "foo"},
+					ColumnTypes:    []Type{Text, Int8},
+					ColumnNotNulls: []bool{false, false},
+				},
+				Text,
+			},
+		},
+		{
+			name:     "composite table - not null",
+			schema:   `CREATE TABLE qux (id text PRIMARY KEY, foo int8 NOT NULL, bar int8);`,
+			fetchOID: "qux",
+			wants: []Type{
+				Int8,
+				CompositeType{
+					ID:             0, // set in test
+					Name:           "qux",
+					ColumnNames:    []string{"id", "foo", "bar"},
+					ColumnTypes:    []Type{Text, Int8, Int8},
+					ColumnNotNulls: []bool{true, true, false},
 				},
 				Text,
 			},
@@ -121,6 +165,7 @@ func TestNewTypeFetcher(t *testing.T) {
 						},
 						Int8,
 					},
+					ColumnNotNulls: []bool{false, false},
 				},
 				Int8,
 				Text,
@@ -151,6 +196,35 @@ func TestNewTypeFetcher(t *testing.T) {
 				);
 			`),
 		},
+		{
+			name:     "composite table - 2d array",
+			schema:   `CREATE TABLE matrix (cells int4[][]);`,
+			fetchOID: "matrix",
+			wants: []Type{
+				CompositeType{
+					Name:           "matrix",
+					ColumnNames:    []string{"cells"},
+					ColumnTypes:    []Type{ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: Int4, Dimensions: 2}},
+					ColumnNotNulls: []bool{false},
+				},
+				Int4Array,
+				Int4,
+			},
+		},
+		{
+			name:     "domain - 2d array",
+			schema:   `CREATE DOMAIN matrix AS int4[][];`,
+			fetchOID: "matrix",
+			wants: []Type{
+				DomainType{
+					Name:       "matrix",
+					BaseType:   ArrayType{ID: pgtype.Int4ArrayOID, Name: "_int4", Elem: Int4, Dimensions: 2},
+					Dimensions: 2,
+				},
+				Int4Array,
+				Int4,
+			},
+		},
 		{
 			name: "custom base type",
 			schema: texts.Dedent(`
@@ -226,6 +300,13 @@ func TestNewTypeFetcher(t *testing.T) {
 				case CompositeType:
 					typ.ID = findOIDVal(t, typ.Name, querier)
 					wantTypes[i] = typ
+				case RangeType:
+					typ.ID = findOIDVal(t, typ.Name, querier)
+					wantTypes[i] = typ
+				case MultirangeType:
+					typ.ID = findOIDVal(t, typ.Name, querier)
+					typ.Range.ID = findOIDVal(t, typ.Range.Name, querier)
+					wantTypes[i] = typ
 				case UnknownType:
 					typ.ID = findOIDVal(t, typ.Name, querier)
 					wantTypes[i] = typ
@@ -234,10 +315,15 @@ func TestNewTypeFetcher(t *testing.T) {
 				}
 			}
 
+			// Ignore the schema because each test uses a randomly named schema.
 			opts := cmp.Options{
-				cmpopts.IgnoreFields(EnumType{}, "ChildOIDs", "ID"),
-				cmpopts.IgnoreFields(CompositeType{}, "ID"),
-				cmpopts.IgnoreFields(ArrayType{}, "ID"),
+				cmpopts.IgnoreFields(EnumType{}, "ChildOIDs", "ID", "Schema"),
+				cmpopts.IgnoreFields(CompositeType{}, "ID", "Schema"),
+				cmpopts.IgnoreFields(ArrayType{}, "ID", "Schema"),
+				cmpopts.IgnoreFields(DomainType{}, "Schema"),
+				cmpopts.IgnoreFields(RangeType{}, "Schema"),
+				cmpopts.IgnoreFields(MultirangeType{}, "Schema"),
+				cmpopts.IgnoreFields(UnknownType{}, "Schema"),
 			}
 			sortTypes(wantTypes)
 			sortTypes(gotTypes)
@@ -248,6 +334,64 @@ func TestNewTypeFetcher(t *testing.T) {
 	}
 }
 
+func TestNewTypeFetcher_SchemaQualified(t *testing.T) {
+	conn, cleanup := pgtest.NewPostgresSchemaString(t, `CREATE TYPE status AS ENUM ('open', 'closed');`)
+	defer cleanup()
+	querier := NewQuerier(conn)
+
+	// Create a type with the same name in a second schema.
+	var schema string
+	if err := conn.QueryRow(t.Context(), "SELECT current_schema()").Scan(&schema); err != nil {
+		t.Fatal(err)
+	}
+	otherSchema := schema + "_other"
+	otherSQL := texts.Dedent(`
+		CREATE SCHEMA ` + otherSchema + `;
+		CREATE TYPE ` + otherSchema + `.status AS ENUM ('shipped');
+	`)
+	if _, err := conn.Exec(t.Context(), otherSQL); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if _, err := conn.Exec(t.Context(), "DROP SCHEMA "+otherSchema+" CASCADE"); err != nil {
+			t.Errorf("drop schema %s: %s", otherSchema, err)
+		}
+	}()
+
+	// Act.
+	fetcher := NewTypeFetcher(conn)
+	oid := findOIDVal(t, schema+".status", querier)
+	otherOID := findOIDVal(t, otherSchema+".status", querier)
+	gotTypes, err := fetcher.FindTypesByOIDs(uint32(oid), uint32(otherOID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantTypes := map[pgtype.OID]Type{
+		oid: EnumType{
+			ID:     oid,
+			Name:   "status",
+			Schema: schema,
+			Labels: []string{"open", "closed"},
+			Orders: []float32{1, 2},
+		},
+		otherOID: EnumType{
+			ID:     otherOID,
+			Name:   "status",
+			Schema: otherSchema,
+			Labels: []string{"shipped"},
+			Orders: []float32{1},
+		},
+	}
+	opts := cmpopts.IgnoreFields(EnumType{}, "ChildOIDs")
+	if diff := cmp.Diff(wantTypes, gotTypes, opts); diff != "" {
+		t.Errorf("FindTypesByOIDs() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := QualifiedName(gotTypes[otherOID]), otherSchema+".status"; got != want {
+		t.Errorf("QualifiedName() = %q; want %q", got, want)
+	}
+}
+
 // Get the OID by name if fetchOID was a string, or just return the OID.
 func findOIDVal(t *testing.T, fetchOID interface{}, querier *DBQuerier) pgtype.OID {
 	switch rawOID := fetchOID.(type) {
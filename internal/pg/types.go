@@ -24,6 +24,7 @@ const (
 	KindEnumType        TypeKind = 'e'
 	KindPseudoType      TypeKind = 'p'
 	KindRangeType       TypeKind = 'r'
+	KindMultirangeType  TypeKind = 'm'
 	kindPlaceholderType TypeKind = '?' // pggen only, not part of postgres
 )
 
@@ -41,6 +42,8 @@ func (k TypeKind) String() string {
 		return "PseudoType"
 	case KindRangeType:
 		return "RangeType"
+	case KindMultirangeType:
+		return "MultirangeType"
 	case kindPlaceholderType:
 		return "PlaceholderType"
 	default:
@@ -67,8 +70,16 @@ type (
 		// The name of the type, like _int4. Array types in Postgres typically
 		// begin with an underscore. From pg_type.typname.
 		Name string
+		// pg_namespace.nspname: the schema containing the type, like public.
+		// Empty for well-known types.
+		Schema string
 		// pg_type.typelem: the element type of the array
 		Elem Type
+		// The number of array dimensions. Postgres doesn't track dimensions in the
+		// array type, so we read dimensions from the column definition, like
+		// pg_attribute.attndims, or from pg_type.typndims for domains. Zero or
+		// one means a one-dimensional array.
+		Dimensions int
 	}
 
 	EnumType struct {
@@ -77,6 +88,8 @@ type (
 		//     CREATE TYPE device_type AS ENUM ('foo');
 		// From pg_type.typname.
 		Name string
+		// pg_namespace.nspname: the schema containing the enum, like public.
+		Schema string
 		// All textual labels for this enum in sort order.
 		Labels []string
 		// When an enum type is created, its members are assigned sort-order
@@ -92,9 +105,10 @@ type (
 	DomainType struct {
 		ID         pgtype.OID // pg_type.oid: row identifier
 		Name       string     // pg_type.typname: data type name
+		Schema     string     // pg_namespace.nspname: schema containing the type
 		IsNotNull  bool       // pg_type.typnotnull: domains only, not null constraint for domains
 		HasDefault bool       // pg_type.typdefault: domains only, if there's a default value
-		BaseType   BaseType   // pg_type.typbasetype: domains only, the base type
+		BaseType   Type       // pg_type.typbasetype: domains only, the base type
 		Dimensions int        // pg_type.typndims: domains on array type only, 0 otherwise, number of array dimensions
 	}
 
@@ -103,8 +117,32 @@ type (
 	CompositeType struct {
 		ID          pgtype.OID // pg_class.oid: row identifier
 		Name        string     // pg_class.relname: name of the composite type
+		Schema      string     // pg_namespace.nspname: schema containing the type
 		ColumnNames []string   // pg_attribute.attname: names of the column, in order
 		ColumnTypes []Type     // pg_attribute JOIN pg_type: information about columns of the composite type
+		// pg_attribute.attnotnull: if each column has a not null constraint; only
+		// set for the row type of a table since other composite types can't have
+		// constraints.
+		ColumnNotNulls []bool
+	}
+
+	// RangeType is a range of values of a subtype, like tstzrange or a
+	// user-defined range type created with CREATE TYPE floatrange AS RANGE.
+	// https://www.postgresql.org/docs/14/catalog-pg-range.html
+	RangeType struct {
+		ID      pgtype.OID // pg_range.rngtypid: row identifier
+		Name    string     // pg_type.typname: data type name
+		Schema  string     // pg_namespace.nspname: schema containing the type; empty for well-known types
+		Subtype Type       // pg_range.rngsubtype: the element type of the range
+	}
+
+	// MultirangeType is an ordered list of non-overlapping ranges. Postgres 14
+	// added multirange types and creates a multirange for each range type.
+	MultirangeType struct {
+		ID     pgtype.OID // pg_range.rngmultitypid: row identifier
+		Name   string     // pg_type.typname: data type name
+		Schema string     // pg_namespace.nspname: schema containing the type; empty for well-known types
+		Range  RangeType  // pg_range.rngtypid: the range type of each element
 	}
 
 	// UnknownType is a Postgres type that's not a well-known type in
@@ -114,6 +152,7 @@ type (
 	UnknownType struct {
 		ID     pgtype.OID // pg_type.oid: row identifier
 		Name   string     // pg_type.typname: data type name
+		Schema string     // pg_namespace.nspname: schema containing the type
 		PgKind TypeKind
 	}
 
@@ -123,10 +162,63 @@ type (
 	// requires two passes for cases like when a composite type has a child type
 	// that's an array.
 	placeholderType struct {
-		ID pgtype.OID // pg_type.oid: row identifier
+		ID         pgtype.OID // pg_type.oid: row identifier
+		Dimensions int        // array dimensions to apply to the resolved type
 	}
 )
 
+// WithDimensions returns typ with the number of array dimensions set to dims
+// if typ is an array type and dims is greater than one. Returns typ unchanged
+// for all other types.
+func WithDimensions(typ Type, dims int) Type {
+	if dims <= 1 {
+		return typ
+	}
+	switch typ := typ.(type) {
+	case ArrayType:
+		typ.Dimensions = dims
+		return typ
+	case placeholderType:
+		typ.Dimensions = dims
+		return typ
+	default:
+		return typ
+	}
+}
+
+// SchemaName returns the schema containing typ, like "public", from
+// pg_namespace.nspname. Returns an empty string for types without a known
+// schema, like well-known base types.
+func SchemaName(typ Type) string {
+	switch typ := typ.(type) {
+	case ArrayType:
+		return typ.Schema
+	case EnumType:
+		return typ.Schema
+	case DomainType:
+		return typ.Schema
+	case CompositeType:
+		return typ.Schema
+	case RangeType:
+		return typ.Schema
+	case MultirangeType:
+		return typ.Schema
+	case UnknownType:
+		return typ.Schema
+	default:
+		return ""
+	}
+}
+
+// QualifiedName returns the schema-qualified name of typ, like
+// "billing.status", or the unqualified name if typ has no known schema.
+func QualifiedName(typ Type) string {
+	if schema := SchemaName(typ); schema != "" {
+		return schema + "." + typ.String()
+	}
+	return typ.String()
+}
+
 func (b BaseType) OID() pgtype.OID { return b.ID }
 func (b BaseType) String() string  { return b.Name }
 func (b BaseType) Kind() TypeKind  { return KindBaseType }
@@ -151,6 +243,18 @@ func (e CompositeType) OID() pgtype.OID { return e.ID }
 func (e CompositeType) String() string  { return e.Name }
 func (e CompositeType) Kind() TypeKind  { return KindCompositeType }
 
+// IsRecord returns true if the composite type describes the fields of an
+// anonymous record, like ROW(1, 'foo'), instead of a named composite type.
+func (e CompositeType) IsRecord() bool { return e.ID == pgtype.RecordOID }
+
+func (r RangeType) OID() pgtype.OID { return r.ID }
+func (r RangeType) String() string  { return r.Name }
+func (r RangeType) Kind() TypeKind  { return KindRangeType }
+
+func (m MultirangeType) OID() pgtype.OID { return m.ID }
+func (m MultirangeType) String() string  { return m.Name }
+func (m MultirangeType) Kind() TypeKind  { return KindMultirangeType }
+
 func (e UnknownType) OID() pgtype.OID { return e.ID }
 func (e UnknownType) String() string  { return e.Name }
 func (e UnknownType) Kind() TypeKind  { return e.PgKind }
@@ -19,9 +19,17 @@ const (
 
 // Plan is the plan output from an EXPLAIN query.
 type Plan struct {
-	Type     PlanType
-	Relation string   // target relation if any
-	Outputs  []string // the output expressions if any
+	Type      PlanType
+	Relation  string         // target relation if any
+	Outputs   []string       // the output expressions if any
+	Relations []PlanRelation // relations scanned by any node in the plan tree
+}
+
+// PlanRelation is a table or view scanned by a node in the plan tree.
+type PlanRelation struct {
+	Schema string // schema containing the relation, like "public"
+	Name   string // name of the relation, like "author"
+	Alias  string // alias used in output expressions, like "a" in "a.author_id"
 }
 
 type ExplainQueryResultRow struct {
@@ -74,8 +82,33 @@ func (inf *Inferrer) explainQuery(query *ast.SourceQuery) (Plan, error) {
 		strOuts[i] = out
 	}
 	return Plan{
-		Type:     PlanType(strNode),
-		Relation: relationStr,
-		Outputs:  strOuts,
+		Type:      PlanType(strNode),
+		Relation:  relationStr,
+		Outputs:   strOuts,
+		Relations: findPlanRelations(plan, nil),
 	}, nil
 }
+
+// findPlanRelations appends the distinct relations scanned by node and all
+// child nodes to rels.
+func findPlanRelations(node map[string]interface{}, rels []PlanRelation) []PlanRelation {
+	if name, ok := node["Relation Name"].(string); ok {
+		schema, _ := node["Schema"].(string)
+		alias, _ := node["Alias"].(string)
+		rel := PlanRelation{Schema: schema, Name: name, Alias: alias}
+		isDupe := false
+		for _, r := range rels {
+			isDupe = isDupe || r.Alias == rel.Alias
+		}
+		if !isDupe {
+			rels = append(rels, rel)
+		}
+	}
+	children, _ := node["Plans"].([]interface{})
+	for _, child := range children {
+		if child, ok := child.(map[string]interface{}); ok {
+			rels = findPlanRelations(child, rels)
+		}
+	}
+	return rels
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,7 +26,8 @@ type TypedQuery struct {
 	// Name of the query, from the comment preceding the query. Like 'FindAuthors'
 	// in the source SQL: "-- name: FindAuthors :many"
 	Name string
-	// The result output kind, :one, :many, or :exec.
+	// The result output kind, :one, :opt, :many, :map, :iter, :exec,
+	// :execrows, or :copyfrom.
 	ResultKind ast.ResultKind
 	// The comment lines preceding the query, without the SQL comment syntax and
 	// excluding the :name line.
@@ -39,6 +42,27 @@ type TypedQuery struct {
 	// Qualified protocol buffer message type to use for each output row, like
 	// "erp.api.Product". If empty, generate our own Row type.
 	ProtobufType string
+	// Fully qualified Go types for input params and output columns, keyed by
+	// the param or column name, from go-type pragmas like
+	// go-type=settings:example.com/user.Settings.
+	GoTypes map[string]string
+	// The table and columns to insert into for a :copyfrom query.
+	CopyFrom ast.CopyFromTarget
+	// The number of rows to fetch at a time with a server-side cursor, from
+	// the cursor pragma. Zero if the query has no cursor method.
+	CursorSize int
+	// The number of rows an :exec or :execrows query must affect, from the
+	// affected pragma. Nil if the query doesn't check the affected rows.
+	AffectedRows *int
+	// The output column to key the rows of a :map query by, from the key
+	// pragma.
+	MapKey string
+	// The output column to group the rows of a :many query by, from the
+	// group-by pragma.
+	GroupBy string
+	// The name of the Go struct for output rows shared by queries with the
+	// same row-type pragma. Empty if the query declares its own row struct.
+	RowType string
 }
 
 // InputParam is an input parameter for a prepared query.
@@ -60,6 +84,11 @@ type OutputColumn struct {
 	// with a NOT NULL constraint can still be null in the output with a left
 	// join. Nullability is determined using rudimentary control-flow analysis.
 	Nullable bool
+	// The table and table column backing the output column, like "author" and
+	// "first_name" in "SELECT a.first_name AS name FROM author a". Empty if the
+	// output column isn't a table column, like an expression.
+	TableName   string
+	TableColumn string
 }
 
 type Inferrer struct {
@@ -81,18 +110,35 @@ func (inf *Inferrer) InferTypes(query *ast.SourceQuery) (TypedQuery, error) {
 	if err != nil {
 		return TypedQuery{}, fmt.Errorf("infer output types for query: %w", err)
 	}
-	if query.ResultKind != ast.ResultKindExec && len(outputs) == 0 {
+	if query.ResultKind == ast.ResultKindCopyFrom && len(outputs) > 0 {
+		return TypedQuery{}, fmt.Errorf(
+			"query %s has incompatible result kind %s; the query returns columns; "+
+				"remove the RETURNING clause",
+			query.Name, query.ResultKind)
+	}
+	isExec := query.ResultKind == ast.ResultKindExec || query.ResultKind == ast.ResultKindExecRows ||
+		query.ResultKind == ast.ResultKindCopyFrom
+	if !isExec && len(outputs) == 0 {
 		return TypedQuery{}, fmt.Errorf(
 			"query %s has incompatible result kind %s; the query doesn't return any columns; "+
 				"use :exec if query shouldn't return any columns",
 			query.Name, query.ResultKind)
 	}
-	if query.ResultKind != ast.ResultKindExec && countVoids(outputs) == len(outputs) {
+	if !isExec && countVoids(outputs) == len(outputs) {
 		return TypedQuery{}, fmt.Errorf(
 			"query %s has incompatible result kind %s; the query only has void columns; "+
 				"use :exec if query shouldn't return any columns",
 			query.Name, query.ResultKind)
 	}
+	if err := validateGoTypePragmas(query, inputs, outputs); err != nil {
+		return TypedQuery{}, fmt.Errorf("query %s: %w", query.Name, err)
+	}
+	if key := query.Pragmas.MapKey; key != "" && findOutputColumn(outputs, key) == -1 {
+		return TypedQuery{}, fmt.Errorf("query %s: key pragma %q does not match any output column", query.Name, key)
+	}
+	if col := query.Pragmas.GroupBy; col != "" && findOutputColumn(outputs, col) == -1 {
+		return TypedQuery{}, fmt.Errorf("query %s: group-by pragma %q does not match any output column", query.Name, col)
+	}
 	doc := extractDoc(query)
 	return TypedQuery{
 		Name:         query.Name,
@@ -102,9 +148,31 @@ func (inf *Inferrer) InferTypes(query *ast.SourceQuery) (TypedQuery, error) {
 		Inputs:       inputs,
 		Outputs:      outputs,
 		ProtobufType: query.Pragmas.ProtobufType,
+		GoTypes:      query.Pragmas.GoTypes,
+		CopyFrom:     query.CopyFrom,
+		CursorSize:   query.Pragmas.CursorSize,
+		AffectedRows: query.Pragmas.AffectedRows,
+		MapKey:       query.Pragmas.MapKey,
+		GroupBy:      query.Pragmas.GroupBy,
+		RowType:      query.Pragmas.RowType,
 	}, nil
 }
 
+// validateGoTypePragmas checks that each go-type pragma matches an input param
+// or output column.
+func validateGoTypePragmas(query *ast.SourceQuery, inputs []InputParam, outputs []OutputColumn) error {
+	for name := range query.Pragmas.GoTypes {
+		isMatch := findOutputColumn(outputs, name) != -1
+		for _, input := range inputs {
+			isMatch = isMatch || input.PgName == name
+		}
+		if !isMatch {
+			return fmt.Errorf("go-type pragma for %q does not match any param or output column", name)
+		}
+	}
+	return nil
+}
+
 func (inf *Inferrer) prepareTypes(query *ast.SourceQuery) (_a []InputParam, _ []OutputColumn, mErr error) {
 	// Execute the query to get field descriptions of the output columns.
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
@@ -159,6 +227,7 @@ func (inf *Inferrer) prepareTypes(query *ast.SourceQuery) (_a []InputParam, _ []
 		if err != nil {
 			return nil, nil, fmt.Errorf("fetch oid types: %w", err)
 		}
+		paramDims := inferParamDimensions(query.PreparedSQL, len(stmtDesc.ParamOIDs))
 		for i, oid := range stmtDesc.ParamOIDs {
 			inputType, ok := types[pgtype.OID(oid)]
 			if !ok {
@@ -166,7 +235,7 @@ func (inf *Inferrer) prepareTypes(query *ast.SourceQuery) (_a []InputParam, _ []
 			}
 			inputParams = append(inputParams, InputParam{
 				PgName: query.ParamNames[i],
-				PgType: inputType,
+				PgType: pg.WithDimensions(inputType, paramDims[i]),
 			})
 		}
 	}
@@ -181,8 +250,14 @@ func (inf *Inferrer) prepareTypes(query *ast.SourceQuery) (_a []InputParam, _ []
 		return nil, nil, fmt.Errorf("fetch oid types: %w", err)
 	}
 
+	// Table columns backing the output columns.
+	cols, err := inf.fetchOutputColumns(stmtDesc.Fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch output table columns: %w", err)
+	}
+
 	// Output nullability.
-	nullables, err := inf.inferOutputNullability(query, stmtDesc.Fields)
+	nullables, err := inf.inferOutputNullability(query, stmtDesc.Fields, cols)
 	if err != nil {
 		return nil, nil, fmt.Errorf("infer output type nullability: %w", err)
 	}
@@ -195,25 +270,29 @@ func (inf *Inferrer) prepareTypes(query *ast.SourceQuery) (_a []InputParam, _ []
 			return nil, nil, fmt.Errorf("no postgrestype name found for column %s with oid %d", string(desc.Name), desc.DataTypeOID)
 		}
 		outputColumns = append(outputColumns, OutputColumn{
-			PgName:   string(desc.Name),
-			PgType:   pgType,
-			Nullable: nullables[i],
+			PgName:      string(desc.Name),
+			PgType:      pg.WithDimensions(pgType, cols[i].Dimensions),
+			Nullable:    nullables[i],
+			TableName:   cols[i].TableName,
+			TableColumn: cols[i].Name,
 		})
 	}
+
+	// Replace the record pseudo-type with the fields of the record.
+	if err := inf.inferRecordTypes(query, stmtDesc.ParamOIDs, outputColumns); err != nil {
+		return nil, nil, fmt.Errorf("infer record types: %w", err)
+	}
 	return inputParams, outputColumns, nil
 }
 
-// inferOutputNullability infers which of the output columns produced by the
-// query and described by descs can be null.
-func (inf *Inferrer) inferOutputNullability(query *ast.SourceQuery, descs []pgproto3.FieldDescription) ([]bool, error) {
+// fetchOutputColumns fetches the table column backing each output column
+// described by descs. The nth entry is the zero Column if the output column
+// described by descs[n] isn't backed by a table.
+func (inf *Inferrer) fetchOutputColumns(descs []pgproto3.FieldDescription) ([]pg.Column, error) {
+	cols := make([]pg.Column, len(descs))
 	if len(descs) == 0 {
-		return nil, nil
-	}
-	plan, err := inf.explainQuery(query)
-	if err != nil {
-		return nil, err
+		return cols, nil
 	}
-
 	columnKeys := make([]pg.ColumnKey, len(descs))
 	for i, desc := range descs {
 		if desc.TableOID > 0 {
@@ -223,9 +302,24 @@ func (inf *Inferrer) inferOutputNullability(query *ast.SourceQuery, descs []pgpr
 			}
 		}
 	}
-	cols, err := pg.FetchColumns(inf.conn, columnKeys)
+	fetched, err := pg.FetchColumns(inf.conn, columnKeys)
+	if err != nil {
+		return nil, err
+	}
+	copy(cols, fetched)
+	return cols, nil
+}
+
+// inferOutputNullability infers which of the output columns produced by the
+// query and described by descs can be null. cols are the table columns backing
+// each output column, from fetchOutputColumns.
+func (inf *Inferrer) inferOutputNullability(query *ast.SourceQuery, descs []pgproto3.FieldDescription, cols []pg.Column) ([]bool, error) {
+	if len(descs) == 0 {
+		return nil, nil
+	}
+	plan, err := inf.explainQuery(query)
 	if err != nil {
-		return nil, fmt.Errorf("fetch column for nullability: %w", err)
+		return nil, err
 	}
 
 	// The nth entry determines if the output column described by descs[n] is
@@ -246,6 +340,33 @@ func (inf *Inferrer) inferOutputNullability(query *ast.SourceQuery, descs []pgpr
 	return nullables, nil
 }
 
+// paramCastRegexp matches a param cast to an array type, like
+// "$1::int4[][]" or "CAST($1 AS int4[][])". The first group is the param
+// number. The second group is the array brackets.
+var paramCastRegexp = regexp.MustCompile(
+	`(?i)(?:\$(\d+)\s*::\s*|\bCAST\s*\(\s*\$(\d+)\s+AS\s+)[\w."]+((?:\s*\[\s*\d*\s*\])+)`)
+
+// inferParamDimensions infers the number of array dimensions of each of the n
+// params in the prepared sql. Postgres doesn't track the dimensions of params,
+// so we count the brackets of the array type the param is cast to, like 2 for
+// pggen.arg('m')::int4[][]. The nth entry is 0 if the param isn't cast to an
+// array type.
+func inferParamDimensions(sql string, n int) []int {
+	dims := make([]int, n)
+	for _, m := range paramCastRegexp.FindAllStringSubmatch(sql, -1) {
+		num := m[1]
+		if num == "" {
+			num = m[2]
+		}
+		idx, err := strconv.Atoi(num)
+		if err != nil || idx < 1 || idx > n {
+			continue
+		}
+		dims[idx-1] = max(dims[idx-1], strings.Count(m[3], "["))
+	}
+	return dims
+}
+
 func createParamArgs(query *ast.SourceQuery) []interface{} {
 	args := make([]interface{}, len(query.ParamNames))
 	for i := range query.ParamNames {
@@ -6,10 +6,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jackc/pgtype"
 	"github.com/jschaf/pggen/internal/ast"
 	"github.com/jschaf/pggen/internal/difftest"
 	"github.com/jschaf/pggen/internal/pg"
 	"github.com/jschaf/pggen/internal/pgtest"
+	"github.com/jschaf/pggen/internal/ptrs"
 	"github.com/jschaf/pggen/internal/texts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -149,7 +151,7 @@ func TestInferrer_InferTypes(t *testing.T) {
 					{PgName: "FirstName", PgType: pg.Text},
 				},
 				Outputs: []OutputColumn{
-					{PgName: "first_name", PgType: pg.Text, Nullable: false},
+					{PgName: "first_name", PgType: pg.Text, Nullable: false, TableName: "author", TableColumn: "first_name"},
 				},
 			},
 		},
@@ -171,7 +173,24 @@ func TestInferrer_InferTypes(t *testing.T) {
 					{PgName: "FirstName", PgType: pg.Text},
 				},
 				Outputs: []OutputColumn{
-					{PgName: "first_name", PgType: pg.Text, Nullable: true},
+					{PgName: "first_name", PgType: pg.Text, Nullable: true, TableName: "author", TableColumn: "first_name"},
+				},
+			},
+		},
+		{
+			name: "multi-dimensional array param",
+			query: &ast.SourceQuery{
+				Name:        "DeleteAuthorsByIDMatrix",
+				PreparedSQL: "DELETE FROM author WHERE author_id = ANY($1::int4[][]);",
+				ParamNames:  []string{"IDs"},
+				ResultKind:  ast.ResultKindExec,
+			},
+			want: TypedQuery{
+				Name:        "DeleteAuthorsByIDMatrix",
+				ResultKind:  ast.ResultKindExec,
+				PreparedSQL: "DELETE FROM author WHERE author_id = ANY($1::int4[][]);",
+				Inputs: []InputParam{
+					{PgName: "IDs", PgType: pg.WithDimensions(pg.Int4Array, 2)},
 				},
 			},
 		},
@@ -195,6 +214,47 @@ func TestInferrer_InferTypes(t *testing.T) {
 				Outputs: nil,
 			},
 		},
+		{
+			name: "execrows delete by author ID affected",
+			query: &ast.SourceQuery{
+				Name:        "DeleteAuthorByID",
+				PreparedSQL: "DELETE FROM author WHERE author_id = $1;",
+				ParamNames:  []string{"AuthorID"},
+				ResultKind:  ast.ResultKindExecRows,
+				Pragmas:     ast.Pragmas{AffectedRows: ptrs.Int(1)},
+			},
+			want: TypedQuery{
+				Name:        "DeleteAuthorByID",
+				ResultKind:  ast.ResultKindExecRows,
+				PreparedSQL: "DELETE FROM author WHERE author_id = $1;",
+				Inputs: []InputParam{
+					{PgName: "AuthorID", PgType: pg.Int4},
+				},
+				Outputs:      nil,
+				AffectedRows: ptrs.Int(1),
+			},
+		},
+		{
+			name: "copyfrom authors",
+			query: &ast.SourceQuery{
+				Name:        "InsertAuthors",
+				PreparedSQL: "INSERT INTO author (first_name, last_name) VALUES ($1, $2);",
+				ParamNames:  []string{"FirstName", "LastName"},
+				ResultKind:  ast.ResultKindCopyFrom,
+				CopyFrom:    ast.CopyFromTarget{Table: []string{"author"}, Columns: []string{"first_name", "last_name"}},
+			},
+			want: TypedQuery{
+				Name:        "InsertAuthors",
+				ResultKind:  ast.ResultKindCopyFrom,
+				PreparedSQL: "INSERT INTO author (first_name, last_name) VALUES ($1, $2);",
+				Inputs: []InputParam{
+					{PgName: "FirstName", PgType: pg.Text},
+					{PgName: "LastName", PgType: pg.Text},
+				},
+				Outputs:  nil,
+				CopyFrom: ast.CopyFromTarget{Table: []string{"author"}, Columns: []string{"first_name", "last_name"}},
+			},
+		},
 		{
 			name: "delete by author id returning",
 			query: &ast.SourceQuery{
@@ -211,9 +271,9 @@ func TestInferrer_InferTypes(t *testing.T) {
 					{PgName: "AuthorID", PgType: pg.Int4},
 				},
 				Outputs: []OutputColumn{
-					{PgName: "author_id", PgType: pg.Int4, Nullable: false},
-					{PgName: "first_name", PgType: pg.Text, Nullable: false},
-					{PgName: "suffix", PgType: pg.Text, Nullable: true},
+					{PgName: "author_id", PgType: pg.Int4, Nullable: false, TableName: "author", TableColumn: "author_id"},
+					{PgName: "first_name", PgType: pg.Text, Nullable: false, TableName: "author", TableColumn: "first_name"},
+					{PgName: "suffix", PgType: pg.Text, Nullable: true, TableName: "author", TableColumn: "suffix"},
 				},
 			},
 		},
@@ -233,9 +293,9 @@ func TestInferrer_InferTypes(t *testing.T) {
 					{PgName: "AuthorID", PgType: pg.Int4},
 				},
 				Outputs: []OutputColumn{
-					{PgName: "author_id", PgType: pg.Int4, Nullable: false},
-					{PgName: "first_name", PgType: pg.Text, Nullable: false},
-					{PgName: "suffix", PgType: pg.Text, Nullable: true},
+					{PgName: "author_id", PgType: pg.Int4, Nullable: false, TableName: "author", TableColumn: "author_id"},
+					{PgName: "first_name", PgType: pg.Text, Nullable: false, TableName: "author", TableColumn: "first_name"},
+					{PgName: "suffix", PgType: pg.Text, Nullable: true, TableName: "author", TableColumn: "suffix"},
 				},
 			},
 		},
@@ -295,6 +355,28 @@ func TestInferrer_InferTypes(t *testing.T) {
 				ProtobufType: "foo.Bar",
 			},
 		},
+		{
+			"pragma go type",
+			&ast.SourceQuery{
+				Name:        "PragmaGoType",
+				PreparedSQL: "SELECT 'foo' as two WHERE $1::int8 > 0",
+				ParamNames:  []string{"num"},
+				ResultKind:  ast.ResultKindOne,
+				Pragmas:     ast.Pragmas{GoTypes: map[string]string{"num": "int", "two": "example.com/foo.Text"}},
+			},
+			TypedQuery{
+				Name:        "PragmaGoType",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT 'foo' as two WHERE $1::int8 > 0",
+				Inputs: []InputParam{
+					{PgName: "num", PgType: pg.Int8},
+				},
+				Outputs: []OutputColumn{
+					{PgName: "two", PgType: pg.Text, Nullable: false},
+				},
+				GoTypes: map[string]string{"num": "int", "two": "example.com/foo.Text"},
+			},
+		},
 		{
 			name: "aggregate non-null column has null output",
 			query: &ast.SourceQuery{
@@ -315,6 +397,75 @@ func TestInferrer_InferTypes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "row constructor",
+			query: &ast.SourceQuery{
+				Name:        "RowCtor",
+				PreparedSQL: "SELECT ROW(a.author_id, a.first_name) AS pair FROM author a;",
+				ResultKind:  ast.ResultKindMany,
+			},
+			want: TypedQuery{
+				Name:        "RowCtor",
+				ResultKind:  ast.ResultKindMany,
+				PreparedSQL: "SELECT ROW(a.author_id, a.first_name) AS pair FROM author a;",
+				Outputs: []OutputColumn{
+					{
+						PgName: "pair",
+						PgType: pg.CompositeType{
+							ID:          pgtype.RecordOID,
+							Name:        "RowCtor_pair",
+							ColumnNames: []string{"author_id", "first_name"},
+							ColumnTypes: []pg.Type{pg.Int4, pg.Text},
+						},
+						Nullable: true,
+					},
+				},
+			},
+		},
+		{
+			name: "row constructor not inferred",
+			query: &ast.SourceQuery{
+				Name:        "RowCtorSubquery",
+				PreparedSQL: "SELECT ROW((SELECT 1), 2) AS pair;",
+				ResultKind:  ast.ResultKindOne,
+			},
+			want: TypedQuery{
+				Name:        "RowCtorSubquery",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT ROW((SELECT 1), 2) AS pair;",
+				Outputs: []OutputColumn{
+					{PgName: "pair", PgType: pg.Record, Nullable: false},
+				},
+			},
+		},
+		{
+			name: "pragma record",
+			query: &ast.SourceQuery{
+				Name:        "PragmaRecord",
+				PreparedSQL: "SELECT ROW(1::int8, 'foo'::text) AS pair;",
+				ResultKind:  ast.ResultKindOne,
+				Pragmas: ast.Pragmas{RecordTypes: map[string][]ast.RecordField{
+					"pair": {{Name: "id", Type: "int8"}, {Name: "names", Type: "text[]"}},
+				}},
+			},
+			want: TypedQuery{
+				Name:        "PragmaRecord",
+				ResultKind:  ast.ResultKindOne,
+				PreparedSQL: "SELECT ROW(1::int8, 'foo'::text) AS pair;",
+				Outputs: []OutputColumn{
+					{
+						PgName: "pair",
+						PgType: pg.CompositeType{
+							ID:          pgtype.RecordOID,
+							Name:        "PragmaRecord_pair",
+							ColumnNames: []string{"id", "names"},
+							ColumnTypes: []pg.Type{pg.Int8, pg.TextArray},
+						},
+						Nullable: false,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -323,8 +474,12 @@ func TestInferrer_InferTypes(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			// Ignore the schema because each test uses a randomly named schema.
 			opts := cmp.Options{
-				cmpopts.IgnoreFields(pg.EnumType{}, "ChildOIDs"),
+				cmpopts.IgnoreFields(pg.EnumType{}, "ChildOIDs", "Schema"),
+				cmpopts.IgnoreFields(pg.ArrayType{}, "Schema"),
+				cmpopts.IgnoreFields(pg.CompositeType{}, "Schema"),
+				cmpopts.IgnoreFields(pg.DomainType{}, "Schema"),
 			}
 			difftest.AssertSame(t, tt.want, got, opts)
 		})
@@ -376,6 +531,27 @@ func TestInferrer_InferTypes_Error(t *testing.T) {
 					"the query only has void columns; " +
 					"use :exec if query shouldn't return any columns"),
 		},
+		{
+			&ast.SourceQuery{
+				Name:        "InsertAuthorsReturning",
+				PreparedSQL: "INSERT INTO author (first_name, last_name) VALUES ($1, $2) RETURNING author_id;",
+				ParamNames:  []string{"FirstName", "LastName"},
+				ResultKind:  ast.ResultKindCopyFrom,
+			},
+			errors.New(
+				"query InsertAuthorsReturning has incompatible result kind :copyfrom; " +
+					"the query returns columns; " +
+					"remove the RETURNING clause"),
+		},
+		{
+			&ast.SourceQuery{
+				Name:        "FindAuthorsMap",
+				PreparedSQL: "SELECT author_id, first_name FROM author;",
+				ResultKind:  ast.ResultKindMap,
+				Pragmas:     ast.Pragmas{MapKey: "id"},
+			},
+			errors.New(`query FindAuthorsMap: key pragma "id" does not match any output column`),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.query.Name, func(t *testing.T) {
@@ -387,6 +563,46 @@ func TestInferrer_InferTypes_Error(t *testing.T) {
 	}
 }
 
+func TestSplitExprList(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"a.author_id", []string{"a.author_id"}},
+		{"a.author_id, a.first_name", []string{"a.author_id", "a.first_name"}},
+		{"1, ROW(2, 3), 'a, b'::text", []string{"1", "ROW(2, 3)", "'a, b'::text"}},
+		{"'it''s, ok'::text, a.\"x,y\"", []string{"'it''s, ok'::text", "a.\"x,y\""}},
+		{"(ARRAY[1, 2])[1], 2", []string{"(ARRAY[1, 2])[1]", "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitExprList(tt.expr))
+		})
+	}
+}
+
+func TestInferParamDimensions(t *testing.T) {
+	tests := []struct {
+		sql  string
+		n    int
+		want []int
+	}{
+		{"SELECT $1;", 1, []int{0}},
+		{"SELECT $1::int4;", 1, []int{0}},
+		{"SELECT $1::int4[];", 1, []int{1}},
+		{"SELECT $1::int4[][], $2 :: text [] [] [];", 2, []int{2, 3}},
+		{"SELECT $2::int4[3][3], $1::public.\"my_type\"[];", 2, []int{1, 2}},
+		{"SELECT cast($1 AS int8[][]);", 1, []int{2}},
+		{"SELECT $1::int4[], $1::int4[][];", 1, []int{2}},
+		{"SELECT $3::int4[][];", 2, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			assert.Equal(t, tt.want, inferParamDimensions(tt.sql, tt.n))
+		})
+	}
+}
+
 func newCommentGroup(lines ...string) *ast.CommentGroup {
 	cs := make([]*ast.LineComment, len(lines))
 	for i, line := range lines {
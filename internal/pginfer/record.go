@@ -0,0 +1,239 @@
+package pginfer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/jschaf/pggen/internal/ast"
+	"github.com/jschaf/pggen/internal/pg"
+)
+
+// inferRecordTypes replaces the type of output columns with the record
+// pseudo-type with an anonymous composite type that describes the fields of
+// the record. The fields come from a record pragma, like
+// record=pair(id:int8,name:text), or from a ROW(...) expression in the query
+// plan. Record columns without either, or with a ROW(...) expression we can't
+// infer, keep the record pseudo-type. Only an invalid record pragma is an
+// error.
+func (inf *Inferrer) inferRecordTypes(query *ast.SourceQuery, paramOIDs []uint32, outputs []OutputColumn) error {
+	for col := range query.Pragmas.RecordTypes {
+		idx := findOutputColumn(outputs, col)
+		if idx == -1 {
+			return fmt.Errorf("record pragma for column %q does not match any output column", col)
+		}
+		if outputs[idx].PgType.OID() != pgtype.RecordOID {
+			return fmt.Errorf("record pragma for column %q but column has type %s, not record", col, outputs[idx].PgType.String())
+		}
+	}
+
+	var plan *Plan // only explain the query if needed
+	for i, out := range outputs {
+		if out.PgType.OID() != pgtype.RecordOID {
+			continue
+		}
+		name := query.Name + "_" + out.PgName
+		if fields, ok := query.Pragmas.RecordTypes[out.PgName]; ok {
+			typ, err := inf.declareRecordType(name, fields)
+			if err != nil {
+				return fmt.Errorf("record pragma for column %q: %w", out.PgName, err)
+			}
+			outputs[i].PgType = typ
+			continue
+		}
+		if plan == nil {
+			p, err := inf.explainQuery(query)
+			if err != nil {
+				return err
+			}
+			plan = &p
+		}
+		if i >= len(plan.Outputs) || !isRowExpr(plan.Outputs[i]) {
+			continue
+		}
+		typ, err := inf.inferRowType(name, plan.Outputs[i], plan.Relations, paramOIDs)
+		if err != nil {
+			// Fall back to the record pseudo-type, like for records without a
+			// ROW(...) expression. Declare the fields with a record pragma to
+			// get a struct.
+			continue
+		}
+		outputs[i].PgType = typ
+	}
+	return nil
+}
+
+// declareRecordType creates an anonymous composite type named name from the
+// fields declared in a record pragma.
+func (inf *Inferrer) declareRecordType(name string, fields []ast.RecordField) (pg.CompositeType, error) {
+	exprs := make([]string, len(fields))
+	for i, f := range fields {
+		exprs[i] = "NULL::" + f.Type
+	}
+	descs, err := inf.describeOutputs("SELECT "+strings.Join(exprs, ", "), nil)
+	if err != nil {
+		return pg.CompositeType{}, fmt.Errorf("resolve field types: %w", err)
+	}
+	types, err := inf.findFieldTypes(descs)
+	if err != nil {
+		return pg.CompositeType{}, err
+	}
+	comp := pg.CompositeType{
+		ID:          pgtype.RecordOID,
+		Name:        name,
+		ColumnNames: make([]string, len(fields)),
+		ColumnTypes: make([]pg.Type, len(fields)),
+	}
+	for i, f := range fields {
+		comp.ColumnNames[i] = f.Name
+		comp.ColumnTypes[i] = pg.WithDimensions(types[i], strings.Count(f.Type, "[]"))
+	}
+	return comp, nil
+}
+
+// inferRowType creates an anonymous composite type named name for a row
+// constructor expression from the query plan, like
+// ROW(a.author_id, a.first_name). Infers the field types by preparing a query
+// that selects each argument of the row constructor from the relations in
+// the plan.
+func (inf *Inferrer) inferRowType(name string, expr string, rels []PlanRelation, paramOIDs []uint32) (pg.CompositeType, error) {
+	args := splitExprList(expr[len("ROW(") : len(expr)-1])
+	sql := &strings.Builder{}
+	sql.WriteString("SELECT ")
+	sql.WriteString(strings.Join(args, ", "))
+	for i, rel := range rels {
+		if i == 0 {
+			sql.WriteString(" FROM ")
+		} else {
+			sql.WriteString(", ")
+		}
+		ident := pgx.Identifier{rel.Name}
+		if rel.Schema != "" {
+			ident = pgx.Identifier{rel.Schema, rel.Name}
+		}
+		sql.WriteString(ident.Sanitize())
+		sql.WriteString(" ")
+		sql.WriteString(pgx.Identifier{rel.Alias}.Sanitize())
+	}
+	descs, err := inf.describeOutputs(sql.String(), paramOIDs)
+	if err != nil {
+		return pg.CompositeType{}, fmt.Errorf("describe %s: %w", expr, err)
+	}
+	if len(descs) != len(args) {
+		return pg.CompositeType{}, fmt.Errorf("expected %d fields for %s; got %d", len(args), expr, len(descs))
+	}
+	types, err := inf.findFieldTypes(descs)
+	if err != nil {
+		return pg.CompositeType{}, err
+	}
+	comp := pg.CompositeType{
+		ID:          pgtype.RecordOID,
+		Name:        name,
+		ColumnNames: make([]string, len(descs)),
+		ColumnTypes: make([]pg.Type, len(descs)),
+	}
+	seen := make(map[string]struct{}, len(descs))
+	for i, desc := range descs {
+		// Postgres names the fields of a row constructor f1, f2, etc. Prefer the
+		// column name for simple column references, like a.author_id.
+		fieldName := string(desc.Name)
+		if _, isDupe := seen[fieldName]; isDupe || fieldName == "?column?" {
+			fieldName = "f" + strconv.Itoa(i+1)
+		}
+		seen[fieldName] = struct{}{}
+		comp.ColumnNames[i] = fieldName
+		comp.ColumnTypes[i] = types[i]
+		if types[i].OID() == pgtype.RecordOID && isRowExpr(args[i]) {
+			nested, err := inf.inferRowType(name+"_"+fieldName, args[i], rels, paramOIDs)
+			if err != nil {
+				return pg.CompositeType{}, err
+			}
+			comp.ColumnTypes[i] = nested
+		}
+	}
+	return comp, nil
+}
+
+// describeOutputs prepares sql to get the field descriptions of the output
+// columns.
+func (inf *Inferrer) describeOutputs(sql string, paramOIDs []uint32) ([]pgproto3.FieldDescription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	stmtDesc, err := inf.conn.PgConn().Prepare(ctx, "", sql, paramOIDs)
+	if err != nil {
+		return nil, err
+	}
+	return stmtDesc.Fields, nil
+}
+
+// findFieldTypes returns the Postgres type of each field description.
+func (inf *Inferrer) findFieldTypes(descs []pgproto3.FieldDescription) ([]pg.Type, error) {
+	oids := make([]uint32, len(descs))
+	for i, desc := range descs {
+		oids[i] = desc.DataTypeOID
+	}
+	typesByOID, err := inf.typeFetcher.FindTypesByOIDs(oids...)
+	if err != nil {
+		return nil, fmt.Errorf("fetch oid types: %w", err)
+	}
+	types := make([]pg.Type, len(descs))
+	for i, desc := range descs {
+		typ, ok := typesByOID[pgtype.OID(desc.DataTypeOID)]
+		if !ok {
+			return nil, fmt.Errorf("no postgres type name found for field %s with oid %d", string(desc.Name), desc.DataTypeOID)
+		}
+		types[i] = typ
+	}
+	return types, nil
+}
+
+// findOutputColumn returns the index of the output column named name or -1 if
+// no such output column exists.
+func findOutputColumn(outputs []OutputColumn, name string) int {
+	for i, out := range outputs {
+		if out.PgName == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isRowExpr returns true if expr is a row constructor from the query plan
+// output, like ROW(a.author_id, a.first_name).
+func isRowExpr(expr string) bool {
+	return strings.HasPrefix(expr, "ROW(") && strings.HasSuffix(expr, ")")
+}
+
+// splitExprList splits a comma separated list of SQL expressions, like the
+// arguments of a row constructor. Ignores commas nested in parentheses,
+// string literals, and quoted identifiers.
+func splitExprList(s string) []string {
+	var exprs []string
+	depth, start := 0, 0
+	var quote byte // the open quote character, if any
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			// A doubled quote is an escaped quote and ends, then reopens, the
+			// quoted text, so there's no need to handle it specially.
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			exprs = append(exprs, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(exprs, strings.TrimSpace(s[start:]))
+}